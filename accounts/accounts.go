@@ -0,0 +1,313 @@
+// Package accounts : the clients of a master account and its sub accounts, sharing one connection pool,
+// with the rate limits of each UID tracked across its accounts and calls fanned out to all of them, e.g.
+//
+//	manager := accounts.NewManager(bybit.NewClient())
+//	if err := manager.Load(accounts.FromEnv("BYBIT_ACCOUNT_")); err != nil {
+//		return err
+//	}
+//	balances, err := manager.WalletBalances(bybit.AccountTypeUnified, nil)
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// ErrUnknownAccount : no account of the name was added
+var ErrUnknownAccount = errors.New("unknown account")
+
+// Manager : safe for concurrent use
+type Manager struct {
+	client      *bybit.Client
+	concurrency int
+
+	mu       sync.RWMutex
+	accounts map[string]*account
+	// limits : by UID
+	limits map[string]*rateLimits
+	// uids : looked up by api key
+	uids map[string]string
+}
+
+type account struct {
+	credentials Credentials
+	client      *bybit.Client
+	limits      *rateLimits
+}
+
+// NewManager : the clients of the accounts are clones of client with their credentials,
+// sharing its http.Client and the rest of its configuration
+func NewManager(client *bybit.Client) *Manager {
+	if client == nil {
+		client = bybit.NewClient()
+	}
+	return &Manager{
+		client:   client,
+		accounts: map[string]*account{},
+		limits:   map[string]*rateLimits{},
+		uids:     map[string]string{},
+	}
+}
+
+// WithConcurrency : how many accounts the fan out calls at once, all of them by default
+func (m *Manager) WithConcurrency(concurrency int) *Manager {
+	m.concurrency = concurrency
+
+	return m
+}
+
+// Add : an account, replacing the one of the same name.
+// Without a UID in credentials, the UID of the api key is looked up once with V5().User().GetAPIKey().
+func (m *Manager) Add(name string, credentials Credentials) error {
+	if name == "" {
+		return errors.New("need a name")
+	}
+	if credentials.Key == "" || credentials.Secret == "" {
+		return fmt.Errorf("%s: need a key and a secret", name)
+	}
+	if credentials.UID == "" {
+		uid, err := m.lookUpUID(credentials)
+		if err != nil {
+			return fmt.Errorf("%s: look up the UID of the api key: %w", name, err)
+		}
+		credentials.UID = uid
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	limits, ok := m.limits[credentials.UID]
+	if !ok {
+		limits = newRateLimits()
+		m.limits[credentials.UID] = limits
+	}
+	m.accounts[name] = &account{
+		credentials: credentials,
+		client: m.client.Clone(
+			bybit.WithAuthOption(credentials.Key, credentials.Secret),
+			bybit.WithMiddlewareOption(limits.middleware),
+		),
+		limits: limits,
+	}
+	return nil
+}
+
+// lookUpUID : the userID of the api key, asked once per key
+func (m *Manager) lookUpUID(credentials Credentials) (string, error) {
+	m.mu.RLock()
+	uid, ok := m.uids[credentials.Key]
+	m.mu.RUnlock()
+	if ok {
+		return uid, nil
+	}
+
+	res, err := m.client.Clone(bybit.WithAuthOption(credentials.Key, credentials.Secret)).V5().User().GetAPIKey()
+	if err != nil {
+		return "", err
+	}
+	if res.Result.UserID == 0 {
+		return "", errors.New("no userID in the response")
+	}
+	uid = strconv.Itoa(res.Result.UserID)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uids[credentials.Key] = uid
+	return uid, nil
+}
+
+// Load : the accounts of the providers, a later provider replacing the accounts of the same name
+func (m *Manager) Load(providers ...SecretProvider) error {
+	for _, provider := range providers {
+		credentials, err := provider.Credentials()
+		if err != nil {
+			return err
+		}
+		for name, c := range credentials {
+			if err := m.Add(name, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Names : of the accounts, sorted
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.accounts))
+	for name := range m.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *Manager) account(name string) (*account, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a, ok := m.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAccount, name)
+	}
+	return a, nil
+}
+
+// Client : of the account
+func (m *Manager) Client(name string) (*bybit.Client, error) {
+	a, err := m.account(name)
+	if err != nil {
+		return nil, err
+	}
+	return a.client, nil
+}
+
+// V5 : the V5 services of the account
+func (m *Manager) V5(name string) (bybit.V5ServiceI, error) {
+	client, err := m.Client(name)
+	if err != nil {
+		return nil, err
+	}
+	return client.V5(), nil
+}
+
+// RateLimit : of path for the UID of the account, unknown until a response of path reported it
+func (m *Manager) RateLimit(name, path string) (RateLimitStatus, bool) {
+	a, err := m.account(name)
+	if err != nil {
+		return RateLimitStatus{}, false
+	}
+	return a.limits.status(path)
+}
+
+// Result : of one account in a fan out
+type Result struct {
+	Account string
+	Value   interface{}
+	Err     error
+}
+
+// Results : by account name
+type Results []Result
+
+// Err : the errors of the accounts that failed, nil when none did
+func (r Results) Err() error {
+	errs := Errors{}
+	for _, result := range r {
+		if result.Err != nil {
+			errs[result.Account] = result.Err
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Errors : by account name
+type Errors map[string]error
+
+// Error :
+func (e Errors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %v", name, e[name]))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ForEach : calls fn with the V5 services of every account concurrently, see WithConcurrency.
+// The results are by account name, whether fn failed or not.
+func (m *Manager) ForEach(fn func(name string, service bybit.V5ServiceI) (interface{}, error)) Results {
+	names := m.Names()
+	results := make(Results, len(names))
+	concurrency := m.concurrency
+	if concurrency <= 0 || concurrency > len(names) {
+		concurrency = len(names)
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i].Account = name
+			service, err := m.V5(name)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Value, results[i].Err = fn(name, service)
+		}(i, name)
+	}
+	wg.Wait()
+	return results
+}
+
+// WalletBalances : of the accounts that answered
+type WalletBalances map[string]bybit.V5WalletBalanceResult
+
+// WalletBalances : of every account, the error being Errors of the accounts that failed
+func (m *Manager) WalletBalances(at bybit.AccountType, coins []bybit.Coin) (WalletBalances, error) {
+	results := m.ForEach(func(_ string, service bybit.V5ServiceI) (interface{}, error) {
+		res, err := service.Account().GetWalletBalance(at, coins)
+		if err != nil {
+			return nil, err
+		}
+		return res.Result, nil
+	})
+	balances := WalletBalances{}
+	for _, result := range results {
+		if result.Err == nil {
+			balances[result.Account] = result.Value.(bybit.V5WalletBalanceResult)
+		}
+	}
+	return balances, results.Err()
+}
+
+// TotalEquity : of all the accounts, in USD
+func (b WalletBalances) TotalEquity() (bybit.Decimal, error) {
+	var total bybit.Decimal
+	for name, result := range b {
+		for _, list := range result.List {
+			equity, err := bybit.NewDecimalFromString(list.TotalEquity)
+			if err != nil {
+				return bybit.Decimal{}, fmt.Errorf("%s: totalEquity: %w", name, err)
+			}
+			total = bybit.NewDecimal(total.Add(equity.Decimal))
+		}
+	}
+	return total, nil
+}
+
+// Coins : the wallet balance of every coin summed over all the accounts
+func (b WalletBalances) Coins() (map[bybit.Coin]bybit.Decimal, error) {
+	coins := map[bybit.Coin]bybit.Decimal{}
+	for name, result := range b {
+		for _, list := range result.List {
+			for _, coin := range list.Coin {
+				balance, err := bybit.NewDecimalFromString(coin.WalletBalance)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s walletBalance: %w", name, coin.Coin, err)
+				}
+				coins[coin.Coin] = bybit.NewDecimal(coins[coin.Coin].Add(balance.Decimal))
+			}
+		}
+	}
+	return coins, nil
+}
@@ -0,0 +1,156 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	requests int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestManagerWalletBalances(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+
+	transport := &countingTransport{}
+	client := bybit.NewClient(bybit.WithBaseURLOption(server.URL), bybit.WithHTTPClientOption(&http.Client{Transport: transport}))
+	manager := NewManager(client).WithConcurrency(2)
+	require.NoError(t, manager.Add("main", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret, UID: "1"}))
+	require.NoError(t, manager.Add("sub1", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret, UID: "2"}))
+	require.NoError(t, manager.Add("revoked", Credentials{Key: "revoked-key", Secret: "revoked-secret", UID: "3"}))
+
+	balances, err := manager.WalletBalances(bybit.AccountTypeUnified, nil)
+
+	var errs Errors
+	require.True(t, errors.As(err, &errs), "got %v", err)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs["revoked"].Error(), "10003")
+	require.Len(t, balances, 2)
+	assert.Contains(t, balances, "main")
+	assert.Contains(t, balances, "sub1")
+	equity, err := balances.TotalEquity()
+	require.NoError(t, err)
+	assert.Equal(t, "20453.0436", equity.String())
+	coins, err := balances.Coins()
+	require.NoError(t, err)
+	assert.NotEmpty(t, coins)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&transport.requests), "the accounts share the http.Client")
+}
+
+func TestManagerForEach(t *testing.T) {
+	manager := NewManager(nil)
+	require.NoError(t, manager.Add("b", Credentials{Key: "key-b", Secret: "secret-b", UID: "2"}))
+	require.NoError(t, manager.Add("a", Credentials{Key: "key-a", Secret: "secret-a", UID: "1"}))
+
+	results := manager.ForEach(func(name string, _ bybit.V5ServiceI) (interface{}, error) {
+		if name == "b" {
+			return nil, errors.New("failed")
+		}
+		return name, nil
+	})
+
+	assert.Equal(t, Results{{Account: "a", Value: "a"}, {Account: "b", Err: errors.New("failed")}}, results)
+	assert.EqualError(t, results.Err(), "b: failed")
+	_, err := manager.V5("c")
+	assert.True(t, errors.Is(err, ErrUnknownAccount))
+	assert.Error(t, manager.Add("d", Credentials{Key: "key-d"}))
+}
+
+func TestManagerRateLimit(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+	server.Handle(http.MethodGet, "/v5/account/wallet-balance").RespondRateLimit().RespondFile("v5/account/wallet-balance.json")
+
+	manager := NewManager(bybit.NewClient(bybit.WithBaseURLOption(server.URL)))
+	require.NoError(t, manager.Add("main", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret, UID: "1"}))
+	require.NoError(t, manager.Add("main-readonly", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret, UID: "1"}))
+	require.NoError(t, manager.Add("sub1", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret, UID: "2"}))
+
+	service, err := manager.V5("main")
+	require.NoError(t, err)
+	_, err = service.Account().GetWalletBalance(bybit.AccountTypeUnified, nil)
+	var rateLimit *bybit.RateLimitError
+	require.True(t, errors.As(err, &rateLimit), "got %v", err)
+	status, ok := manager.RateLimit("main-readonly", "/v5/account/wallet-balance")
+	require.True(t, ok, "the accounts of a UID share its limits")
+	assert.Equal(t, 10, status.Limit)
+	assert.Equal(t, 0, status.Remaining)
+	assert.True(t, status.Reset.After(time.Now()))
+
+	service, err = manager.V5("main-readonly")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = service.Account().GetWalletBalanceContext(ctx, bybit.AccountTypeUnified, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "held back until the window resets, got %v", err)
+	assert.Len(t, server.RequestsTo(http.MethodGet, "/v5/account/wallet-balance"), 1)
+
+	_, ok = manager.RateLimit("sub1", "/v5/account/wallet-balance")
+	assert.False(t, ok)
+	service, err = manager.V5("sub1")
+	require.NoError(t, err)
+	_, err = service.Account().GetWalletBalance(bybit.AccountTypeUnified, nil)
+	assert.NoError(t, err, "another UID is not held back")
+}
+
+func TestManagerLooksUpUID(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+
+	manager := NewManager(bybit.NewClient(bybit.WithBaseURLOption(server.URL)))
+	require.NoError(t, manager.Add("main", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret}))
+	require.NoError(t, manager.Add("main-again", Credentials{Key: testhelper.FakeKey, Secret: testhelper.FakeSecret}))
+	require.NoError(t, manager.Add("main-with-uid", Credentials{Key: "other-key", Secret: "other-secret", UID: "24600000"}))
+	assert.Len(t, server.RequestsTo(http.MethodGet, "/v5/user/query-api"), 1, "once per api key")
+
+	main, err := manager.account("main")
+	require.NoError(t, err)
+	assert.Equal(t, "24600000", main.credentials.UID)
+	withUID, err := manager.account("main-with-uid")
+	require.NoError(t, err)
+	assert.Same(t, main.limits, withUID.limits, "the keys of a UID share its limits")
+
+	err = manager.Add("revoked", Credentials{Key: "revoked-key", Secret: "revoked-secret"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "revoked: look up the UID of the api key")
+	assert.Contains(t, err.Error(), "10003")
+	assert.Equal(t, []string{"main", "main-again", "main-with-uid"}, manager.Names())
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("BYBIT_ACCOUNT_MAIN_KEY", "main-key")
+	t.Setenv("BYBIT_ACCOUNT_MAIN_SECRET", "main-secret")
+	t.Setenv("BYBIT_ACCOUNT_MAIN_UID", "1001")
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"sub1": {"key": "sub1-key", "secret": "sub1-secret", "uid": "1002"}}`), 0600))
+
+	manager := NewManager(nil)
+	require.NoError(t, manager.Load(FromEnv("BYBIT_ACCOUNT_"), FromFile(path), SecretProviderFunc(func() (map[string]Credentials, error) {
+		return map[string]Credentials{"sub2": {Key: "sub2-key", Secret: "sub2-secret", UID: "1003"}}, nil
+	})))
+	assert.Equal(t, []string{"main", "sub1", "sub2"}, manager.Names())
+
+	t.Setenv("BYBIT_ACCOUNT_BROKEN_KEY", "broken-key")
+	assert.EqualError(t, NewManager(nil).Load(FromEnv("BYBIT_ACCOUNT_")), "need BYBIT_ACCOUNT_BROKEN_SECRET as environment variable")
+	assert.Error(t, NewManager(nil).Load(FromFile(filepath.Join(t.TempDir(), "missing.json"))))
+}
@@ -0,0 +1,76 @@
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Credentials : of one account
+type Credentials struct {
+	Key    string `json:"key"`
+	Secret string `json:"secret"`
+	// UID : of the account, accounts of the same UID share its rate limits.
+	// When empty it is looked up from the api key when the account is added.
+	UID string `json:"uid,omitempty"`
+}
+
+// SecretProvider : credentials by account name, e.g. read from a vault
+type SecretProvider interface {
+	Credentials() (map[string]Credentials, error)
+}
+
+// SecretProviderFunc : a function as a SecretProvider
+type SecretProviderFunc func() (map[string]Credentials, error)
+
+// Credentials :
+func (f SecretProviderFunc) Credentials() (map[string]Credentials, error) {
+	return f()
+}
+
+// FromEnv : the accounts of the <prefix><NAME>_KEY, <prefix><NAME>_SECRET and optional <prefix><NAME>_UID
+// environment variables, named by NAME in lower case, e.g. BYBIT_ACCOUNT_SUB1_KEY is the key of "sub1"
+// for the prefix BYBIT_ACCOUNT_
+func FromEnv(prefix string) SecretProvider {
+	return SecretProviderFunc(func() (map[string]Credentials, error) {
+		result := map[string]Credentials{}
+		for _, env := range os.Environ() {
+			name := strings.SplitN(env, "=", 2)[0]
+			if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, "_KEY") {
+				continue
+			}
+			account := strings.TrimSuffix(strings.TrimPrefix(name, prefix), "_KEY")
+			if account == "" {
+				continue
+			}
+			secret, ok := os.LookupEnv(prefix + account + "_SECRET")
+			if !ok {
+				return nil, fmt.Errorf("need %s%s_SECRET as environment variable", prefix, account)
+			}
+			result[strings.ToLower(account)] = Credentials{
+				Key:    os.Getenv(name),
+				Secret: secret,
+				UID:    os.Getenv(prefix + account + "_UID"),
+			}
+		}
+		return result, nil
+	})
+}
+
+// FromFile : the accounts of a JSON file mapping their names to their credentials, e.g.
+//
+//	{"main": {"key": "...", "secret": "...", "uid": "1001"}, "sub1": {"key": "...", "secret": "...", "uid": "1002"}}
+func FromFile(path string) SecretProvider {
+	return SecretProviderFunc(func() (map[string]Credentials, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var result map[string]Credentials
+		if err := json.Unmarshal(b, &result); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return result, nil
+	})
+}
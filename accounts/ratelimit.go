@@ -0,0 +1,80 @@
+package accounts
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// RateLimitStatus : of an endpoint for a UID, as the last response reported it
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// rateLimits : of the endpoints of one UID, shared by its accounts
+type rateLimits struct {
+	mu        sync.Mutex
+	endpoints map[string]RateLimitStatus
+}
+
+func newRateLimits() *rateLimits {
+	return &rateLimits{endpoints: map[string]RateLimitStatus{}}
+}
+
+func (l *rateLimits) status(path string) (RateLimitStatus, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	status, ok := l.endpoints[path]
+	return status, ok
+}
+
+// wait : until the window of path resets when nothing remains of it
+func (l *rateLimits) wait(path string) time.Duration {
+	status, ok := l.status(path)
+	if !ok || status.Remaining > 0 {
+		return 0
+	}
+	return time.Until(status.Reset)
+}
+
+func (l *rateLimits) update(path string, header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-Bapi-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-Bapi-Limit-Status"))
+	if err != nil {
+		return
+	}
+	status := RateLimitStatus{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.ParseInt(header.Get("X-Bapi-Limit-Reset-Timestamp"), 10, 64); err == nil {
+		status.Reset = time.Unix(0, reset*int64(time.Millisecond))
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.endpoints[path] = status
+}
+
+// middleware : holds a request back until its window resets when the UID used it up,
+// rather than sending it to be rejected
+func (l *rateLimits) middleware(next bybit.RoundTrip) bybit.RoundTrip {
+	return func(call *bybit.Call) error {
+		if wait := l.wait(call.Path); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-call.Request.Context().Done():
+				timer.Stop()
+				return call.Request.Context().Err()
+			}
+		}
+		err := next(call)
+		l.update(call.Path, call.Header)
+		return err
+	}
+}
@@ -0,0 +1,309 @@
+// Package backtest replays historical klines, trades and funding rates through a strategy
+// that trades on a paper.Exchange, and reports its equity curve, trades and statistics.
+package backtest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/oneart-dev/bybit/candle"
+	"github.com/oneart-dev/bybit/history"
+	"github.com/oneart-dev/bybit/paper"
+)
+
+// Strategy : the hooks are called in the order of the replayed data, an error stops the run
+type Strategy interface {
+	// OnBar : once the bar has closed
+	OnBar(broker *Broker, bar history.Bar) error
+	OnTrade(broker *Broker, trade candle.Trade) error
+	// OnFill : every execution of the exchange, funding included
+	OnFill(broker *Broker, fill bybit.V5GetExecutionOrder) error
+}
+
+// Hooks : a Strategy of functions, nil ones are skipped
+type Hooks struct {
+	Bar   func(broker *Broker, bar history.Bar) error
+	Trade func(broker *Broker, trade candle.Trade) error
+	Fill  func(broker *Broker, fill bybit.V5GetExecutionOrder) error
+}
+
+// OnBar :
+func (h Hooks) OnBar(broker *Broker, bar history.Bar) error {
+	if h.Bar == nil {
+		return nil
+	}
+	return h.Bar(broker, bar)
+}
+
+// OnTrade :
+func (h Hooks) OnTrade(broker *Broker, trade candle.Trade) error {
+	if h.Trade == nil {
+		return nil
+	}
+	return h.Trade(broker, trade)
+}
+
+// OnFill :
+func (h Hooks) OnFill(broker *Broker, fill bybit.V5GetExecutionOrder) error {
+	if h.Fill == nil {
+		return nil
+	}
+	return h.Fill(broker, fill)
+}
+
+// Funding : the rate settled at Time
+type Funding struct {
+	Time time.Time
+	Rate bybit.Decimal
+}
+
+// Data : what is replayed for one symbol. Bars are the ones of history.Downloader, of Interval;
+// each one moves the price from open to the nearer extreme, the other extreme and close before OnBar.
+type Data struct {
+	Category bybit.CategoryV5
+	Symbol   bybit.SymbolV5
+	Interval bybit.Interval
+	Bars     []history.Bar
+	Trades   []candle.Trade
+	Funding  []Funding
+}
+
+// EquityPoint :
+type EquityPoint struct {
+	Time   time.Time
+	Equity bybit.Decimal
+}
+
+// Rejection : an order or cancel sent with latency that the exchange refused
+type Rejection struct {
+	Time        time.Time
+	OrderLinkID string
+	Err         error
+}
+
+// Result :
+type Result struct {
+	// Equity : the total equity after every bar, or every trade when there are no bars
+	Equity []EquityPoint
+	// Fills : every execution, oldest first
+	Fills []bybit.V5GetExecutionOrder
+	// Trades : the closed PnL of every fill that reduced a position, oldest first
+	Trades     []bybit.V5GetClosedPnl
+	Rejections []Rejection
+	Stats      Stats
+}
+
+// Runner : drives a paper.Exchange with simulated time
+type Runner struct {
+	exchange *paper.Exchange
+	strategy Strategy
+	slippage SlippageModel
+	latency  time.Duration
+
+	data       Data
+	now        time.Time
+	broker     *Broker
+	pending    []pendingRequest
+	lastLinkID int
+	result     *Result
+	err        error
+}
+
+// NewRunner : exchange should be fresh with its balance deposited, the runner takes over its clock and OnExecution
+func NewRunner(exchange *paper.Exchange, strategy Strategy) *Runner {
+	r := &Runner{
+		exchange: exchange,
+		strategy: strategy,
+		slippage: NoSlippage{},
+	}
+	exchange.WithClock(func() time.Time { return r.now })
+	exchange.OnExecution(r.onExecution)
+	r.broker = &Broker{runner: r}
+	return r
+}
+
+// WithSlippage : applied to the price market orders, triggered orders, take profits and stop losses fill at
+func (r *Runner) WithSlippage(model SlippageModel) *Runner {
+	r.slippage = model
+	return r
+}
+
+// WithLatency : orders and cancels reach the exchange latency after they are sent, at the last price replayed by then
+func (r *Runner) WithLatency(latency time.Duration) *Runner {
+	r.latency = latency
+	return r
+}
+
+// WithFees : maker and taker rates of the exchange
+func (r *Runner) WithFees(maker, taker bybit.Decimal) *Runner {
+	r.exchange.WithFees(maker, taker)
+	return r
+}
+
+type eventKind int
+
+const (
+	eventPrice eventKind = iota
+	eventBar
+	eventTrade
+	eventFunding
+)
+
+type event struct {
+	time  time.Time
+	kind  eventKind
+	price bybit.Decimal
+	bar   history.Bar
+	trade candle.Trade
+	rate  bybit.Decimal
+}
+
+// Run : replays data to the end, unless the strategy or ctx stop it
+func (r *Runner) Run(ctx context.Context, data Data) (*Result, error) {
+	if data.Category == "" || data.Symbol == "" {
+		return nil, errors.New("category and symbol are required")
+	}
+	events, err := r.events(data)
+	if err != nil {
+		return nil, err
+	}
+	r.data = data
+	r.pending = nil
+	r.result = &Result{}
+	r.err = nil
+
+	for _, e := range events {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r.advance(e.time)
+		switch e.kind {
+		case eventPrice:
+			r.feed(e.price)
+		case eventBar:
+			r.do(func() error { return r.strategy.OnBar(r.broker, e.bar) })
+			r.recordEquity()
+		case eventTrade:
+			r.feed(e.trade.Price)
+			r.do(func() error { return r.strategy.OnTrade(r.broker, e.trade) })
+			if len(data.Bars) == 0 {
+				r.recordEquity()
+			}
+		case eventFunding:
+			r.exchange.ApplyFunding(data.Category, data.Symbol, e.rate)
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+	}
+
+	trades, err := r.closedPnl()
+	if err != nil {
+		return nil, err
+	}
+	r.result.Trades = trades
+	r.result.Stats = newStats(r.result)
+	return r.result, nil
+}
+
+// events : in time order, the path of a bar and its close come before the next bar opens
+func (r *Runner) events(data Data) ([]event, error) {
+	var events []event
+	if len(data.Bars) > 0 {
+		tf, err := candle.NewTimeframe(data.Interval, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, bar := range data.Bars {
+			end := tf.Next(bar.Start)
+			step := end.Sub(bar.Start) / 3
+			first, second := bar.Low, bar.High
+			if bar.Close.LessThan(bar.Open.Decimal) {
+				first, second = bar.High, bar.Low
+			}
+			events = append(events,
+				event{time: bar.Start, kind: eventPrice, price: bar.Open},
+				event{time: bar.Start.Add(step), kind: eventPrice, price: first},
+				event{time: bar.Start.Add(2 * step), kind: eventPrice, price: second},
+				event{time: end.Add(-time.Millisecond), kind: eventPrice, price: bar.Close},
+				event{time: end, kind: eventBar, bar: bar},
+			)
+		}
+	}
+	for _, trade := range data.Trades {
+		events = append(events, event{time: trade.Time, kind: eventTrade, trade: trade})
+	}
+	for _, funding := range data.Funding {
+		events = append(events, event{time: funding.Time, kind: eventFunding, rate: funding.Rate})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].time.Before(events[j].time) })
+	return events, nil
+}
+
+// feed : the mark follows the last price, the slippage widens it to a bid and an ask
+func (r *Runner) feed(price bybit.Decimal) {
+	r.exchange.UpdateQuote(r.data.Category, r.data.Symbol, paper.Quote{
+		Last: price,
+		Bid:  r.slippage.Price(bybit.SideSell, price),
+		Ask:  r.slippage.Price(bybit.SideBuy, price),
+		Mark: price,
+	})
+}
+
+// do : keeps the first error of a hook
+func (r *Runner) do(hook func() error) {
+	if r.err != nil {
+		return
+	}
+	if err := hook(); err != nil {
+		r.err = err
+	}
+}
+
+func (r *Runner) onExecution(fill bybit.V5GetExecutionOrder) {
+	if r.result == nil {
+		return
+	}
+	r.result.Fills = append(r.result.Fills, fill)
+	r.do(func() error { return r.strategy.OnFill(r.broker, fill) })
+}
+
+func (r *Runner) recordEquity() {
+	res, err := r.exchange.GetWalletBalance(bybit.AccountTypeUnified, nil)
+	if err != nil {
+		r.err = err
+		return
+	}
+	equity, err := bybit.NewDecimalFromString(res.Result.List[0].TotalEquity)
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.result.Equity = append(r.result.Equity, EquityPoint{Time: r.now, Equity: equity})
+}
+
+// closedPnl : all pages, oldest first
+func (r *Runner) closedPnl() ([]bybit.V5GetClosedPnl, error) {
+	var list []bybit.V5GetClosedPnl
+	limit := 100
+	param := bybit.V5GetClosedPnlParam{Category: r.data.Category, Symbol: &r.data.Symbol, Limit: &limit}
+	for {
+		res, err := r.exchange.GetClosedPnl(param)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, res.Result.List...)
+		if res.Result.NextPageCursor == "" {
+			break
+		}
+		cursor := res.Result.NextPageCursor
+		param.Cursor = &cursor
+	}
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list, nil
+}
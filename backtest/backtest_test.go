@@ -0,0 +1,162 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/oneart-dev/bybit/history"
+	"github.com/oneart-dev/bybit/paper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2023, 11, 29, 0, 0, 0, 0, time.UTC)
+
+func bars() []history.Bar {
+	ohlc := [][4]string{
+		{"100", "110", "95", "105"},
+		{"105", "120", "104", "118"},
+		{"118", "119", "100", "101"},
+		{"101", "102", "90", "91"},
+	}
+	var list []history.Bar
+	for i, prices := range ohlc {
+		list = append(list, history.Bar{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			Open:  bybit.RequireDecimal(prices[0]),
+			High:  bybit.RequireDecimal(prices[1]),
+			Low:   bybit.RequireDecimal(prices[2]),
+			Close: bybit.RequireDecimal(prices[3]),
+		})
+	}
+	return list
+}
+
+func data() Data {
+	return Data{
+		Category: bybit.CategoryV5Linear,
+		Symbol:   bybit.SymbolV5BTCUSDT,
+		Interval: bybit.Interval60,
+		Bars:     bars(),
+	}
+}
+
+func marketOrder(broker *Broker, side bybit.Side) error {
+	_, err := broker.Order().CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: bybit.SymbolV5BTCUSDT, Side: side, OrderType: bybit.OrderTypeMarket, Qty: "1",
+	})
+	return err
+}
+
+func newExchange() *paper.Exchange {
+	return paper.NewExchange().WithBalance(bybit.CoinUSDT, bybit.RequireDecimal("1000")).WithDefaultLeverage(bybit.RequireDecimal("1"))
+}
+
+func TestRun(t *testing.T) {
+	var fills, barCount int
+	strategy := Hooks{
+		// buys on even bars and sells on odd ones
+		Bar: func(broker *Broker, bar history.Bar) error {
+			barCount++
+			side := bybit.SideBuy
+			if barCount%2 == 0 {
+				side = bybit.SideSell
+			}
+			return marketOrder(broker, side)
+		},
+		Fill: func(broker *Broker, fill bybit.V5GetExecutionOrder) error {
+			fills++
+			return nil
+		},
+	}
+	d := data()
+	d.Funding = []Funding{{Time: start.Add(210 * time.Minute), Rate: bybit.RequireDecimal("0.0001")}}
+
+	result, err := NewRunner(newExchange(), strategy).
+		WithFees(bybit.RequireDecimal("0"), bybit.RequireDecimal("0.001")).
+		WithSlippage(FixedSlippage{Amount: bybit.RequireDecimal("0.5")}).
+		Run(context.Background(), d)
+	require.NoError(t, err)
+
+	require.Len(t, result.Fills, 5)
+	assert.Equal(t, 5, fills)
+	assert.Equal(t, "105.5", result.Fills[0].ExecPrice)
+	assert.Equal(t, "117.5", result.Fills[1].ExecPrice)
+	assert.Equal(t, "101.5", result.Fills[2].ExecPrice)
+	assert.Equal(t, bybit.ExecTypeFunding, result.Fills[3].ExecType)
+	assert.Equal(t, "102", result.Fills[3].ExecPrice, "the high of the falling bar comes first")
+	assert.Equal(t, "90.5", result.Fills[4].ExecPrice)
+
+	require.Len(t, result.Trades, 2)
+	assert.Equal(t, "11.777", result.Trades[0].ClosedPnl)
+	assert.Equal(t, "-11.192", result.Trades[1].ClosedPnl)
+
+	require.Len(t, result.Equity, 4)
+	assert.Equal(t, start.Add(time.Hour), result.Equity[0].Time)
+	assert.Equal(t, "999.3945", result.Equity[0].Equity.String())
+	assert.Equal(t, "1011.777", result.Equity[1].Equity.String())
+	assert.Equal(t, "1000.5748", result.Equity[3].Equity.String())
+
+	stats := result.Stats
+	assert.Equal(t, 2, stats.Trades)
+	assert.Equal(t, 0.5, stats.WinRate)
+	assert.Equal(t, "0.4150", stats.Fees.String())
+	assert.Equal(t, "0.0102", stats.Funding.String())
+	assert.InDelta(t, 0.0011818, stats.Return, 1e-6)
+	assert.InDelta(t, 11.2022/1011.777, stats.MaxDrawdown, 1e-9)
+	assert.NotZero(t, stats.Sharpe)
+}
+
+func TestRunLatency(t *testing.T) {
+	strategy := Hooks{
+		Bar: func(broker *Broker, bar history.Bar) error {
+			if !bar.Start.Equal(start) {
+				return nil
+			}
+			if err := marketOrder(broker, bybit.SideBuy); err != nil {
+				return err
+			}
+			linkID, price := "far", "50"
+			_, err := broker.Order().CreateOrder(bybit.V5CreateOrderParam{
+				Category: bybit.CategoryV5Linear, Symbol: bybit.SymbolV5BTCUSDT, Side: bybit.SideBuy, OrderType: bybit.OrderTypeLimit,
+				Qty: "1", Price: &price, OrderLinkID: &linkID,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := broker.Order().CancelOrder(bybit.V5CancelOrderParam{
+				Category: bybit.CategoryV5Linear, Symbol: bybit.SymbolV5BTCUSDT, OrderLinkID: &linkID,
+			}); err != nil {
+				return err
+			}
+			_, err = broker.Order().CreateOrder(bybit.V5CreateOrderParam{
+				Category: bybit.CategoryV5Linear, Symbol: bybit.SymbolV5BTCUSDT, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: "0",
+			})
+			return err
+		},
+	}
+
+	result, err := NewRunner(newExchange(), strategy).WithLatency(30*time.Minute).Run(context.Background(), data())
+	require.NoError(t, err)
+	require.Len(t, result.Fills, 1)
+	assert.Equal(t, "104", result.Fills[0].ExecPrice, "the low reached before the order")
+	assert.Equal(t, start.Add(90*time.Minute), result.Fills[0].ExecTime.Time, "when it is due, between the replayed prices")
+	assert.Equal(t, "backtest-1", result.Fills[0].OrderLinkID)
+	require.Len(t, result.Rejections, 1)
+	assert.Equal(t, "backtest-2", result.Rejections[0].OrderLinkID)
+}
+
+func TestRunStops(t *testing.T) {
+	stop := errors.New("stop")
+	strategy := Hooks{Bar: func(broker *Broker, bar history.Bar) error { return stop }}
+	_, err := NewRunner(newExchange(), strategy).Run(context.Background(), data())
+	assert.Equal(t, stop, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewRunner(newExchange(), Hooks{}).Run(ctx, data())
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,189 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// SlippageModel : the price a taker on side gets when the market trades at price
+type SlippageModel interface {
+	Price(side bybit.Side, price bybit.Decimal) bybit.Decimal
+}
+
+// NoSlippage : takers fill at the last price
+type NoSlippage struct{}
+
+// Price :
+func (NoSlippage) Price(side bybit.Side, price bybit.Decimal) bybit.Decimal {
+	return price
+}
+
+// FixedSlippage : takers pay Amount over the last price, e.g. half the usual spread
+type FixedSlippage struct {
+	Amount bybit.Decimal
+}
+
+// Price :
+func (s FixedSlippage) Price(side bybit.Side, price bybit.Decimal) bybit.Decimal {
+	if side == bybit.SideSell {
+		return bybit.NewDecimal(price.Sub(s.Amount.Decimal))
+	}
+	return bybit.NewDecimal(price.Add(s.Amount.Decimal))
+}
+
+// RateSlippage : takers pay Rate of the last price, e.g. 0.0005 for 5 bps
+type RateSlippage struct {
+	Rate bybit.Decimal
+}
+
+// Price :
+func (s RateSlippage) Price(side bybit.Side, price bybit.Decimal) bybit.Decimal {
+	move := price.Mul(s.Rate.Decimal)
+	if side == bybit.SideSell {
+		return bybit.NewDecimal(price.Sub(move))
+	}
+	return bybit.NewDecimal(price.Add(move))
+}
+
+// Broker : what a strategy trades through, the services are the ones of the paper exchange
+type Broker struct {
+	runner *Runner
+}
+
+// Now : the simulated time
+func (b *Broker) Now() time.Time {
+	return b.runner.now
+}
+
+// Data : being replayed
+func (b *Broker) Data() Data {
+	return b.runner.data
+}
+
+// Order : CreateOrder and CancelOrder are delayed by the latency of the runner.
+// A delayed CreateOrder answers with the orderLinkId only, one is generated when none is given.
+func (b *Broker) Order() bybit.V5OrderServiceI {
+	if b.runner.latency == 0 {
+		return b.runner.exchange
+	}
+	return &delayedOrderService{V5OrderServiceI: b.runner.exchange, runner: b.runner}
+}
+
+// Position :
+func (b *Broker) Position() bybit.V5PositionServiceI {
+	return b.runner.exchange
+}
+
+// Account :
+func (b *Broker) Account() bybit.V5AccountServiceI {
+	return b.runner.exchange
+}
+
+type pendingRequest struct {
+	due    time.Time
+	create *bybit.V5CreateOrderParam
+	cancel *bybit.V5CancelOrderParam
+}
+
+type delayedOrderService struct {
+	bybit.V5OrderServiceI
+	runner *Runner
+}
+
+// CreateOrder :
+func (s *delayedOrderService) CreateOrder(param bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error) {
+	r := s.runner
+	if param.OrderLinkID == nil {
+		r.lastLinkID++
+		linkID := fmt.Sprintf("backtest-%d", r.lastLinkID)
+		param.OrderLinkID = &linkID
+	}
+	r.pending = append(r.pending, pendingRequest{due: r.now.Add(r.latency), create: &param})
+	return &bybit.V5CreateOrderResponse{
+		CommonV5Response: bybit.CommonV5Response{RetMsg: "OK", RetExtInfo: map[string]interface{}{}, Time: int(r.now.UnixMilli())},
+		Result:           bybit.V5CreateOrderResult{OrderLinkID: *param.OrderLinkID},
+	}, nil
+}
+
+// CancelOrder : an order still on its way is cancelled before it arrives
+func (s *delayedOrderService) CancelOrder(param bybit.V5CancelOrderParam) (*bybit.V5CancelOrderResponse, error) {
+	r := s.runner
+	r.pending = append(r.pending, pendingRequest{due: r.now.Add(r.latency), cancel: &param})
+	result := bybit.V5CancelOrderResult{}
+	if param.OrderID != nil {
+		result.OrderID = *param.OrderID
+	}
+	if param.OrderLinkID != nil {
+		result.OrderLinkID = *param.OrderLinkID
+	}
+	return &bybit.V5CancelOrderResponse{
+		CommonV5Response: bybit.CommonV5Response{RetMsg: "OK", RetExtInfo: map[string]interface{}{}, Time: int(r.now.UnixMilli())},
+		Result:           result,
+	}, nil
+}
+
+// CreateOrderContext :
+func (s *delayedOrderService) CreateOrderContext(_ context.Context, param bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error) {
+	return s.CreateOrder(param)
+}
+
+// CancelOrderContext :
+func (s *delayedOrderService) CancelOrderContext(_ context.Context, param bybit.V5CancelOrderParam) (*bybit.V5CancelOrderResponse, error) {
+	return s.CancelOrder(param)
+}
+
+// advance : moves the clock to t, stopping at the due time of every request arriving before,
+// so that it reaches the exchange when it is due and meets the last price replayed by then
+func (r *Runner) advance(t time.Time) {
+	for len(r.pending) > 0 && r.pending[0].due.Before(t) && r.err == nil {
+		r.now = r.pending[0].due
+		r.submitDue()
+	}
+	r.now = t
+	r.submitDue()
+}
+
+// submitDue : sends the requests that have arrived by now, in the order they were made
+func (r *Runner) submitDue() {
+	for len(r.pending) > 0 && !r.pending[0].due.After(r.now) {
+		request := r.pending[0]
+		r.pending = r.pending[1:]
+		if request.cancel != nil {
+			if r.cancelPending(*request.cancel) {
+				continue
+			}
+			if _, err := r.exchange.CancelOrder(*request.cancel); err != nil {
+				r.reject(request.cancel.OrderLinkID, err)
+			}
+			continue
+		}
+		if _, err := r.exchange.CreateOrder(*request.create); err != nil {
+			r.reject(request.create.OrderLinkID, err)
+		}
+	}
+}
+
+// cancelPending : drops the order the cancel is for when it has not arrived either
+func (r *Runner) cancelPending(param bybit.V5CancelOrderParam) bool {
+	if param.OrderLinkID == nil {
+		return false
+	}
+	for i, request := range r.pending {
+		if request.create != nil && *request.create.OrderLinkID == *param.OrderLinkID {
+			r.pending = append(r.pending[:i], r.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Runner) reject(orderLinkID *string, err error) {
+	rejection := Rejection{Time: r.now, Err: err}
+	if orderLinkID != nil {
+		rejection.OrderLinkID = *orderLinkID
+	}
+	r.result.Rejections = append(r.result.Rejections, rejection)
+}
@@ -0,0 +1,105 @@
+package backtest
+
+import (
+	"math"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/shopspring/decimal"
+)
+
+const year = 365 * 24 * time.Hour
+
+// Stats : of a Result
+type Stats struct {
+	StartEquity bybit.Decimal
+	EndEquity   bybit.Decimal
+	// Return : EndEquity / StartEquity - 1
+	Return float64
+	// Sharpe : of the returns between equity points, annualised and without a risk free rate
+	Sharpe float64
+	// MaxDrawdown : the largest fall of the equity from a previous peak, as a fraction of the peak
+	MaxDrawdown float64
+	// WinRate : the fraction of Trades with a positive closed PnL
+	WinRate float64
+	Trades  int
+	// Fees : paid on trades, negative for rebates
+	Fees bybit.Decimal
+	// Funding : paid, negative when received
+	Funding bybit.Decimal
+}
+
+func newStats(result *Result) Stats {
+	var stats Stats
+	for _, fill := range result.Fills {
+		fee, _ := decimal.NewFromString(fill.ExecFee)
+		if fill.ExecType == bybit.ExecTypeFunding {
+			stats.Funding = bybit.NewDecimal(stats.Funding.Add(fee))
+		} else {
+			stats.Fees = bybit.NewDecimal(stats.Fees.Add(fee))
+		}
+	}
+
+	stats.Trades = len(result.Trades)
+	wins := 0
+	for _, trade := range result.Trades {
+		if pnl, _ := decimal.NewFromString(trade.ClosedPnl); pnl.IsPositive() {
+			wins++
+		}
+	}
+	if stats.Trades > 0 {
+		stats.WinRate = float64(wins) / float64(stats.Trades)
+	}
+
+	curve := result.Equity
+	if len(curve) == 0 {
+		return stats
+	}
+	stats.StartEquity = curve[0].Equity
+	stats.EndEquity = curve[len(curve)-1].Equity
+	start := curve[0].Equity.InexactFloat64()
+	if start != 0 {
+		stats.Return = curve[len(curve)-1].Equity.InexactFloat64()/start - 1
+	}
+
+	peak := start
+	returns := make([]float64, 0, len(curve)-1)
+	for i, point := range curve {
+		equity := point.Equity.InexactFloat64()
+		if equity > peak {
+			peak = equity
+		}
+		if peak > 0 {
+			stats.MaxDrawdown = math.Max(stats.MaxDrawdown, (peak-equity)/peak)
+		}
+		if i > 0 {
+			if previous := curve[i-1].Equity.InexactFloat64(); previous != 0 {
+				returns = append(returns, equity/previous-1)
+			}
+		}
+	}
+	stats.Sharpe = sharpe(returns, curve[len(curve)-1].Time.Sub(curve[0].Time))
+	return stats
+}
+
+// sharpe : mean over standard deviation of returns spread over span, scaled to a year
+func sharpe(returns []float64, span time.Duration) float64 {
+	if len(returns) < 2 || span <= 0 {
+		return 0
+	}
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+	if std == 0 {
+		return 0
+	}
+	periods := float64(year) / (float64(span) / float64(len(returns)))
+	return mean / std * math.Sqrt(periods)
+}
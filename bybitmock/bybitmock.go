@@ -0,0 +1,69 @@
+// Package bybitmock : mocks of the service interfaces of bybit, recording their calls
+// and answering with the functions programmed per method, e.g.
+//
+//	order := &bybitmock.V5OrderService{
+//		CreateOrderFunc: func(param bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error) {
+//			return &bybit.V5CreateOrderResponse{}, nil
+//		},
+//	}
+//	v5 := &bybitmock.V5Service{OrderMock: order}
+//	// code under test calls v5.Order().CreateOrder(...)
+//	calls := order.CallsTo("CreateOrder")
+//
+// A method whose function is nil returns zero values and ErrNotProgrammed, and a method returning
+// another service returns the mock in its Mock field, created when nil.
+// The mocks are generated from the interfaces, run go generate when they change.
+package bybitmock
+
+//go:generate go run ./internal/mockgen -src .. -out mocks.go
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotProgrammed : returned by a method whose function is nil
+var ErrNotProgrammed = errors.New("bybitmock: method not programmed")
+
+// Call : a method called and its arguments
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder : the calls a mock received, embedded in every mock
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls : all received so far, oldest first
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo : those of method
+func (r *Recorder) CallsTo(method string) []Call {
+	var list []Call
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			list = append(list, call)
+		}
+	}
+	return list
+}
+
+// Reset : forgets the calls received
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
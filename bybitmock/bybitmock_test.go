@@ -0,0 +1,70 @@
+package bybitmock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// placeOrder : code depending on the interface only
+func placeOrder(v5 bybit.V5ServiceI, qty string) (string, error) {
+	res, err := v5.Order().CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: bybit.SymbolV5BTCUSDT, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: qty,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Result.OrderID, nil
+}
+
+func TestV5Service(t *testing.T) {
+	order := &V5OrderService{
+		CreateOrderFunc: func(param bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error) {
+			if param.Qty == "0" {
+				return nil, errors.New("qty invalid")
+			}
+			res := &bybit.V5CreateOrderResponse{}
+			res.Result.OrderID = "1"
+			return res, nil
+		},
+	}
+	v5 := &V5Service{OrderMock: order}
+
+	id, err := placeOrder(v5, "0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "1", id)
+	_, err = placeOrder(v5, "0")
+	assert.EqualError(t, err, "qty invalid")
+
+	calls := order.CallsTo("CreateOrder")
+	require.Len(t, calls, 2)
+	assert.Equal(t, "0.1", calls[0].Args[0].(bybit.V5CreateOrderParam).Qty)
+	assert.Len(t, v5.CallsTo("Order"), 2)
+
+	_, err = v5.Order().GetOpenOrders(bybit.V5GetOpenOrdersParam{Category: bybit.CategoryV5Linear})
+	assert.ErrorIs(t, err, ErrNotProgrammed)
+	_, err = v5.Market().GetTickers(bybit.V5GetTickersParam{Category: bybit.CategoryV5Linear})
+	assert.ErrorIs(t, err, ErrNotProgrammed, "the market mock is created")
+	assert.Same(t, v5.MarketMock, v5.Market())
+
+	order.Reset()
+	assert.Empty(t, order.Calls())
+}
+
+func TestFutureService(t *testing.T) {
+	future := &FutureService{}
+	future.USDTPerpetualMock = &FutureUSDTPerpetualService{
+		BalanceFunc: func(coin bybit.Coin) (*bybit.BalanceResponse, error) {
+			return &bybit.BalanceResponse{Result: bybit.BalanceResult{Balance: map[bybit.Coin]bybit.Balance{coin: {Equity: 1}}}}, nil
+		},
+	}
+	var service bybit.FutureServiceI = future
+
+	res, err := service.USDTPerpetual().Balance(bybit.CoinUSDT)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), res.Result.Balance[bybit.CoinUSDT].Equity)
+	assert.Equal(t, []Call{{Method: "Balance", Args: []interface{}{bybit.Coin("USDT")}}}, future.USDTPerpetualMock.Calls())
+}
@@ -0,0 +1,343 @@
+// Command mockgen : writes the bybitmock mocks of the exported service interfaces of bybit,
+// those whose name ends with ServiceI
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const bybitPath = "github.com/oneart-dev/bybit"
+
+func main() {
+	src := flag.String("src", "..", "directory of the bybit package")
+	out := flag.String("out", "mocks.go", "file to write")
+	flag.Parse()
+
+	b, err := Generate(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, b, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// service : an interface to mock
+type service struct {
+	name    string
+	methods []method
+}
+
+type method struct {
+	name    string
+	params  []string
+	results []string
+	// variadic : the last param is ...T, passed on as p...
+	variadic bool
+}
+
+// Generate : the mocks of the service interfaces of the package in dir, formatted
+func Generate(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["bybit"]
+	if !ok {
+		return nil, fmt.Errorf("no bybit package in %s", dir)
+	}
+
+	imports := map[string]string{"bybit": bybitPath}
+	var services []service
+	for _, file := range pkg.Files {
+		fileImports := map[string]string{}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			fileImports[name] = path
+		}
+		q := &qualifier{fileImports: fileImports, imports: imports}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				iface, ok := typeSpec.Type.(*ast.InterfaceType)
+				if !ok || !typeSpec.Name.IsExported() || !strings.HasSuffix(typeSpec.Name.Name, "ServiceI") {
+					continue
+				}
+				s, err := q.service(typeSpec.Name.Name, iface)
+				if err != nil {
+					return nil, err
+				}
+				services = append(services, s)
+			}
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].name < services[j].name })
+
+	mocked := map[string]bool{}
+	for _, s := range services {
+		mocked[s.name] = true
+	}
+	var buf bytes.Buffer
+	writeFile(&buf, services, mocked, imports)
+	return format.Source(buf.Bytes())
+}
+
+// qualifier : prints the types of the signatures as seen from bybitmock
+type qualifier struct {
+	fileImports map[string]string
+	imports     map[string]string
+}
+
+func (q *qualifier) service(name string, iface *ast.InterfaceType) (service, error) {
+	s := service{name: name}
+	for _, field := range iface.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return s, fmt.Errorf("%s: embedded interfaces are not supported", name)
+		}
+		m := method{name: field.Names[0].Name}
+		var err error
+		if m.params, m.variadic, err = q.fields(fn.Params); err != nil {
+			return s, err
+		}
+		if m.results, _, err = q.fields(fn.Results); err != nil {
+			return s, err
+		}
+		s.methods = append(s.methods, m)
+	}
+	return s, nil
+}
+
+// fields : one type per param or result, named or not
+func (q *qualifier) fields(list *ast.FieldList) ([]string, bool, error) {
+	if list == nil {
+		return nil, false, nil
+	}
+	var types []string
+	variadic := false
+	for _, field := range list.List {
+		t, err := q.typeString(field.Type)
+		if err != nil {
+			return nil, false, err
+		}
+		_, variadic = field.Type.(*ast.Ellipsis)
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, t)
+		}
+	}
+	return types, variadic, nil
+}
+
+func (q *qualifier) typeString(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch {
+		case types.Universe.Lookup(t.Name) != nil:
+			return t.Name, nil
+		case !t.IsExported():
+			return "", fmt.Errorf("unexported type %s", t.Name)
+		}
+		return "bybit." + t.Name, nil
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		path, ok := q.fileImports[pkg]
+		if !ok {
+			return "", fmt.Errorf("unknown package %s", pkg)
+		}
+		q.imports[pkg] = path
+		return pkg + "." + t.Sel.Name, nil
+	case *ast.StarExpr:
+		s, err := q.typeString(t.X)
+		return "*" + s, err
+	case *ast.Ellipsis:
+		s, err := q.typeString(t.Elt)
+		return "..." + s, err
+	case *ast.ArrayType:
+		s, err := q.typeString(t.Elt)
+		if t.Len != nil {
+			return "", fmt.Errorf("arrays are not supported")
+		}
+		return "[]" + s, err
+	case *ast.MapType:
+		key, err := q.typeString(t.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := q.typeString(t.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return "", fmt.Errorf("interface literals are not supported")
+		}
+		return "interface{}", nil
+	case *ast.FuncType:
+		params, _, err := q.fields(t.Params)
+		if err != nil {
+			return "", err
+		}
+		results, _, err := q.fields(t.Results)
+		if err != nil {
+			return "", err
+		}
+		return "func(" + strings.Join(params, ", ") + ")" + resultList(results), nil
+	}
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+func resultList(results []string) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return " " + results[0]
+	}
+	return " (" + strings.Join(results, ", ") + ")"
+}
+
+func writeFile(buf *bytes.Buffer, services []service, mocked map[string]bool, imports map[string]string) {
+	fmt.Fprintln(buf, "// Code generated by bybitmock/internal/mockgen. DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package bybitmock")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "import (")
+	names := make([]string, 0, len(imports))
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return imports[names[i]] < imports[names[j]] })
+	std := true
+	for _, name := range names {
+		path := imports[name]
+		if std && strings.Contains(strings.Split(path, "/")[0], ".") {
+			if names[0] != name {
+				fmt.Fprintln(buf)
+			}
+			std = false
+		}
+		fmt.Fprintf(buf, "\t%q\n", path)
+	}
+	fmt.Fprintln(buf, ")")
+
+	for _, s := range services {
+		mock := strings.TrimSuffix(s.name, "I")
+		fmt.Fprintf(buf, "\n// %s : a mock of bybit.%s\n", mock, s.name)
+		fmt.Fprintf(buf, "type %s struct {\n\tRecorder\n\n", mock)
+		for _, m := range s.methods {
+			fmt.Fprintf(buf, "\t%sFunc func(%s)%s\n", m.name, strings.Join(m.params, ", "), resultList(m.results))
+			if child, ok := childService(m, mocked); ok {
+				fmt.Fprintf(buf, "\t%sMock *%s\n", m.name, strings.TrimSuffix(child, "I"))
+			}
+		}
+		fmt.Fprintln(buf, "}")
+		fmt.Fprintf(buf, "\nvar _ bybit.%s = (*%s)(nil)\n", s.name, mock)
+		for _, m := range s.methods {
+			writeMethod(buf, mock, m, mocked)
+		}
+	}
+}
+
+// childService : the service the method returns when it takes nothing and returns a mocked interface
+func childService(m method, mocked map[string]bool) (string, bool) {
+	if len(m.params) != 0 || len(m.results) != 1 {
+		return "", false
+	}
+	name := strings.TrimPrefix(m.results[0], "bybit.")
+	return name, mocked[name]
+}
+
+func writeMethod(buf *bytes.Buffer, mock string, m method, mocked map[string]bool) {
+	var (
+		params  []string
+		args    []string
+		results []string
+	)
+	for i, t := range m.params {
+		params = append(params, fmt.Sprintf("p%d %s", i, t))
+		arg := fmt.Sprintf("p%d", i)
+		if m.variadic && i == len(m.params)-1 {
+			arg += "..."
+		}
+		args = append(args, arg)
+	}
+	for i, t := range m.results {
+		name := fmt.Sprintf("r%d", i)
+		if t == "error" && i == len(m.results)-1 {
+			name = "err"
+		}
+		results = append(results, name+" "+t)
+	}
+	resultDecl := ""
+	if len(results) > 0 {
+		resultDecl = " (" + strings.Join(results, ", ") + ")"
+	}
+	recorded := append([]string{strconv.Quote(m.name)}, recordedArgs(m)...)
+	call := fmt.Sprintf("m.%sFunc(%s)", m.name, strings.Join(args, ", "))
+
+	child, isChild := childService(m, mocked)
+	switch {
+	case isChild:
+		fmt.Fprintf(buf, "\n// %s : calls %sFunc, returns %sMock otherwise\n", m.name, m.name, m.name)
+	case len(m.results) > 0 && m.results[len(m.results)-1] == "error":
+		fmt.Fprintf(buf, "\n// %s : calls %sFunc, returns ErrNotProgrammed otherwise\n", m.name, m.name)
+	default:
+		fmt.Fprintf(buf, "\n// %s : calls %sFunc when programmed\n", m.name, m.name)
+	}
+	fmt.Fprintf(buf, "func (m *%s) %s(%s)%s {\n", mock, m.name, strings.Join(params, ", "), resultDecl)
+	fmt.Fprintf(buf, "\tm.record(%s)\n", strings.Join(recorded, ", "))
+	fmt.Fprintf(buf, "\tif m.%sFunc != nil {\n", m.name)
+	if len(m.results) > 0 {
+		fmt.Fprintf(buf, "\t\treturn %s\n", call)
+	} else {
+		fmt.Fprintf(buf, "\t\t%s\n\t\treturn\n", call)
+	}
+	fmt.Fprintln(buf, "\t}")
+	switch {
+	case isChild:
+		fmt.Fprintln(buf, "\tm.mu.Lock()")
+		fmt.Fprintln(buf, "\tdefer m.mu.Unlock()")
+		fmt.Fprintf(buf, "\tif m.%sMock == nil {\n\t\tm.%sMock = &%s{}\n\t}\n", m.name, m.name, strings.TrimSuffix(child, "I"))
+		fmt.Fprintf(buf, "\treturn m.%sMock\n", m.name)
+	case len(m.results) > 0 && m.results[len(m.results)-1] == "error":
+		fmt.Fprintln(buf, "\terr = ErrNotProgrammed")
+		fmt.Fprintln(buf, "\treturn")
+	case len(m.results) > 0:
+		fmt.Fprintln(buf, "\treturn")
+	}
+	fmt.Fprintln(buf, "}")
+}
+
+func recordedArgs(m method) []string {
+	var args []string
+	for i := range m.params {
+		args = append(args, fmt.Sprintf("p%d", i))
+	}
+	return args
+}
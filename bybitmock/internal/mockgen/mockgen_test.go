@@ -0,0 +1,17 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateInSync(t *testing.T) {
+	want, err := Generate("../../..")
+	require.NoError(t, err)
+	got, err := os.ReadFile("../../mocks.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "run go generate ./bybitmock")
+}
@@ -0,0 +1,1648 @@
+// Code generated by bybitmock/internal/mockgen. DO NOT EDIT.
+
+package bybitmock
+
+import (
+	"context"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// FutureContractService : a mock of bybit.FutureContractServiceI
+type FutureContractService struct {
+	Recorder
+
+	TickersFunc                      func(string, string) (*bybit.ContractTickersResponse, error)
+	SymbolsFunc                      func() (*bybit.ContractSymbolsResponse, error)
+	ListKlineFunc                    func(bybit.ContractListKlineParam) (*bybit.ContractListKlineResponse, error)
+	ContractExecutionHistoryListFunc func(bybit.ContractExecutionHistoryListParam) (*bybit.ContractExecutionHistoryListResponse, error)
+	BalanceFunc                      func(bybit.Coin) (*bybit.ContractBalanceResponse, error)
+}
+
+var _ bybit.FutureContractServiceI = (*FutureContractService)(nil)
+
+// Tickers : calls TickersFunc, returns ErrNotProgrammed otherwise
+func (m *FutureContractService) Tickers(p0 string, p1 string) (r0 *bybit.ContractTickersResponse, err error) {
+	m.record("Tickers", p0, p1)
+	if m.TickersFunc != nil {
+		return m.TickersFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Symbols : calls SymbolsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureContractService) Symbols() (r0 *bybit.ContractSymbolsResponse, err error) {
+	m.record("Symbols")
+	if m.SymbolsFunc != nil {
+		return m.SymbolsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListKline : calls ListKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureContractService) ListKline(p0 bybit.ContractListKlineParam) (r0 *bybit.ContractListKlineResponse, err error) {
+	m.record("ListKline", p0)
+	if m.ListKlineFunc != nil {
+		return m.ListKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ContractExecutionHistoryList : calls ContractExecutionHistoryListFunc, returns ErrNotProgrammed otherwise
+func (m *FutureContractService) ContractExecutionHistoryList(p0 bybit.ContractExecutionHistoryListParam) (r0 *bybit.ContractExecutionHistoryListResponse, err error) {
+	m.record("ContractExecutionHistoryList", p0)
+	if m.ContractExecutionHistoryListFunc != nil {
+		return m.ContractExecutionHistoryListFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Balance : calls BalanceFunc, returns ErrNotProgrammed otherwise
+func (m *FutureContractService) Balance(p0 bybit.Coin) (r0 *bybit.ContractBalanceResponse, err error) {
+	m.record("Balance", p0)
+	if m.BalanceFunc != nil {
+		return m.BalanceFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// FutureInverseFutureService : a mock of bybit.FutureInverseFutureServiceI
+type FutureInverseFutureService struct {
+	Recorder
+
+	OrderBookFunc       func(bybit.SymbolInverse) (*bybit.OrderBookResponse, error)
+	ListKlineFunc       func(bybit.ListKlineParam) (*bybit.ListKlineResponse, error)
+	TickersFunc         func(bybit.SymbolInverse) (*bybit.TickersResponse, error)
+	TradingRecordsFunc  func(bybit.TradingRecordsParam) (*bybit.TradingRecordsResponse, error)
+	SymbolsFunc         func() (*bybit.SymbolsResponse, error)
+	MarkPriceKlineFunc  func(bybit.MarkPriceKlineParam) (*bybit.MarkPriceKlineResponse, error)
+	IndexPriceKlineFunc func(bybit.IndexPriceKlineParam) (*bybit.IndexPriceKlineResponse, error)
+	OpenInterestFunc    func(bybit.OpenInterestParam) (*bybit.OpenInterestResponse, error)
+	BigDealFunc         func(bybit.BigDealParam) (*bybit.BigDealResponse, error)
+	AccountRatioFunc    func(bybit.AccountRatioParam) (*bybit.AccountRatioResponse, error)
+	BalanceFunc         func(bybit.Coin) (*bybit.BalanceResponse, error)
+	APIKeyFunc          func() (*bybit.APIKeyResponse, error)
+}
+
+var _ bybit.FutureInverseFutureServiceI = (*FutureInverseFutureService)(nil)
+
+// OrderBook : calls OrderBookFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) OrderBook(p0 bybit.SymbolInverse) (r0 *bybit.OrderBookResponse, err error) {
+	m.record("OrderBook", p0)
+	if m.OrderBookFunc != nil {
+		return m.OrderBookFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListKline : calls ListKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) ListKline(p0 bybit.ListKlineParam) (r0 *bybit.ListKlineResponse, err error) {
+	m.record("ListKline", p0)
+	if m.ListKlineFunc != nil {
+		return m.ListKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Tickers : calls TickersFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) Tickers(p0 bybit.SymbolInverse) (r0 *bybit.TickersResponse, err error) {
+	m.record("Tickers", p0)
+	if m.TickersFunc != nil {
+		return m.TickersFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// TradingRecords : calls TradingRecordsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) TradingRecords(p0 bybit.TradingRecordsParam) (r0 *bybit.TradingRecordsResponse, err error) {
+	m.record("TradingRecords", p0)
+	if m.TradingRecordsFunc != nil {
+		return m.TradingRecordsFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Symbols : calls SymbolsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) Symbols() (r0 *bybit.SymbolsResponse, err error) {
+	m.record("Symbols")
+	if m.SymbolsFunc != nil {
+		return m.SymbolsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// MarkPriceKline : calls MarkPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) MarkPriceKline(p0 bybit.MarkPriceKlineParam) (r0 *bybit.MarkPriceKlineResponse, err error) {
+	m.record("MarkPriceKline", p0)
+	if m.MarkPriceKlineFunc != nil {
+		return m.MarkPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// IndexPriceKline : calls IndexPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) IndexPriceKline(p0 bybit.IndexPriceKlineParam) (r0 *bybit.IndexPriceKlineResponse, err error) {
+	m.record("IndexPriceKline", p0)
+	if m.IndexPriceKlineFunc != nil {
+		return m.IndexPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// OpenInterest : calls OpenInterestFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) OpenInterest(p0 bybit.OpenInterestParam) (r0 *bybit.OpenInterestResponse, err error) {
+	m.record("OpenInterest", p0)
+	if m.OpenInterestFunc != nil {
+		return m.OpenInterestFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// BigDeal : calls BigDealFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) BigDeal(p0 bybit.BigDealParam) (r0 *bybit.BigDealResponse, err error) {
+	m.record("BigDeal", p0)
+	if m.BigDealFunc != nil {
+		return m.BigDealFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// AccountRatio : calls AccountRatioFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) AccountRatio(p0 bybit.AccountRatioParam) (r0 *bybit.AccountRatioResponse, err error) {
+	m.record("AccountRatio", p0)
+	if m.AccountRatioFunc != nil {
+		return m.AccountRatioFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Balance : calls BalanceFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) Balance(p0 bybit.Coin) (r0 *bybit.BalanceResponse, err error) {
+	m.record("Balance", p0)
+	if m.BalanceFunc != nil {
+		return m.BalanceFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// APIKey : calls APIKeyFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInverseFutureService) APIKey() (r0 *bybit.APIKeyResponse, err error) {
+	m.record("APIKey")
+	if m.APIKeyFunc != nil {
+		return m.APIKeyFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// FutureInversePerpetualService : a mock of bybit.FutureInversePerpetualServiceI
+type FutureInversePerpetualService struct {
+	Recorder
+
+	OrderBookFunc         func(bybit.SymbolInverse) (*bybit.OrderBookResponse, error)
+	ListKlineFunc         func(bybit.ListKlineParam) (*bybit.ListKlineResponse, error)
+	TickersFunc           func(bybit.SymbolInverse) (*bybit.TickersResponse, error)
+	TradingRecordsFunc    func(bybit.TradingRecordsParam) (*bybit.TradingRecordsResponse, error)
+	SymbolsFunc           func() (*bybit.SymbolsResponse, error)
+	MarkPriceKlineFunc    func(bybit.MarkPriceKlineParam) (*bybit.MarkPriceKlineResponse, error)
+	IndexPriceKlineFunc   func(bybit.IndexPriceKlineParam) (*bybit.IndexPriceKlineResponse, error)
+	PremiumIndexKlineFunc func(bybit.PremiumIndexKlineParam) (*bybit.PremiumIndexKlineResponse, error)
+	OpenInterestFunc      func(bybit.OpenInterestParam) (*bybit.OpenInterestResponse, error)
+	BigDealFunc           func(bybit.BigDealParam) (*bybit.BigDealResponse, error)
+	AccountRatioFunc      func(bybit.AccountRatioParam) (*bybit.AccountRatioResponse, error)
+	CreateOrderFunc       func(bybit.CreateOrderParam) (*bybit.CreateOrderResponse, error)
+	ListOrderFunc         func(bybit.ListOrderParam) (*bybit.ListOrderResponse, error)
+	CancelOrderFunc       func(bybit.CancelOrderParam) (*bybit.CancelOrderResponse, error)
+	ListPositionFunc      func(bybit.SymbolInverse) (*bybit.ListPositionResponse, error)
+	ListPositionsFunc     func() (*bybit.ListPositionsResponse, error)
+	SaveLeverageFunc      func(bybit.SaveLeverageParam) (*bybit.SaveLeverageResponse, error)
+	BalanceFunc           func(bybit.Coin) (*bybit.BalanceResponse, error)
+}
+
+var _ bybit.FutureInversePerpetualServiceI = (*FutureInversePerpetualService)(nil)
+
+// OrderBook : calls OrderBookFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) OrderBook(p0 bybit.SymbolInverse) (r0 *bybit.OrderBookResponse, err error) {
+	m.record("OrderBook", p0)
+	if m.OrderBookFunc != nil {
+		return m.OrderBookFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListKline : calls ListKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) ListKline(p0 bybit.ListKlineParam) (r0 *bybit.ListKlineResponse, err error) {
+	m.record("ListKline", p0)
+	if m.ListKlineFunc != nil {
+		return m.ListKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Tickers : calls TickersFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) Tickers(p0 bybit.SymbolInverse) (r0 *bybit.TickersResponse, err error) {
+	m.record("Tickers", p0)
+	if m.TickersFunc != nil {
+		return m.TickersFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// TradingRecords : calls TradingRecordsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) TradingRecords(p0 bybit.TradingRecordsParam) (r0 *bybit.TradingRecordsResponse, err error) {
+	m.record("TradingRecords", p0)
+	if m.TradingRecordsFunc != nil {
+		return m.TradingRecordsFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Symbols : calls SymbolsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) Symbols() (r0 *bybit.SymbolsResponse, err error) {
+	m.record("Symbols")
+	if m.SymbolsFunc != nil {
+		return m.SymbolsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// MarkPriceKline : calls MarkPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) MarkPriceKline(p0 bybit.MarkPriceKlineParam) (r0 *bybit.MarkPriceKlineResponse, err error) {
+	m.record("MarkPriceKline", p0)
+	if m.MarkPriceKlineFunc != nil {
+		return m.MarkPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// IndexPriceKline : calls IndexPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) IndexPriceKline(p0 bybit.IndexPriceKlineParam) (r0 *bybit.IndexPriceKlineResponse, err error) {
+	m.record("IndexPriceKline", p0)
+	if m.IndexPriceKlineFunc != nil {
+		return m.IndexPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// PremiumIndexKline : calls PremiumIndexKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) PremiumIndexKline(p0 bybit.PremiumIndexKlineParam) (r0 *bybit.PremiumIndexKlineResponse, err error) {
+	m.record("PremiumIndexKline", p0)
+	if m.PremiumIndexKlineFunc != nil {
+		return m.PremiumIndexKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// OpenInterest : calls OpenInterestFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) OpenInterest(p0 bybit.OpenInterestParam) (r0 *bybit.OpenInterestResponse, err error) {
+	m.record("OpenInterest", p0)
+	if m.OpenInterestFunc != nil {
+		return m.OpenInterestFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// BigDeal : calls BigDealFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) BigDeal(p0 bybit.BigDealParam) (r0 *bybit.BigDealResponse, err error) {
+	m.record("BigDeal", p0)
+	if m.BigDealFunc != nil {
+		return m.BigDealFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// AccountRatio : calls AccountRatioFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) AccountRatio(p0 bybit.AccountRatioParam) (r0 *bybit.AccountRatioResponse, err error) {
+	m.record("AccountRatio", p0)
+	if m.AccountRatioFunc != nil {
+		return m.AccountRatioFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CreateOrder : calls CreateOrderFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) CreateOrder(p0 bybit.CreateOrderParam) (r0 *bybit.CreateOrderResponse, err error) {
+	m.record("CreateOrder", p0)
+	if m.CreateOrderFunc != nil {
+		return m.CreateOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListOrder : calls ListOrderFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) ListOrder(p0 bybit.ListOrderParam) (r0 *bybit.ListOrderResponse, err error) {
+	m.record("ListOrder", p0)
+	if m.ListOrderFunc != nil {
+		return m.ListOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CancelOrder : calls CancelOrderFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) CancelOrder(p0 bybit.CancelOrderParam) (r0 *bybit.CancelOrderResponse, err error) {
+	m.record("CancelOrder", p0)
+	if m.CancelOrderFunc != nil {
+		return m.CancelOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListPosition : calls ListPositionFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) ListPosition(p0 bybit.SymbolInverse) (r0 *bybit.ListPositionResponse, err error) {
+	m.record("ListPosition", p0)
+	if m.ListPositionFunc != nil {
+		return m.ListPositionFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListPositions : calls ListPositionsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) ListPositions() (r0 *bybit.ListPositionsResponse, err error) {
+	m.record("ListPositions")
+	if m.ListPositionsFunc != nil {
+		return m.ListPositionsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SaveLeverage : calls SaveLeverageFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) SaveLeverage(p0 bybit.SaveLeverageParam) (r0 *bybit.SaveLeverageResponse, err error) {
+	m.record("SaveLeverage", p0)
+	if m.SaveLeverageFunc != nil {
+		return m.SaveLeverageFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Balance : calls BalanceFunc, returns ErrNotProgrammed otherwise
+func (m *FutureInversePerpetualService) Balance(p0 bybit.Coin) (r0 *bybit.BalanceResponse, err error) {
+	m.record("Balance", p0)
+	if m.BalanceFunc != nil {
+		return m.BalanceFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// FutureService : a mock of bybit.FutureServiceI
+type FutureService struct {
+	Recorder
+
+	InversePerpetualFunc func() bybit.FutureInversePerpetualServiceI
+	InversePerpetualMock *FutureInversePerpetualService
+	USDTPerpetualFunc    func() bybit.FutureUSDTPerpetualServiceI
+	USDTPerpetualMock    *FutureUSDTPerpetualService
+	InverseFutureFunc    func() bybit.FutureInverseFutureServiceI
+	InverseFutureMock    *FutureInverseFutureService
+	ContractFunc         func() bybit.FutureContractServiceI
+	ContractMock         *FutureContractService
+}
+
+var _ bybit.FutureServiceI = (*FutureService)(nil)
+
+// InversePerpetual : calls InversePerpetualFunc, returns InversePerpetualMock otherwise
+func (m *FutureService) InversePerpetual() (r0 bybit.FutureInversePerpetualServiceI) {
+	m.record("InversePerpetual")
+	if m.InversePerpetualFunc != nil {
+		return m.InversePerpetualFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.InversePerpetualMock == nil {
+		m.InversePerpetualMock = &FutureInversePerpetualService{}
+	}
+	return m.InversePerpetualMock
+}
+
+// USDTPerpetual : calls USDTPerpetualFunc, returns USDTPerpetualMock otherwise
+func (m *FutureService) USDTPerpetual() (r0 bybit.FutureUSDTPerpetualServiceI) {
+	m.record("USDTPerpetual")
+	if m.USDTPerpetualFunc != nil {
+		return m.USDTPerpetualFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.USDTPerpetualMock == nil {
+		m.USDTPerpetualMock = &FutureUSDTPerpetualService{}
+	}
+	return m.USDTPerpetualMock
+}
+
+// InverseFuture : calls InverseFutureFunc, returns InverseFutureMock otherwise
+func (m *FutureService) InverseFuture() (r0 bybit.FutureInverseFutureServiceI) {
+	m.record("InverseFuture")
+	if m.InverseFutureFunc != nil {
+		return m.InverseFutureFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.InverseFutureMock == nil {
+		m.InverseFutureMock = &FutureInverseFutureService{}
+	}
+	return m.InverseFutureMock
+}
+
+// Contract : calls ContractFunc, returns ContractMock otherwise
+func (m *FutureService) Contract() (r0 bybit.FutureContractServiceI) {
+	m.record("Contract")
+	if m.ContractFunc != nil {
+		return m.ContractFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ContractMock == nil {
+		m.ContractMock = &FutureContractService{}
+	}
+	return m.ContractMock
+}
+
+// FutureUSDTPerpetualService : a mock of bybit.FutureUSDTPerpetualServiceI
+type FutureUSDTPerpetualService struct {
+	Recorder
+
+	OrderBookFunc                  func(bybit.SymbolInverse) (*bybit.OrderBookResponse, error)
+	TickersFunc                    func(bybit.SymbolInverse) (*bybit.TickersResponse, error)
+	SymbolsFunc                    func() (*bybit.SymbolsResponse, error)
+	OpenInterestFunc               func(bybit.OpenInterestParam) (*bybit.OpenInterestResponse, error)
+	BigDealFunc                    func(bybit.BigDealParam) (*bybit.BigDealResponse, error)
+	AccountRatioFunc               func(bybit.AccountRatioParam) (*bybit.AccountRatioResponse, error)
+	ListKlineFunc                  func(bybit.ListKlineParam) (*bybit.ListKlineResponse, error)
+	CreateLinearOrderFunc          func(bybit.CreateLinearOrderParam) (*bybit.CreateLinearOrderResponse, error)
+	CancelLinearOrderFunc          func(bybit.CancelLinearOrderParam) (*bybit.CancelLinearOrderResponse, error)
+	LinearCancelAllOrderFunc       func(bybit.LinearCancelAllParam) (*bybit.LinearCancelAllResponse, error)
+	ListLinearPositionFunc         func(bybit.SymbolUSDT) (*bybit.ListLinearPositionResponse, error)
+	ListLinearPositionsFunc        func() (*bybit.ListLinearPositionsResponse, error)
+	SaveLinearLeverageFunc         func(bybit.SaveLinearLeverageParam) (*bybit.SaveLinearLeverageResponse, error)
+	LinearExecutionListFunc        func(bybit.LinearExecutionListParam) (*bybit.LinearExecutionListResponse, error)
+	LinearExecutionHistoryListFunc func(bybit.LinearExecutionHistoryListParam) (*bybit.LinearExecutionHistoryListResponse, error)
+	BalanceFunc                    func(bybit.Coin) (*bybit.BalanceResponse, error)
+}
+
+var _ bybit.FutureUSDTPerpetualServiceI = (*FutureUSDTPerpetualService)(nil)
+
+// OrderBook : calls OrderBookFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) OrderBook(p0 bybit.SymbolInverse) (r0 *bybit.OrderBookResponse, err error) {
+	m.record("OrderBook", p0)
+	if m.OrderBookFunc != nil {
+		return m.OrderBookFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Tickers : calls TickersFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) Tickers(p0 bybit.SymbolInverse) (r0 *bybit.TickersResponse, err error) {
+	m.record("Tickers", p0)
+	if m.TickersFunc != nil {
+		return m.TickersFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Symbols : calls SymbolsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) Symbols() (r0 *bybit.SymbolsResponse, err error) {
+	m.record("Symbols")
+	if m.SymbolsFunc != nil {
+		return m.SymbolsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// OpenInterest : calls OpenInterestFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) OpenInterest(p0 bybit.OpenInterestParam) (r0 *bybit.OpenInterestResponse, err error) {
+	m.record("OpenInterest", p0)
+	if m.OpenInterestFunc != nil {
+		return m.OpenInterestFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// BigDeal : calls BigDealFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) BigDeal(p0 bybit.BigDealParam) (r0 *bybit.BigDealResponse, err error) {
+	m.record("BigDeal", p0)
+	if m.BigDealFunc != nil {
+		return m.BigDealFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// AccountRatio : calls AccountRatioFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) AccountRatio(p0 bybit.AccountRatioParam) (r0 *bybit.AccountRatioResponse, err error) {
+	m.record("AccountRatio", p0)
+	if m.AccountRatioFunc != nil {
+		return m.AccountRatioFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListKline : calls ListKlineFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) ListKline(p0 bybit.ListKlineParam) (r0 *bybit.ListKlineResponse, err error) {
+	m.record("ListKline", p0)
+	if m.ListKlineFunc != nil {
+		return m.ListKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CreateLinearOrder : calls CreateLinearOrderFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) CreateLinearOrder(p0 bybit.CreateLinearOrderParam) (r0 *bybit.CreateLinearOrderResponse, err error) {
+	m.record("CreateLinearOrder", p0)
+	if m.CreateLinearOrderFunc != nil {
+		return m.CreateLinearOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CancelLinearOrder : calls CancelLinearOrderFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) CancelLinearOrder(p0 bybit.CancelLinearOrderParam) (r0 *bybit.CancelLinearOrderResponse, err error) {
+	m.record("CancelLinearOrder", p0)
+	if m.CancelLinearOrderFunc != nil {
+		return m.CancelLinearOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// LinearCancelAllOrder : calls LinearCancelAllOrderFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) LinearCancelAllOrder(p0 bybit.LinearCancelAllParam) (r0 *bybit.LinearCancelAllResponse, err error) {
+	m.record("LinearCancelAllOrder", p0)
+	if m.LinearCancelAllOrderFunc != nil {
+		return m.LinearCancelAllOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListLinearPosition : calls ListLinearPositionFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) ListLinearPosition(p0 bybit.SymbolUSDT) (r0 *bybit.ListLinearPositionResponse, err error) {
+	m.record("ListLinearPosition", p0)
+	if m.ListLinearPositionFunc != nil {
+		return m.ListLinearPositionFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// ListLinearPositions : calls ListLinearPositionsFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) ListLinearPositions() (r0 *bybit.ListLinearPositionsResponse, err error) {
+	m.record("ListLinearPositions")
+	if m.ListLinearPositionsFunc != nil {
+		return m.ListLinearPositionsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SaveLinearLeverage : calls SaveLinearLeverageFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) SaveLinearLeverage(p0 bybit.SaveLinearLeverageParam) (r0 *bybit.SaveLinearLeverageResponse, err error) {
+	m.record("SaveLinearLeverage", p0)
+	if m.SaveLinearLeverageFunc != nil {
+		return m.SaveLinearLeverageFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// LinearExecutionList : calls LinearExecutionListFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) LinearExecutionList(p0 bybit.LinearExecutionListParam) (r0 *bybit.LinearExecutionListResponse, err error) {
+	m.record("LinearExecutionList", p0)
+	if m.LinearExecutionListFunc != nil {
+		return m.LinearExecutionListFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// LinearExecutionHistoryList : calls LinearExecutionHistoryListFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) LinearExecutionHistoryList(p0 bybit.LinearExecutionHistoryListParam) (r0 *bybit.LinearExecutionHistoryListResponse, err error) {
+	m.record("LinearExecutionHistoryList", p0)
+	if m.LinearExecutionHistoryListFunc != nil {
+		return m.LinearExecutionHistoryListFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Balance : calls BalanceFunc, returns ErrNotProgrammed otherwise
+func (m *FutureUSDTPerpetualService) Balance(p0 bybit.Coin) (r0 *bybit.BalanceResponse, err error) {
+	m.record("Balance", p0)
+	if m.BalanceFunc != nil {
+		return m.BalanceFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotService : a mock of bybit.SpotServiceI
+type SpotService struct {
+	Recorder
+
+	V1Func func() bybit.SpotV1ServiceI
+	V1Mock *SpotV1Service
+	V3Func func() *bybit.SpotV3Service
+}
+
+var _ bybit.SpotServiceI = (*SpotService)(nil)
+
+// V1 : calls V1Func, returns V1Mock otherwise
+func (m *SpotService) V1() (r0 bybit.SpotV1ServiceI) {
+	m.record("V1")
+	if m.V1Func != nil {
+		return m.V1Func()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.V1Mock == nil {
+		m.V1Mock = &SpotV1Service{}
+	}
+	return m.V1Mock
+}
+
+// V3 : calls V3Func when programmed
+func (m *SpotService) V3() (r0 *bybit.SpotV3Service) {
+	m.record("V3")
+	if m.V3Func != nil {
+		return m.V3Func()
+	}
+	return
+}
+
+// SpotV1Service : a mock of bybit.SpotV1ServiceI
+type SpotV1Service struct {
+	Recorder
+
+	SpotSymbolsFunc               func() (*bybit.SpotSymbolsResponse, error)
+	SpotQuoteDepthFunc            func(bybit.SpotQuoteDepthParam) (*bybit.SpotQuoteDepthResponse, error)
+	SpotQuoteDepthMergedFunc      func(bybit.SpotQuoteDepthMergedParam) (*bybit.SpotQuoteDepthMergedResponse, error)
+	SpotQuoteTradesFunc           func(bybit.SpotQuoteTradesParam) (*bybit.SpotQuoteTradesResponse, error)
+	SpotQuoteKlineFunc            func(bybit.SpotQuoteKlineParam) (*bybit.SpotQuoteKlineResponse, error)
+	SpotQuoteTicker24hrFunc       func(bybit.SpotQuoteTicker24hrParam) (*bybit.SpotQuoteTicker24hrResponse, error)
+	SpotQuoteTickerPriceFunc      func(bybit.SpotQuoteTickerPriceParam) (*bybit.SpotQuoteTickerPriceResponse, error)
+	SpotQuoteTickerBookTickerFunc func(bybit.SpotQuoteTickerBookTickerParam) (*bybit.SpotQuoteTickerBookTickerResponse, error)
+	SpotPostOrderFunc             func(bybit.SpotPostOrderParam) (*bybit.SpotPostOrderResponse, error)
+	SpotGetOrderFunc              func(bybit.SpotGetOrderParam) (*bybit.SpotGetOrderResponse, error)
+	SpotDeleteOrderFunc           func(bybit.SpotDeleteOrderParam) (*bybit.SpotDeleteOrderResponse, error)
+	SpotDeleteOrderFastFunc       func(bybit.SpotDeleteOrderFastParam) (*bybit.SpotDeleteOrderFastResponse, error)
+	SpotOrderBatchCancelFunc      func(bybit.SpotOrderBatchCancelParam) (*bybit.SpotOrderBatchCancelResponse, error)
+	SpotOrderBatchFastCancelFunc  func(bybit.SpotOrderBatchFastCancelParam) (*bybit.SpotOrderBatchFastCancelResponse, error)
+	SpotOrderBatchCancelByIDsFunc func([]string) (*bybit.SpotOrderBatchCancelByIDsResponse, error)
+}
+
+var _ bybit.SpotV1ServiceI = (*SpotV1Service)(nil)
+
+// SpotSymbols : calls SpotSymbolsFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotSymbols() (r0 *bybit.SpotSymbolsResponse, err error) {
+	m.record("SpotSymbols")
+	if m.SpotSymbolsFunc != nil {
+		return m.SpotSymbolsFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteDepth : calls SpotQuoteDepthFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteDepth(p0 bybit.SpotQuoteDepthParam) (r0 *bybit.SpotQuoteDepthResponse, err error) {
+	m.record("SpotQuoteDepth", p0)
+	if m.SpotQuoteDepthFunc != nil {
+		return m.SpotQuoteDepthFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteDepthMerged : calls SpotQuoteDepthMergedFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteDepthMerged(p0 bybit.SpotQuoteDepthMergedParam) (r0 *bybit.SpotQuoteDepthMergedResponse, err error) {
+	m.record("SpotQuoteDepthMerged", p0)
+	if m.SpotQuoteDepthMergedFunc != nil {
+		return m.SpotQuoteDepthMergedFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteTrades : calls SpotQuoteTradesFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteTrades(p0 bybit.SpotQuoteTradesParam) (r0 *bybit.SpotQuoteTradesResponse, err error) {
+	m.record("SpotQuoteTrades", p0)
+	if m.SpotQuoteTradesFunc != nil {
+		return m.SpotQuoteTradesFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteKline : calls SpotQuoteKlineFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteKline(p0 bybit.SpotQuoteKlineParam) (r0 *bybit.SpotQuoteKlineResponse, err error) {
+	m.record("SpotQuoteKline", p0)
+	if m.SpotQuoteKlineFunc != nil {
+		return m.SpotQuoteKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteTicker24hr : calls SpotQuoteTicker24hrFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteTicker24hr(p0 bybit.SpotQuoteTicker24hrParam) (r0 *bybit.SpotQuoteTicker24hrResponse, err error) {
+	m.record("SpotQuoteTicker24hr", p0)
+	if m.SpotQuoteTicker24hrFunc != nil {
+		return m.SpotQuoteTicker24hrFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteTickerPrice : calls SpotQuoteTickerPriceFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteTickerPrice(p0 bybit.SpotQuoteTickerPriceParam) (r0 *bybit.SpotQuoteTickerPriceResponse, err error) {
+	m.record("SpotQuoteTickerPrice", p0)
+	if m.SpotQuoteTickerPriceFunc != nil {
+		return m.SpotQuoteTickerPriceFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotQuoteTickerBookTicker : calls SpotQuoteTickerBookTickerFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotQuoteTickerBookTicker(p0 bybit.SpotQuoteTickerBookTickerParam) (r0 *bybit.SpotQuoteTickerBookTickerResponse, err error) {
+	m.record("SpotQuoteTickerBookTicker", p0)
+	if m.SpotQuoteTickerBookTickerFunc != nil {
+		return m.SpotQuoteTickerBookTickerFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotPostOrder : calls SpotPostOrderFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotPostOrder(p0 bybit.SpotPostOrderParam) (r0 *bybit.SpotPostOrderResponse, err error) {
+	m.record("SpotPostOrder", p0)
+	if m.SpotPostOrderFunc != nil {
+		return m.SpotPostOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotGetOrder : calls SpotGetOrderFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotGetOrder(p0 bybit.SpotGetOrderParam) (r0 *bybit.SpotGetOrderResponse, err error) {
+	m.record("SpotGetOrder", p0)
+	if m.SpotGetOrderFunc != nil {
+		return m.SpotGetOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotDeleteOrder : calls SpotDeleteOrderFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotDeleteOrder(p0 bybit.SpotDeleteOrderParam) (r0 *bybit.SpotDeleteOrderResponse, err error) {
+	m.record("SpotDeleteOrder", p0)
+	if m.SpotDeleteOrderFunc != nil {
+		return m.SpotDeleteOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotDeleteOrderFast : calls SpotDeleteOrderFastFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotDeleteOrderFast(p0 bybit.SpotDeleteOrderFastParam) (r0 *bybit.SpotDeleteOrderFastResponse, err error) {
+	m.record("SpotDeleteOrderFast", p0)
+	if m.SpotDeleteOrderFastFunc != nil {
+		return m.SpotDeleteOrderFastFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotOrderBatchCancel : calls SpotOrderBatchCancelFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotOrderBatchCancel(p0 bybit.SpotOrderBatchCancelParam) (r0 *bybit.SpotOrderBatchCancelResponse, err error) {
+	m.record("SpotOrderBatchCancel", p0)
+	if m.SpotOrderBatchCancelFunc != nil {
+		return m.SpotOrderBatchCancelFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotOrderBatchFastCancel : calls SpotOrderBatchFastCancelFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotOrderBatchFastCancel(p0 bybit.SpotOrderBatchFastCancelParam) (r0 *bybit.SpotOrderBatchFastCancelResponse, err error) {
+	m.record("SpotOrderBatchFastCancel", p0)
+	if m.SpotOrderBatchFastCancelFunc != nil {
+		return m.SpotOrderBatchFastCancelFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SpotOrderBatchCancelByIDs : calls SpotOrderBatchCancelByIDsFunc, returns ErrNotProgrammed otherwise
+func (m *SpotV1Service) SpotOrderBatchCancelByIDs(p0 []string) (r0 *bybit.SpotOrderBatchCancelByIDsResponse, err error) {
+	m.record("SpotOrderBatchCancelByIDs", p0)
+	if m.SpotOrderBatchCancelByIDsFunc != nil {
+		return m.SpotOrderBatchCancelByIDsFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// USDCContractService : a mock of bybit.USDCContractServiceI
+type USDCContractService struct {
+	Recorder
+
+	OptionFunc    func() *bybit.USDCContractOptionService
+	PerpetualFunc func() *bybit.USDCContractPerpetualService
+}
+
+var _ bybit.USDCContractServiceI = (*USDCContractService)(nil)
+
+// Option : calls OptionFunc when programmed
+func (m *USDCContractService) Option() (r0 *bybit.USDCContractOptionService) {
+	m.record("Option")
+	if m.OptionFunc != nil {
+		return m.OptionFunc()
+	}
+	return
+}
+
+// Perpetual : calls PerpetualFunc when programmed
+func (m *USDCContractService) Perpetual() (r0 *bybit.USDCContractPerpetualService) {
+	m.record("Perpetual")
+	if m.PerpetualFunc != nil {
+		return m.PerpetualFunc()
+	}
+	return
+}
+
+// V5AccountService : a mock of bybit.V5AccountServiceI
+type V5AccountService struct {
+	Recorder
+
+	GetWalletBalanceFunc        func(bybit.AccountType, []bybit.Coin) (*bybit.V5WalletBalanceResponse, error)
+	GetWalletBalanceContextFunc func(context.Context, bybit.AccountType, []bybit.Coin) (*bybit.V5WalletBalanceResponse, error)
+}
+
+var _ bybit.V5AccountServiceI = (*V5AccountService)(nil)
+
+// GetWalletBalance : calls GetWalletBalanceFunc, returns ErrNotProgrammed otherwise
+func (m *V5AccountService) GetWalletBalance(p0 bybit.AccountType, p1 []bybit.Coin) (r0 *bybit.V5WalletBalanceResponse, err error) {
+	m.record("GetWalletBalance", p0, p1)
+	if m.GetWalletBalanceFunc != nil {
+		return m.GetWalletBalanceFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetWalletBalanceContext : calls GetWalletBalanceContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5AccountService) GetWalletBalanceContext(p0 context.Context, p1 bybit.AccountType, p2 []bybit.Coin) (r0 *bybit.V5WalletBalanceResponse, err error) {
+	m.record("GetWalletBalanceContext", p0, p1, p2)
+	if m.GetWalletBalanceContextFunc != nil {
+		return m.GetWalletBalanceContextFunc(p0, p1, p2)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// V5AssetService : a mock of bybit.V5AssetServiceI
+type V5AssetService struct {
+	Recorder
+}
+
+var _ bybit.V5AssetServiceI = (*V5AssetService)(nil)
+
+// V5ExecutionService : a mock of bybit.V5ExecutionServiceI
+type V5ExecutionService struct {
+	Recorder
+}
+
+var _ bybit.V5ExecutionServiceI = (*V5ExecutionService)(nil)
+
+// V5MarketService : a mock of bybit.V5MarketServiceI
+type V5MarketService struct {
+	Recorder
+
+	GetKlineFunc                         func(bybit.V5GetKlineParam) (*bybit.V5GetKlineResponse, error)
+	GetKlineContextFunc                  func(context.Context, bybit.V5GetKlineParam) (*bybit.V5GetKlineResponse, error)
+	GetMarkPriceKlineFunc                func(bybit.V5GetMarkPriceKlineParam) (*bybit.V5GetMarkPriceKlineResponse, error)
+	GetMarkPriceKlineContextFunc         func(context.Context, bybit.V5GetMarkPriceKlineParam) (*bybit.V5GetMarkPriceKlineResponse, error)
+	GetIndexPriceKlineFunc               func(bybit.V5GetIndexPriceKlineParam) (*bybit.V5GetIndexPriceKlineResponse, error)
+	GetIndexPriceKlineContextFunc        func(context.Context, bybit.V5GetIndexPriceKlineParam) (*bybit.V5GetIndexPriceKlineResponse, error)
+	GetPremiumIndexPriceKlineFunc        func(bybit.V5GetPremiumIndexPriceKlineParam) (*bybit.V5GetPremiumIndexPriceKlineResponse, error)
+	GetPremiumIndexPriceKlineContextFunc func(context.Context, bybit.V5GetPremiumIndexPriceKlineParam) (*bybit.V5GetPremiumIndexPriceKlineResponse, error)
+	GetInstrumentsInfoFunc               func(bybit.V5GetInstrumentsInfoParam) (*bybit.V5GetInstrumentsInfoResponse, error)
+	GetInstrumentsInfoContextFunc        func(context.Context, bybit.V5GetInstrumentsInfoParam) (*bybit.V5GetInstrumentsInfoResponse, error)
+	GetOrderbookFunc                     func(bybit.V5GetOrderbookParam) (*bybit.V5GetOrderbookResponse, error)
+	GetOrderbookContextFunc              func(context.Context, bybit.V5GetOrderbookParam) (*bybit.V5GetOrderbookResponse, error)
+	GetTickersFunc                       func(bybit.V5GetTickersParam) (*bybit.V5GetTickersResponse, error)
+	GetTickersContextFunc                func(context.Context, bybit.V5GetTickersParam) (*bybit.V5GetTickersResponse, error)
+}
+
+var _ bybit.V5MarketServiceI = (*V5MarketService)(nil)
+
+// GetKline : calls GetKlineFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetKline(p0 bybit.V5GetKlineParam) (r0 *bybit.V5GetKlineResponse, err error) {
+	m.record("GetKline", p0)
+	if m.GetKlineFunc != nil {
+		return m.GetKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetKlineContext : calls GetKlineContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetKlineContext(p0 context.Context, p1 bybit.V5GetKlineParam) (r0 *bybit.V5GetKlineResponse, err error) {
+	m.record("GetKlineContext", p0, p1)
+	if m.GetKlineContextFunc != nil {
+		return m.GetKlineContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetMarkPriceKline : calls GetMarkPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetMarkPriceKline(p0 bybit.V5GetMarkPriceKlineParam) (r0 *bybit.V5GetMarkPriceKlineResponse, err error) {
+	m.record("GetMarkPriceKline", p0)
+	if m.GetMarkPriceKlineFunc != nil {
+		return m.GetMarkPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetMarkPriceKlineContext : calls GetMarkPriceKlineContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetMarkPriceKlineContext(p0 context.Context, p1 bybit.V5GetMarkPriceKlineParam) (r0 *bybit.V5GetMarkPriceKlineResponse, err error) {
+	m.record("GetMarkPriceKlineContext", p0, p1)
+	if m.GetMarkPriceKlineContextFunc != nil {
+		return m.GetMarkPriceKlineContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetIndexPriceKline : calls GetIndexPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetIndexPriceKline(p0 bybit.V5GetIndexPriceKlineParam) (r0 *bybit.V5GetIndexPriceKlineResponse, err error) {
+	m.record("GetIndexPriceKline", p0)
+	if m.GetIndexPriceKlineFunc != nil {
+		return m.GetIndexPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetIndexPriceKlineContext : calls GetIndexPriceKlineContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetIndexPriceKlineContext(p0 context.Context, p1 bybit.V5GetIndexPriceKlineParam) (r0 *bybit.V5GetIndexPriceKlineResponse, err error) {
+	m.record("GetIndexPriceKlineContext", p0, p1)
+	if m.GetIndexPriceKlineContextFunc != nil {
+		return m.GetIndexPriceKlineContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetPremiumIndexPriceKline : calls GetPremiumIndexPriceKlineFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetPremiumIndexPriceKline(p0 bybit.V5GetPremiumIndexPriceKlineParam) (r0 *bybit.V5GetPremiumIndexPriceKlineResponse, err error) {
+	m.record("GetPremiumIndexPriceKline", p0)
+	if m.GetPremiumIndexPriceKlineFunc != nil {
+		return m.GetPremiumIndexPriceKlineFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetPremiumIndexPriceKlineContext : calls GetPremiumIndexPriceKlineContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetPremiumIndexPriceKlineContext(p0 context.Context, p1 bybit.V5GetPremiumIndexPriceKlineParam) (r0 *bybit.V5GetPremiumIndexPriceKlineResponse, err error) {
+	m.record("GetPremiumIndexPriceKlineContext", p0, p1)
+	if m.GetPremiumIndexPriceKlineContextFunc != nil {
+		return m.GetPremiumIndexPriceKlineContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetInstrumentsInfo : calls GetInstrumentsInfoFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetInstrumentsInfo(p0 bybit.V5GetInstrumentsInfoParam) (r0 *bybit.V5GetInstrumentsInfoResponse, err error) {
+	m.record("GetInstrumentsInfo", p0)
+	if m.GetInstrumentsInfoFunc != nil {
+		return m.GetInstrumentsInfoFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetInstrumentsInfoContext : calls GetInstrumentsInfoContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetInstrumentsInfoContext(p0 context.Context, p1 bybit.V5GetInstrumentsInfoParam) (r0 *bybit.V5GetInstrumentsInfoResponse, err error) {
+	m.record("GetInstrumentsInfoContext", p0, p1)
+	if m.GetInstrumentsInfoContextFunc != nil {
+		return m.GetInstrumentsInfoContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetOrderbook : calls GetOrderbookFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetOrderbook(p0 bybit.V5GetOrderbookParam) (r0 *bybit.V5GetOrderbookResponse, err error) {
+	m.record("GetOrderbook", p0)
+	if m.GetOrderbookFunc != nil {
+		return m.GetOrderbookFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetOrderbookContext : calls GetOrderbookContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetOrderbookContext(p0 context.Context, p1 bybit.V5GetOrderbookParam) (r0 *bybit.V5GetOrderbookResponse, err error) {
+	m.record("GetOrderbookContext", p0, p1)
+	if m.GetOrderbookContextFunc != nil {
+		return m.GetOrderbookContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetTickers : calls GetTickersFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetTickers(p0 bybit.V5GetTickersParam) (r0 *bybit.V5GetTickersResponse, err error) {
+	m.record("GetTickers", p0)
+	if m.GetTickersFunc != nil {
+		return m.GetTickersFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetTickersContext : calls GetTickersContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5MarketService) GetTickersContext(p0 context.Context, p1 bybit.V5GetTickersParam) (r0 *bybit.V5GetTickersResponse, err error) {
+	m.record("GetTickersContext", p0, p1)
+	if m.GetTickersContextFunc != nil {
+		return m.GetTickersContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// V5OrderService : a mock of bybit.V5OrderServiceI
+type V5OrderService struct {
+	Recorder
+
+	CreateOrderFunc             func(bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error)
+	CreateOrderContextFunc      func(context.Context, bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error)
+	CancelOrderFunc             func(bybit.V5CancelOrderParam) (*bybit.V5CancelOrderResponse, error)
+	CancelOrderContextFunc      func(context.Context, bybit.V5CancelOrderParam) (*bybit.V5CancelOrderResponse, error)
+	GetOpenOrdersFunc           func(bybit.V5GetOpenOrdersParam) (*bybit.V5GetOpenOrdersResponse, error)
+	GetOpenOrdersContextFunc    func(context.Context, bybit.V5GetOpenOrdersParam) (*bybit.V5GetOpenOrdersResponse, error)
+	GetExecutionListFunc        func(bybit.V5GetExecutionListParam) (*bybit.V5GetExecutionListResponse, error)
+	GetExecutionListContextFunc func(context.Context, bybit.V5GetExecutionListParam) (*bybit.V5GetExecutionListResponse, error)
+	GetOrderListFunc            func(bybit.V5GetOrderListParam) (*bybit.V5GetOrderListResponse, error)
+	GetOrderListContextFunc     func(context.Context, bybit.V5GetOrderListParam) (*bybit.V5GetOrderListResponse, error)
+	GetClosedPnlFunc            func(bybit.V5GetClosedPnlParam) (*bybit.V5GetClosedPnlResponse, error)
+	GetClosedPnlContextFunc     func(context.Context, bybit.V5GetClosedPnlParam) (*bybit.V5GetClosedPnlResponse, error)
+}
+
+var _ bybit.V5OrderServiceI = (*V5OrderService)(nil)
+
+// CreateOrder : calls CreateOrderFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) CreateOrder(p0 bybit.V5CreateOrderParam) (r0 *bybit.V5CreateOrderResponse, err error) {
+	m.record("CreateOrder", p0)
+	if m.CreateOrderFunc != nil {
+		return m.CreateOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CreateOrderContext : calls CreateOrderContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) CreateOrderContext(p0 context.Context, p1 bybit.V5CreateOrderParam) (r0 *bybit.V5CreateOrderResponse, err error) {
+	m.record("CreateOrderContext", p0, p1)
+	if m.CreateOrderContextFunc != nil {
+		return m.CreateOrderContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CancelOrder : calls CancelOrderFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) CancelOrder(p0 bybit.V5CancelOrderParam) (r0 *bybit.V5CancelOrderResponse, err error) {
+	m.record("CancelOrder", p0)
+	if m.CancelOrderFunc != nil {
+		return m.CancelOrderFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// CancelOrderContext : calls CancelOrderContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) CancelOrderContext(p0 context.Context, p1 bybit.V5CancelOrderParam) (r0 *bybit.V5CancelOrderResponse, err error) {
+	m.record("CancelOrderContext", p0, p1)
+	if m.CancelOrderContextFunc != nil {
+		return m.CancelOrderContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetOpenOrders : calls GetOpenOrdersFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetOpenOrders(p0 bybit.V5GetOpenOrdersParam) (r0 *bybit.V5GetOpenOrdersResponse, err error) {
+	m.record("GetOpenOrders", p0)
+	if m.GetOpenOrdersFunc != nil {
+		return m.GetOpenOrdersFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetOpenOrdersContext : calls GetOpenOrdersContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetOpenOrdersContext(p0 context.Context, p1 bybit.V5GetOpenOrdersParam) (r0 *bybit.V5GetOpenOrdersResponse, err error) {
+	m.record("GetOpenOrdersContext", p0, p1)
+	if m.GetOpenOrdersContextFunc != nil {
+		return m.GetOpenOrdersContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetExecutionList : calls GetExecutionListFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetExecutionList(p0 bybit.V5GetExecutionListParam) (r0 *bybit.V5GetExecutionListResponse, err error) {
+	m.record("GetExecutionList", p0)
+	if m.GetExecutionListFunc != nil {
+		return m.GetExecutionListFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetExecutionListContext : calls GetExecutionListContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetExecutionListContext(p0 context.Context, p1 bybit.V5GetExecutionListParam) (r0 *bybit.V5GetExecutionListResponse, err error) {
+	m.record("GetExecutionListContext", p0, p1)
+	if m.GetExecutionListContextFunc != nil {
+		return m.GetExecutionListContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetOrderList : calls GetOrderListFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetOrderList(p0 bybit.V5GetOrderListParam) (r0 *bybit.V5GetOrderListResponse, err error) {
+	m.record("GetOrderList", p0)
+	if m.GetOrderListFunc != nil {
+		return m.GetOrderListFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetOrderListContext : calls GetOrderListContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetOrderListContext(p0 context.Context, p1 bybit.V5GetOrderListParam) (r0 *bybit.V5GetOrderListResponse, err error) {
+	m.record("GetOrderListContext", p0, p1)
+	if m.GetOrderListContextFunc != nil {
+		return m.GetOrderListContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetClosedPnl : calls GetClosedPnlFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetClosedPnl(p0 bybit.V5GetClosedPnlParam) (r0 *bybit.V5GetClosedPnlResponse, err error) {
+	m.record("GetClosedPnl", p0)
+	if m.GetClosedPnlFunc != nil {
+		return m.GetClosedPnlFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetClosedPnlContext : calls GetClosedPnlContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5OrderService) GetClosedPnlContext(p0 context.Context, p1 bybit.V5GetClosedPnlParam) (r0 *bybit.V5GetClosedPnlResponse, err error) {
+	m.record("GetClosedPnlContext", p0, p1)
+	if m.GetClosedPnlContextFunc != nil {
+		return m.GetClosedPnlContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// V5PositionService : a mock of bybit.V5PositionServiceI
+type V5PositionService struct {
+	Recorder
+
+	GetPositionInfoFunc        func(bybit.V5GetPositionInfoParam) (*bybit.V5GetPositionInfoResponse, error)
+	GetPositionInfoContextFunc func(context.Context, bybit.V5GetPositionInfoParam) (*bybit.V5GetPositionInfoResponse, error)
+	SetLeverageFunc            func(bybit.V5SetLeverageParam) (*bybit.V5SetLeverageResponse, error)
+	SetLeverageContextFunc     func(context.Context, bybit.V5SetLeverageParam) (*bybit.V5SetLeverageResponse, error)
+}
+
+var _ bybit.V5PositionServiceI = (*V5PositionService)(nil)
+
+// GetPositionInfo : calls GetPositionInfoFunc, returns ErrNotProgrammed otherwise
+func (m *V5PositionService) GetPositionInfo(p0 bybit.V5GetPositionInfoParam) (r0 *bybit.V5GetPositionInfoResponse, err error) {
+	m.record("GetPositionInfo", p0)
+	if m.GetPositionInfoFunc != nil {
+		return m.GetPositionInfoFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetPositionInfoContext : calls GetPositionInfoContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5PositionService) GetPositionInfoContext(p0 context.Context, p1 bybit.V5GetPositionInfoParam) (r0 *bybit.V5GetPositionInfoResponse, err error) {
+	m.record("GetPositionInfoContext", p0, p1)
+	if m.GetPositionInfoContextFunc != nil {
+		return m.GetPositionInfoContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SetLeverage : calls SetLeverageFunc, returns ErrNotProgrammed otherwise
+func (m *V5PositionService) SetLeverage(p0 bybit.V5SetLeverageParam) (r0 *bybit.V5SetLeverageResponse, err error) {
+	m.record("SetLeverage", p0)
+	if m.SetLeverageFunc != nil {
+		return m.SetLeverageFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SetLeverageContext : calls SetLeverageContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5PositionService) SetLeverageContext(p0 context.Context, p1 bybit.V5SetLeverageParam) (r0 *bybit.V5SetLeverageResponse, err error) {
+	m.record("SetLeverageContext", p0, p1)
+	if m.SetLeverageContextFunc != nil {
+		return m.SetLeverageContextFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// V5Service : a mock of bybit.V5ServiceI
+type V5Service struct {
+	Recorder
+
+	MarketFunc            func() bybit.V5MarketServiceI
+	MarketMock            *V5MarketService
+	OrderFunc             func() bybit.V5OrderServiceI
+	OrderMock             *V5OrderService
+	PositionFunc          func() bybit.V5PositionServiceI
+	PositionMock          *V5PositionService
+	ExecutionFunc         func() bybit.V5ExecutionServiceI
+	ExecutionMock         *V5ExecutionService
+	AccountFunc           func() bybit.V5AccountServiceI
+	AccountMock           *V5AccountService
+	SpotLeverageTokenFunc func() bybit.V5SpotLeverageTokenServiceI
+	SpotLeverageTokenMock *V5SpotLeverageTokenService
+	SpotMarginTradeFunc   func() bybit.V5SpotMarginTradeServiceI
+	SpotMarginTradeMock   *V5SpotMarginTradeService
+	AssetFunc             func() bybit.V5AssetServiceI
+	AssetMock             *V5AssetService
+	UserFunc              func() bybit.V5UserServiceI
+	UserMock              *V5UserService
+}
+
+var _ bybit.V5ServiceI = (*V5Service)(nil)
+
+// Market : calls MarketFunc, returns MarketMock otherwise
+func (m *V5Service) Market() (r0 bybit.V5MarketServiceI) {
+	m.record("Market")
+	if m.MarketFunc != nil {
+		return m.MarketFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.MarketMock == nil {
+		m.MarketMock = &V5MarketService{}
+	}
+	return m.MarketMock
+}
+
+// Order : calls OrderFunc, returns OrderMock otherwise
+func (m *V5Service) Order() (r0 bybit.V5OrderServiceI) {
+	m.record("Order")
+	if m.OrderFunc != nil {
+		return m.OrderFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.OrderMock == nil {
+		m.OrderMock = &V5OrderService{}
+	}
+	return m.OrderMock
+}
+
+// Position : calls PositionFunc, returns PositionMock otherwise
+func (m *V5Service) Position() (r0 bybit.V5PositionServiceI) {
+	m.record("Position")
+	if m.PositionFunc != nil {
+		return m.PositionFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.PositionMock == nil {
+		m.PositionMock = &V5PositionService{}
+	}
+	return m.PositionMock
+}
+
+// Execution : calls ExecutionFunc, returns ExecutionMock otherwise
+func (m *V5Service) Execution() (r0 bybit.V5ExecutionServiceI) {
+	m.record("Execution")
+	if m.ExecutionFunc != nil {
+		return m.ExecutionFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ExecutionMock == nil {
+		m.ExecutionMock = &V5ExecutionService{}
+	}
+	return m.ExecutionMock
+}
+
+// Account : calls AccountFunc, returns AccountMock otherwise
+func (m *V5Service) Account() (r0 bybit.V5AccountServiceI) {
+	m.record("Account")
+	if m.AccountFunc != nil {
+		return m.AccountFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.AccountMock == nil {
+		m.AccountMock = &V5AccountService{}
+	}
+	return m.AccountMock
+}
+
+// SpotLeverageToken : calls SpotLeverageTokenFunc, returns SpotLeverageTokenMock otherwise
+func (m *V5Service) SpotLeverageToken() (r0 bybit.V5SpotLeverageTokenServiceI) {
+	m.record("SpotLeverageToken")
+	if m.SpotLeverageTokenFunc != nil {
+		return m.SpotLeverageTokenFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.SpotLeverageTokenMock == nil {
+		m.SpotLeverageTokenMock = &V5SpotLeverageTokenService{}
+	}
+	return m.SpotLeverageTokenMock
+}
+
+// SpotMarginTrade : calls SpotMarginTradeFunc, returns SpotMarginTradeMock otherwise
+func (m *V5Service) SpotMarginTrade() (r0 bybit.V5SpotMarginTradeServiceI) {
+	m.record("SpotMarginTrade")
+	if m.SpotMarginTradeFunc != nil {
+		return m.SpotMarginTradeFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.SpotMarginTradeMock == nil {
+		m.SpotMarginTradeMock = &V5SpotMarginTradeService{}
+	}
+	return m.SpotMarginTradeMock
+}
+
+// Asset : calls AssetFunc, returns AssetMock otherwise
+func (m *V5Service) Asset() (r0 bybit.V5AssetServiceI) {
+	m.record("Asset")
+	if m.AssetFunc != nil {
+		return m.AssetFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.AssetMock == nil {
+		m.AssetMock = &V5AssetService{}
+	}
+	return m.AssetMock
+}
+
+// User : calls UserFunc, returns UserMock otherwise
+func (m *V5Service) User() (r0 bybit.V5UserServiceI) {
+	m.record("User")
+	if m.UserFunc != nil {
+		return m.UserFunc()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.UserMock == nil {
+		m.UserMock = &V5UserService{}
+	}
+	return m.UserMock
+}
+
+// V5SpotLeverageTokenService : a mock of bybit.V5SpotLeverageTokenServiceI
+type V5SpotLeverageTokenService struct {
+	Recorder
+}
+
+var _ bybit.V5SpotLeverageTokenServiceI = (*V5SpotLeverageTokenService)(nil)
+
+// V5SpotMarginTradeService : a mock of bybit.V5SpotMarginTradeServiceI
+type V5SpotMarginTradeService struct {
+	Recorder
+}
+
+var _ bybit.V5SpotMarginTradeServiceI = (*V5SpotMarginTradeService)(nil)
+
+// V5UserService : a mock of bybit.V5UserServiceI
+type V5UserService struct {
+	Recorder
+
+	GetAPIKeyFunc        func() (*bybit.V5APIKeyResponse, error)
+	GetAPIKeyContextFunc func(context.Context) (*bybit.V5APIKeyResponse, error)
+}
+
+var _ bybit.V5UserServiceI = (*V5UserService)(nil)
+
+// GetAPIKey : calls GetAPIKeyFunc, returns ErrNotProgrammed otherwise
+func (m *V5UserService) GetAPIKey() (r0 *bybit.V5APIKeyResponse, err error) {
+	m.record("GetAPIKey")
+	if m.GetAPIKeyFunc != nil {
+		return m.GetAPIKeyFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// GetAPIKeyContext : calls GetAPIKeyContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5UserService) GetAPIKeyContext(p0 context.Context) (r0 *bybit.V5APIKeyResponse, err error) {
+	m.record("GetAPIKeyContext", p0)
+	if m.GetAPIKeyContextFunc != nil {
+		return m.GetAPIKeyContextFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// V5WebsocketPublicService : a mock of bybit.V5WebsocketPublicServiceI
+type V5WebsocketPublicService struct {
+	Recorder
+
+	StartFunc              func(context.Context)
+	RunFunc                func() error
+	RunContextFunc         func(context.Context) error
+	MessageContextFunc     func() context.Context
+	PingFunc               func() error
+	CloseFunc              func() error
+	SubscribeOrderBookFunc func(bybit.V5WebsocketPublicOrderBookParamKey, func(bybit.V5WebsocketPublicOrderBookResponse) error) (func() error, error)
+	SubscribeTradeFunc     func(bybit.V5WebsocketPublicTradeParamKey, func(bybit.V5WebsocketPublicTradeResponse) error) (func() error, error)
+	SubscribeTickerFunc    func(bybit.V5WebsocketPublicTickerParamKey, func(bybit.V5WebsocketPublicTickerResponse) error) (func() error, error)
+	SubscribeKlineFunc     func(bybit.V5WebsocketPublicKlineParamKey, func(bybit.V5WebsocketPublicKlineResponse) error) (func() error, error)
+}
+
+var _ bybit.V5WebsocketPublicServiceI = (*V5WebsocketPublicService)(nil)
+
+// Start : calls StartFunc when programmed
+func (m *V5WebsocketPublicService) Start(p0 context.Context) {
+	m.record("Start", p0)
+	if m.StartFunc != nil {
+		m.StartFunc(p0)
+		return
+	}
+}
+
+// Run : calls RunFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) Run() (err error) {
+	m.record("Run")
+	if m.RunFunc != nil {
+		return m.RunFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// RunContext : calls RunContextFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) RunContext(p0 context.Context) (err error) {
+	m.record("RunContext", p0)
+	if m.RunContextFunc != nil {
+		return m.RunContextFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// MessageContext : calls MessageContextFunc when programmed
+func (m *V5WebsocketPublicService) MessageContext() (r0 context.Context) {
+	m.record("MessageContext")
+	if m.MessageContextFunc != nil {
+		return m.MessageContextFunc()
+	}
+	return
+}
+
+// Ping : calls PingFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) Ping() (err error) {
+	m.record("Ping")
+	if m.PingFunc != nil {
+		return m.PingFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// Close : calls CloseFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) Close() (err error) {
+	m.record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SubscribeOrderBook : calls SubscribeOrderBookFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) SubscribeOrderBook(p0 bybit.V5WebsocketPublicOrderBookParamKey, p1 func(bybit.V5WebsocketPublicOrderBookResponse) error) (r0 func() error, err error) {
+	m.record("SubscribeOrderBook", p0, p1)
+	if m.SubscribeOrderBookFunc != nil {
+		return m.SubscribeOrderBookFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SubscribeTrade : calls SubscribeTradeFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) SubscribeTrade(p0 bybit.V5WebsocketPublicTradeParamKey, p1 func(bybit.V5WebsocketPublicTradeResponse) error) (r0 func() error, err error) {
+	m.record("SubscribeTrade", p0, p1)
+	if m.SubscribeTradeFunc != nil {
+		return m.SubscribeTradeFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SubscribeTicker : calls SubscribeTickerFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) SubscribeTicker(p0 bybit.V5WebsocketPublicTickerParamKey, p1 func(bybit.V5WebsocketPublicTickerResponse) error) (r0 func() error, err error) {
+	m.record("SubscribeTicker", p0, p1)
+	if m.SubscribeTickerFunc != nil {
+		return m.SubscribeTickerFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// SubscribeKline : calls SubscribeKlineFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketPublicService) SubscribeKline(p0 bybit.V5WebsocketPublicKlineParamKey, p1 func(bybit.V5WebsocketPublicKlineResponse) error) (r0 func() error, err error) {
+	m.record("SubscribeKline", p0, p1)
+	if m.SubscribeKlineFunc != nil {
+		return m.SubscribeKlineFunc(p0, p1)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// V5WebsocketService : a mock of bybit.V5WebsocketServiceI
+type V5WebsocketService struct {
+	Recorder
+
+	PublicFunc     func(bybit.CategoryV5) (bybit.V5WebsocketPublicServiceI, error)
+	PublicPoolFunc func(bybit.CategoryV5) *bybit.V5WebsocketPublicPool
+}
+
+var _ bybit.V5WebsocketServiceI = (*V5WebsocketService)(nil)
+
+// Public : calls PublicFunc, returns ErrNotProgrammed otherwise
+func (m *V5WebsocketService) Public(p0 bybit.CategoryV5) (r0 bybit.V5WebsocketPublicServiceI, err error) {
+	m.record("Public", p0)
+	if m.PublicFunc != nil {
+		return m.PublicFunc(p0)
+	}
+	err = ErrNotProgrammed
+	return
+}
+
+// PublicPool : calls PublicPoolFunc when programmed
+func (m *V5WebsocketService) PublicPool(p0 bybit.CategoryV5) (r0 *bybit.V5WebsocketPublicPool) {
+	m.record("PublicPool", p0)
+	if m.PublicPoolFunc != nil {
+		return m.PublicPoolFunc(p0)
+	}
+	return
+}
@@ -0,0 +1,195 @@
+package candle
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// Trade : one public trade
+type Trade struct {
+	Time  time.Time
+	Price bybit.Decimal
+	Qty   bybit.Decimal
+}
+
+// SpotV1Trade :
+func SpotV1Trade(content bybit.SpotWebsocketV1PublicV1TradeContent) (Trade, error) {
+	return newTrade(int64(content.Timestamp), content.Price, content.Quantity)
+}
+
+// V5Trade : from the publicTrade topic
+func V5Trade(data bybit.V5WebsocketPublicTradeData) (Trade, error) {
+	return newTrade(data.Timestamp, data.Price, data.Size)
+}
+
+func newTrade(ms int64, price, qty string) (Trade, error) {
+	p, err := bybit.NewDecimalFromString(price)
+	if err != nil {
+		return Trade{}, fmt.Errorf("price: %w", err)
+	}
+	q, err := bybit.NewDecimalFromString(qty)
+	if err != nil {
+		return Trade{}, fmt.Errorf("qty: %w", err)
+	}
+	return Trade{Time: time.UnixMilli(ms).UTC(), Price: p, Qty: q}, nil
+}
+
+// Aggregator : builds bars from trades. onBar gets the running bar after every trade and the bar once more with
+// Closed set when a later trade or Flush ends it. Periods without trades have no bar.
+type Aggregator struct {
+	timeframe Timeframe
+	inverse   bool
+	onBar     func(Bar)
+
+	mu      sync.Mutex
+	current *Bar
+	// closedUntil : the end of the last closed bar, older trades are dropped
+	closedUntil time.Time
+}
+
+// NewAggregator : onBar is called from the goroutine that calls Add or Flush
+func NewAggregator(timeframe Timeframe, onBar func(Bar)) (*Aggregator, error) {
+	if _, _, err := timeframe.unit(); err != nil {
+		return nil, err
+	}
+	return &Aggregator{timeframe: timeframe, onBar: onBar}, nil
+}
+
+// WithInverse : for inverse contracts, where qty is in USD and turnover in the base coin
+func (a *Aggregator) WithInverse() *Aggregator {
+	a.inverse = true
+	return a
+}
+
+// Add : false when the trade belongs to a bar that is closed or older than the running one, it is dropped then
+func (a *Aggregator) Add(trade Trade) bool {
+	a.mu.Lock()
+	var emit []Bar
+	ok := a.add(trade, &emit)
+	a.mu.Unlock()
+
+	a.emit(emit)
+	return ok
+}
+
+// AddSpotV1 : can be passed to SubscribeTrade of the spot v1 public service
+func (a *Aggregator) AddSpotV1(response bybit.SpotWebsocketV1PublicV1TradeResponse) error {
+	trades := make([]Trade, 0, len(response.Data))
+	for _, content := range response.Data {
+		trade, err := SpotV1Trade(content)
+		if err != nil {
+			return err
+		}
+		trades = append(trades, trade)
+	}
+	a.addAll(trades)
+	return nil
+}
+
+// AddV5 : can be passed to SubscribeTrade of the v5 public service
+func (a *Aggregator) AddV5(response bybit.V5WebsocketPublicTradeResponse) error {
+	trades := make([]Trade, 0, len(response.Data))
+	for _, data := range response.Data {
+		trade, err := V5Trade(data)
+		if err != nil {
+			return err
+		}
+		trades = append(trades, trade)
+	}
+	a.addAll(trades)
+	return nil
+}
+
+func (a *Aggregator) addAll(trades []Trade) {
+	a.mu.Lock()
+	var emit []Bar
+	for _, trade := range trades {
+		a.add(trade, &emit)
+	}
+	a.mu.Unlock()
+
+	a.emit(emit)
+}
+
+func (a *Aggregator) add(trade Trade, emit *[]Bar) bool {
+	if trade.Time.Before(a.closedUntil) || a.current != nil && trade.Time.Before(a.current.Start) {
+		return false
+	}
+	if a.current != nil && !trade.Time.Before(a.current.End) {
+		a.close(emit)
+	}
+
+	turnover := trade.Price.Mul(trade.Qty.Decimal)
+	if a.inverse {
+		turnover = trade.Qty.Decimal
+		if !trade.Price.IsZero() {
+			turnover = trade.Qty.Div(trade.Price.Decimal)
+		}
+	}
+	if a.current == nil {
+		start := a.timeframe.Start(trade.Time)
+		a.current = &Bar{
+			Start:    start,
+			End:      a.timeframe.Next(start),
+			Open:     trade.Price,
+			High:     trade.Price,
+			Low:      trade.Price,
+			Close:    trade.Price,
+			Volume:   trade.Qty,
+			Turnover: bybit.NewDecimal(turnover),
+			Trades:   1,
+		}
+	} else {
+		merge(a.current, Bar{
+			High:     trade.Price,
+			Low:      trade.Price,
+			Close:    trade.Price,
+			Volume:   trade.Qty,
+			Turnover: bybit.NewDecimal(turnover),
+			Trades:   1,
+		})
+	}
+	*emit = append(*emit, *a.current)
+	return true
+}
+
+// Flush : closes the running bar when now is past its end, call it from a ticker so that a bar closes without waiting for the next trade
+func (a *Aggregator) Flush(now time.Time) {
+	a.mu.Lock()
+	var emit []Bar
+	if a.current != nil && !now.Before(a.current.End) {
+		a.close(&emit)
+	}
+	a.mu.Unlock()
+
+	a.emit(emit)
+}
+
+func (a *Aggregator) close(emit *[]Bar) {
+	a.current.Closed = true
+	a.closedUntil = a.current.End
+	*emit = append(*emit, *a.current)
+	a.current = nil
+}
+
+// Current : the running bar
+func (a *Aggregator) Current() (Bar, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.current == nil {
+		return Bar{}, false
+	}
+	return *a.current, true
+}
+
+func (a *Aggregator) emit(bars []Bar) {
+	if a.onBar == nil {
+		return
+	}
+	for _, bar := range bars {
+		a.onBar(bar)
+	}
+}
@@ -0,0 +1,70 @@
+package candle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregator(t *testing.T) {
+	base := time.Date(2023, 11, 29, 13, 0, 0, 0, time.UTC)
+	ms := func(d time.Duration) int64 { return base.Add(d).UnixMilli() }
+
+	var bars []Bar
+	aggregator, err := NewAggregator(Timeframe{bybit.Interval1, 1}, func(bar Bar) { bars = append(bars, bar) })
+	require.NoError(t, err)
+
+	require.NoError(t, aggregator.AddV5(bybit.V5WebsocketPublicTradeResponse{
+		Data: []bybit.V5WebsocketPublicTradeData{
+			{Timestamp: ms(time.Second), Price: "37500.5", Size: "0.010"},
+			{Timestamp: ms(20 * time.Second), Price: "37510", Size: "0.5"},
+			{Timestamp: ms(59 * time.Second), Price: "37490", Size: "0.1"},
+		},
+	}))
+	require.Len(t, bars, 3)
+	running, ok := aggregator.Current()
+	require.True(t, ok)
+	assert.False(t, running.Closed)
+	assert.Equal(t, 3, running.Trades)
+
+	// the next minute closes the first bar
+	require.NoError(t, aggregator.AddSpotV1(bybit.SpotWebsocketV1PublicV1TradeResponse{
+		Data: []bybit.SpotWebsocketV1PublicV1TradeContent{
+			{Timestamp: int(ms(61 * time.Second)), Price: "37495", Quantity: "1"},
+		},
+	}))
+	require.Len(t, bars, 5)
+	closed := bars[3]
+	assert.True(t, closed.Closed)
+	assert.Equal(t, base, closed.Start)
+	assert.Equal(t, base.Add(time.Minute), closed.End)
+	assert.Equal(t, "37500.5", closed.Open.String())
+	assert.Equal(t, "37510", closed.High.String())
+	assert.Equal(t, "37490", closed.Low.String())
+	assert.Equal(t, "37490", closed.Close.String())
+	assert.Equal(t, "0.610", closed.Volume.String())
+	assert.Equal(t, "22879.0050", closed.Turnover.String())
+	assert.False(t, bars[4].Closed)
+
+	// too late for the closed bar
+	late, err := V5Trade(bybit.V5WebsocketPublicTradeData{Timestamp: ms(30 * time.Second), Price: "1", Size: "1"})
+	require.NoError(t, err)
+	assert.False(t, aggregator.Add(late))
+
+	aggregator.Flush(base.Add(90 * time.Second))
+	assert.Len(t, bars, 5)
+	aggregator.Flush(base.Add(2 * time.Minute))
+	require.Len(t, bars, 6)
+	assert.True(t, bars[5].Closed)
+	_, ok = aggregator.Current()
+	assert.False(t, ok)
+
+	inverse, err := NewAggregator(Timeframe{bybit.Interval60, 1}, nil)
+	require.NoError(t, err)
+	inverse.WithInverse().Add(Trade{Time: base, Price: bybit.RequireDecimal("40000"), Qty: bybit.RequireDecimal("100")})
+	running, _ = inverse.Current()
+	assert.True(t, running.Turnover.Equal(bybit.RequireDecimal("0.0025").Decimal))
+}
@@ -0,0 +1,234 @@
+// Package candle builds bars of any timeframe, from shorter klines or from public trades.
+package candle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// Bar : OHLCV of [Start, End)
+type Bar struct {
+	Start    time.Time
+	End      time.Time
+	Open     bybit.Decimal
+	High     bybit.Decimal
+	Low      bybit.Decimal
+	Close    bybit.Decimal
+	Volume   bybit.Decimal
+	Turnover bybit.Decimal
+	// Trades : only counted by the Aggregator
+	Trades int
+	// Closed : false while End is still ahead
+	Closed bool
+}
+
+// Timeframe : Multiple times Interval, e.g. {bybit.Interval120, 1} or {bybit.IntervalD, 3}.
+// Bars are aligned to the unix epoch, weeks start on monday and months on the first, all in UTC.
+type Timeframe struct {
+	Interval bybit.Interval
+	Multiple int
+}
+
+type unit int
+
+const (
+	unitMinute unit = iota
+	unitWeek
+	unitMonth
+)
+
+// mondayEpoch : the first monday after the unix epoch, where weeks are counted from
+var mondayEpoch = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// NewTimeframe :
+func NewTimeframe(interval bybit.Interval, multiple int) (Timeframe, error) {
+	tf := Timeframe{Interval: interval, Multiple: multiple}
+	if _, _, err := tf.unit(); err != nil {
+		return Timeframe{}, err
+	}
+	return tf, nil
+}
+
+// unit : days are counted as 1440 minutes
+func (tf Timeframe) unit() (unit, int, error) {
+	if tf.Multiple <= 0 {
+		return 0, 0, fmt.Errorf("multiple must be positive, got %d", tf.Multiple)
+	}
+	switch tf.Interval {
+	case bybit.IntervalD:
+		return unitMinute, 1440 * tf.Multiple, nil
+	case bybit.IntervalW:
+		return unitWeek, tf.Multiple, nil
+	case bybit.IntervalM:
+		return unitMonth, tf.Multiple, nil
+	}
+	minutes, err := strconv.Atoi(string(tf.Interval))
+	if err != nil || minutes <= 0 {
+		return 0, 0, fmt.Errorf("unsupported interval %q", tf.Interval)
+	}
+	return unitMinute, minutes * tf.Multiple, nil
+}
+
+// Start : the start of the bar t falls in
+func (tf Timeframe) Start(t time.Time) time.Time {
+	u, n, _ := tf.unit()
+	t = t.UTC()
+	switch u {
+	case unitWeek:
+		week := int64(7 * 24 * time.Hour)
+		weeks := floorDiv(floorDiv(int64(t.Sub(mondayEpoch)), week), int64(n)) * int64(n)
+		return mondayEpoch.AddDate(0, 0, int(weeks)*7)
+	case unitMonth:
+		months := int64(t.Year())*12 + int64(t.Month()) - 1
+		months = floorDiv(months, int64(n)) * int64(n)
+		return time.Date(int(months/12), time.Month(months%12+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+	ms := int64(n) * time.Minute.Milliseconds()
+	return time.UnixMilli(floorDiv(t.UnixMilli(), ms) * ms).UTC()
+}
+
+// Next : the start of the bar after the one starting at start
+func (tf Timeframe) Next(start time.Time) time.Time {
+	u, n, _ := tf.unit()
+	switch u {
+	case unitWeek:
+		return start.AddDate(0, 0, 7*n)
+	case unitMonth:
+		return start.AddDate(0, n, 0)
+	}
+	return start.Add(time.Duration(n) * time.Minute)
+}
+
+// Divides : whether bars of tf add up exactly to bars of other
+func (tf Timeframe) Divides(other Timeframe) bool {
+	u, n, err := tf.unit()
+	if err != nil {
+		return false
+	}
+	ou, on, err := other.unit()
+	if err != nil {
+		return false
+	}
+	switch ou {
+	case unitMonth:
+		return u == unitMonth && on%n == 0 || u == unitMinute && 1440%n == 0
+	case unitWeek:
+		return u == unitWeek && on%n == 0 || u == unitMinute && 1440%n == 0
+	}
+	return u == unitMinute && on%n == 0
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// FromV5Kline : bars of source, the oldest first
+func FromV5Kline(list bybit.V5GetKlineList, source bybit.Interval) ([]Bar, error) {
+	tf := Timeframe{Interval: source, Multiple: 1}
+	if _, _, err := tf.unit(); err != nil {
+		return nil, err
+	}
+	bars := make([]Bar, 0, len(list))
+	for _, item := range list {
+		decimals, err := item.Decimal()
+		if err != nil {
+			return nil, err
+		}
+		bars = append(bars, Bar{
+			Start:    item.StartTime.UTC(),
+			End:      tf.Next(item.StartTime.UTC()),
+			Open:     decimals.Open,
+			High:     decimals.High,
+			Low:      decimals.Low,
+			Close:    decimals.Close,
+			Volume:   decimals.Volume,
+			Turnover: decimals.Turnover,
+		})
+	}
+	sort.Slice(bars, func(i, j int) bool { return bars[i].Start.Before(bars[j].Start) })
+	return bars, nil
+}
+
+// Resampler : merges bars of one timeframe into a longer one
+type Resampler struct {
+	source Timeframe
+	target Timeframe
+	now    func() time.Time
+}
+
+// NewResampler : target must be a multiple of source, e.g. 60 into 120 or D into 3 D, but not W into M
+func NewResampler(source bybit.Interval, target Timeframe) (*Resampler, error) {
+	sourceTf, err := NewTimeframe(source, 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := target.unit(); err != nil {
+		return nil, err
+	}
+	if !sourceTf.Divides(target) {
+		return nil, fmt.Errorf("%d x %s is not a multiple of %s", target.Multiple, target.Interval, source)
+	}
+	return &Resampler{source: sourceTf, target: target, now: time.Now}, nil
+}
+
+// Resample : bars in any order, the result is the oldest first.
+// A bar is Closed once its End has passed, even when some of the source bars were missing.
+func (r *Resampler) Resample(bars []Bar) []Bar {
+	sorted := append([]Bar(nil), bars...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	now := r.now()
+	var result []Bar
+	for _, bar := range sorted {
+		start := r.target.Start(bar.Start)
+		if len(result) > 0 && result[len(result)-1].Start.Equal(start) {
+			merge(&result[len(result)-1], bar)
+			continue
+		}
+		end := r.target.Next(start)
+		result = append(result, Bar{
+			Start:    start,
+			End:      end,
+			Open:     bar.Open,
+			High:     bar.High,
+			Low:      bar.Low,
+			Close:    bar.Close,
+			Volume:   bar.Volume,
+			Turnover: bar.Turnover,
+			Trades:   bar.Trades,
+			Closed:   !end.After(now),
+		})
+	}
+	return result
+}
+
+// ResampleV5Kline : GetKline result of the source interval
+func (r *Resampler) ResampleV5Kline(list bybit.V5GetKlineList) ([]Bar, error) {
+	bars, err := FromV5Kline(list, r.source.Interval)
+	if err != nil {
+		return nil, err
+	}
+	return r.Resample(bars), nil
+}
+
+// merge : bar comes after b
+func merge(b *Bar, bar Bar) {
+	if bar.High.GreaterThan(b.High.Decimal) {
+		b.High = bar.High
+	}
+	if bar.Low.LessThan(b.Low.Decimal) {
+		b.Low = bar.Low
+	}
+	b.Close = bar.Close
+	b.Volume = bybit.NewDecimal(b.Volume.Add(bar.Volume.Decimal))
+	b.Turnover = bybit.NewDecimal(b.Turnover.Add(bar.Turnover.Decimal))
+	b.Trades += bar.Trades
+}
@@ -0,0 +1,74 @@
+package candle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeframe(t *testing.T) {
+	at := time.Date(2023, 11, 29, 13, 47, 12, 0, time.UTC) // a wednesday
+	tests := []struct {
+		timeframe Timeframe
+		start     time.Time
+		next      time.Time
+	}{
+		{Timeframe{bybit.Interval60, 2}, time.Date(2023, 11, 29, 12, 0, 0, 0, time.UTC), time.Date(2023, 11, 29, 14, 0, 0, 0, time.UTC)},
+		{Timeframe{bybit.Interval15, 1}, time.Date(2023, 11, 29, 13, 45, 0, 0, time.UTC), time.Date(2023, 11, 29, 14, 0, 0, 0, time.UTC)},
+		{Timeframe{bybit.IntervalD, 3}, time.Date(2023, 11, 28, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{Timeframe{bybit.IntervalW, 1}, time.Date(2023, 11, 27, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC)},
+		{Timeframe{bybit.IntervalM, 3}, time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.start, tt.timeframe.Start(at), "%+v", tt.timeframe)
+		assert.Equal(t, tt.next, tt.timeframe.Next(tt.start), "%+v", tt.timeframe)
+	}
+
+	_, err := NewTimeframe(bybit.SpotInterval1h, 2)
+	assert.Error(t, err)
+	_, err = NewTimeframe(bybit.Interval60, 0)
+	assert.Error(t, err)
+
+	_, err = NewResampler(bybit.IntervalW, Timeframe{bybit.IntervalM, 1})
+	assert.Error(t, err)
+	_, err = NewResampler(bybit.Interval120, Timeframe{bybit.Interval60, 3})
+	assert.Error(t, err)
+	_, err = NewResampler(bybit.IntervalD, Timeframe{bybit.IntervalW, 1})
+	assert.NoError(t, err)
+}
+
+func TestResampleV5Kline(t *testing.T) {
+	hour := func(h int) bybit.MillisTime {
+		return bybit.NewMillisTime(time.Date(2023, 11, 29, h, 0, 0, 0, time.UTC).UnixMilli())
+	}
+	// newest first, as GetKline returns them
+	list := bybit.V5GetKlineList{
+		{StartTime: hour(14), Open: "37500", High: "37600", Low: "37400", Close: "37550", Volume: "2", Turnover: "75000"},
+		{StartTime: hour(13), Open: "37300", High: "37520", Low: "37290", Close: "37500", Volume: "1.5", Turnover: "56000.5"},
+		{StartTime: hour(12), Open: "37200", High: "37400", Low: "37100", Close: "37300", Volume: "1", Turnover: "37250"},
+	}
+
+	resampler, err := NewResampler(bybit.Interval60, Timeframe{bybit.Interval60, 2})
+	require.NoError(t, err)
+	resampler.now = func() time.Time { return time.Date(2023, 11, 29, 14, 30, 0, 0, time.UTC) }
+	bars, err := resampler.ResampleV5Kline(list)
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+
+	first := bars[0]
+	assert.Equal(t, time.Date(2023, 11, 29, 12, 0, 0, 0, time.UTC), first.Start)
+	assert.Equal(t, time.Date(2023, 11, 29, 14, 0, 0, 0, time.UTC), first.End)
+	assert.Equal(t, "37200", first.Open.String())
+	assert.Equal(t, "37520", first.High.String())
+	assert.Equal(t, "37100", first.Low.String())
+	assert.Equal(t, "37500", first.Close.String())
+	assert.Equal(t, "2.5", first.Volume.String())
+	assert.Equal(t, "93250.5", first.Turnover.String())
+	assert.True(t, first.Closed)
+
+	assert.Equal(t, "37500", bars[1].Open.String())
+	assert.False(t, bars[1].Closed)
+}
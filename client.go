@@ -2,6 +2,7 @@ package bybit
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -30,7 +31,8 @@ type Logger interface {
 	Errorf(template string, args ...interface{})
 }
 
-// Client :
+// Client : safe for concurrent use, its configuration is set by NewClient and never changes afterwards.
+// The With methods and Clone return a configured copy instead.
 type Client struct {
 	httpClient *http.Client
 
@@ -42,39 +44,49 @@ type Client struct {
 	logger Logger
 
 	checkResponseBody checkResponseBodyFunc
+
+	instruments *InstrumentRegistry
+
+	middlewares []Middleware
+	metrics     MetricsSink
+	tracer      Tracer
+	requestLog  func(context.Context, RequestLog)
+	logOptions  LogOptions
 }
 
-// NewClient :
-func NewClient() *Client {
-	return &Client{
+// NewClient : for the main net, configured by opts, e.g.
+//
+//	client := bybit.NewClient(
+//		bybit.WithAuthOption("your api key", "your api secret"),
+//		bybit.WithHTTPClientOption(&http.Client{Timeout: 10 * time.Second}),
+//	)
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{},
 
 		baseURL:           MainNetBaseURL,
 		checkResponseBody: checkResponseBody,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-// WithHTTPClient :
+// WithHTTPClient : a copy of the client sending with httpClient
 func (c *Client) WithHTTPClient(httpClient *http.Client) *Client {
-	c.httpClient = httpClient
-
-	return c
+	return c.Clone(WithHTTPClientOption(httpClient))
 }
 
-// WithHTTPClient :
+// Debug : a copy of the client logging requests, responses and bodies to logger, redacted
 func (c *Client) Debug(logger Logger) *Client {
-	c.debug = true
-	c.logger = logger
-
-	return c
+	return c.Clone(WithDebugOption(logger))
 }
 
-// WithAuth :
+// WithAuth : a copy of the client authenticated with key and secret
 func (c *Client) WithAuth(key string, secret string) *Client {
-	c.key = key
-	c.secret = secret
-
-	return c
+	return c.Clone(WithAuthOption(key, secret))
 }
 
 func (c Client) withCheckResponseBody(f checkResponseBodyFunc) *Client {
@@ -83,11 +95,14 @@ func (c Client) withCheckResponseBody(f checkResponseBodyFunc) *Client {
 	return &c
 }
 
-// WithBaseURL :
+// WithBaseURL : a copy of the client sending to url
 func (c *Client) WithBaseURL(url string) *Client {
-	c.baseURL = url
+	return c.Clone(WithBaseURLOption(url))
+}
 
-	return c
+// WithInstrumentRegistry : V5 CreateOrder rejects orders that break the rules of the registry before sending them
+func (c *Client) WithInstrumentRegistry(registry *InstrumentRegistry) *Client {
+	return c.Clone(WithInstrumentRegistryOption(registry))
 }
 
 type RateLimitHeaders struct {
@@ -98,9 +113,14 @@ type RateLimitHeaders struct {
 
 // Request :
 func (c *Client) V5Request(req *http.Request, dst interface{}) error {
+	return c.roundTrip(req, dst, c.sendV5Request)
+}
+
+func (c *Client) sendV5Request(call *Call) error {
+	req, dst := call.Request, call.Dst
 
 	if c.debug {
-		c.logger.Debugf("Request url: %s", req.URL.String())
+		c.logger.Debugf("Request url: %s", c.redactURL(req.URL))
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -108,9 +128,11 @@ func (c *Client) V5Request(req *http.Request, dst interface{}) error {
 		return err
 	}
 	defer resp.Body.Close()
+	call.StatusCode = resp.StatusCode
+	call.Header = resp.Header
 
 	if c.debug {
-		c.logger.Debugf("Response: %v", resp)
+		c.logger.Debugf("Response: %s %v", resp.Status, resp.Header)
 	}
 
 	headers := RateLimitHeaders{}
@@ -120,7 +142,7 @@ func (c *Client) V5Request(req *http.Request, dst interface{}) error {
 		body, err := io.ReadAll(resp.Body)
 
 		if c.debug {
-			c.logger.Debugf("Body: %s", string(body))
+			c.logger.Debugf("Body: %s", c.redactBody(body, false))
 		}
 
 		if err != nil {
@@ -130,6 +152,8 @@ func (c *Client) V5Request(req *http.Request, dst interface{}) error {
 			return err
 		}
 
+		call.RetCode, call.RetMsg = retCodeOf(body)
+		call.body = body
 		if c.checkResponseBody == nil {
 			return errors.New("checkResponseBody func should be set")
 		}
@@ -169,7 +193,7 @@ func (c *Client) V5Request(req *http.Request, dst interface{}) error {
 
 		body, err := io.ReadAll(resp.Body)
 		if c.debug {
-			c.logger.Debugf("Body: %v", string(body))
+			c.logger.Debugf("Body: %v", c.redactBody(body, false))
 		}
 
 		if err != nil && c.debug {
@@ -182,9 +206,14 @@ func (c *Client) V5Request(req *http.Request, dst interface{}) error {
 
 // Request :
 func (c *Client) Request(req *http.Request, dst interface{}) error {
+	return c.roundTrip(req, dst, c.sendRequest)
+}
+
+func (c *Client) sendRequest(call *Call) error {
+	req, dst := call.Request, call.Dst
 
 	if c.debug {
-		c.logger.Debugf("Request url: %s", req.URL.String())
+		c.logger.Debugf("Request url: %s", c.redactURL(req.URL))
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -192,16 +221,18 @@ func (c *Client) Request(req *http.Request, dst interface{}) error {
 		return err
 	}
 	defer resp.Body.Close()
+	call.StatusCode = resp.StatusCode
+	call.Header = resp.Header
 
 	if c.debug {
-		c.logger.Debugf("Response: %v", resp)
+		c.logger.Debugf("Response: %s %v", resp.Status, resp.Header)
 	}
 
 	switch {
 	case 200 <= resp.StatusCode && resp.StatusCode <= 299:
 		body, err := io.ReadAll(resp.Body)
 		if c.debug {
-			c.logger.Debugf("Body: %s", string(body))
+			c.logger.Debugf("Body: %s", c.redactBody(body, false))
 		}
 
 		if err != nil {
@@ -211,6 +242,8 @@ func (c *Client) Request(req *http.Request, dst interface{}) error {
 			return err
 		}
 
+		call.RetCode, call.RetMsg = retCodeOf(body)
+		call.body = body
 		if c.checkResponseBody == nil {
 			return errors.New("checkResponseBody func should be set")
 		}
@@ -236,7 +269,7 @@ func (c *Client) Request(req *http.Request, dst interface{}) error {
 
 		body, err := io.ReadAll(resp.Body)
 		if c.debug {
-			c.logger.Debugf("Body: %v", string(body))
+			c.logger.Debugf("Body: %v", c.redactBody(body, false))
 		}
 
 		if err != nil && c.debug {
@@ -359,6 +392,10 @@ func getSignatureForBody(src map[string]interface{}, key string) string {
 }
 
 func (c *Client) getPublicly(path string, query url.Values, dst interface{}) error {
+	return c.getPubliclyContext(context.Background(), path, query, dst)
+}
+
+func (c *Client) getPubliclyContext(ctx context.Context, path string, query url.Values, dst interface{}) error {
 	u, err := url.Parse(c.baseURL)
 	if err != nil {
 		return err
@@ -366,7 +403,7 @@ func (c *Client) getPublicly(path string, query url.Values, dst interface{}) err
 	u.Path = path
 	u.RawQuery = query.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return err
 	}
@@ -403,7 +440,7 @@ func (c *Client) getPrivately(path string, query url.Values, dst interface{}) er
 	return nil
 }
 
-func (c *Client) getV5Privately(path string, query url.Values, dst interface{}) error {
+func (c *Client) getV5Privately(ctx context.Context, path string, query url.Values, dst interface{}) error {
 	if !c.hasAuth() {
 		return fmt.Errorf("this is private endpoint, please set api key and secret")
 	}
@@ -418,7 +455,7 @@ func (c *Client) getV5Privately(path string, query url.Values, dst interface{})
 	timestamp := int(time.Now().UTC().UnixNano() / int64(time.Millisecond))
 	sign := getV5Signature(timestamp, c.key, query.Encode(), c.secret)
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return err
 	}
@@ -459,7 +496,7 @@ func (c *Client) postJSON(path string, body []byte, dst interface{}) error {
 	return nil
 }
 
-func (c *Client) postV5JSON(path string, body []byte, dst interface{}) error {
+func (c *Client) postV5JSON(ctx context.Context, path string, body []byte, dst interface{}) error {
 	if !c.hasAuth() {
 		return fmt.Errorf("this is private endpoint, please set api key and secret")
 	}
@@ -473,7 +510,7 @@ func (c *Client) postV5JSON(path string, body []byte, dst interface{}) error {
 	timestamp := int(time.Now().UTC().UnixNano() / int64(time.Millisecond))
 	sign := getV5SignatureForBody(timestamp, c.key, body, c.secret)
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
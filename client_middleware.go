@@ -0,0 +1,89 @@
+package bybit
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// RoundTrip : sends the request of the call and decodes the response into its Dst
+type RoundTrip func(*Call) error
+
+// Middleware : wraps the round trip of every request, e.g. to log, measure, mirror or refuse it
+type Middleware func(next RoundTrip) RoundTrip
+
+// Call : a request to an endpoint going through the middlewares.
+// Path, Request and Dst are set before the round trip, StatusCode, Header, RetCode, RetMsg and Latency
+// once the response came back, whether an error is returned or not.
+type Call struct {
+	// Path : of the endpoint, e.g. /v5/order/create
+	Path string
+	// Request : signed, a middleware may replace it before calling next
+	Request *http.Request
+	// Dst : the response is decoded into
+	Dst interface{}
+
+	StatusCode int
+	// Header : of the response
+	Header http.Header
+	// RetCode : retCode, or ret_code of the older APIs
+	RetCode int
+	RetMsg  string
+	// Latency : from sending the request to decoding the response
+	Latency time.Duration
+
+	body []byte
+}
+
+// Use : adds middlewares around Request and V5Request, the first added is the outermost, e.g.
+//
+//	client.Use(func(next bybit.RoundTrip) bybit.RoundTrip {
+//		return func(call *bybit.Call) error {
+//			err := next(call)
+//			log.Printf("%s retCode=%d in %s: %v", call.Path, call.RetCode, call.Latency, err)
+//			return err
+//		}
+//	})
+func (c *Client) Use(middlewares ...Middleware) *Client {
+	return c.Clone(WithMiddlewareOption(middlewares...))
+}
+
+// roundTrip : send wrapped by the middlewares, and by the span of the call when tracing
+func (c *Client) roundTrip(req *http.Request, dst interface{}, send RoundTrip) error {
+	var next RoundTrip = func(call *Call) error {
+		start := time.Now()
+		err := send(call)
+		call.Latency = time.Since(start)
+		c.observe(call, err)
+		c.logRequest(call, err)
+		return err
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		next = c.middlewares[i](next)
+	}
+	call := &Call{Path: req.URL.Path, Request: req, Dst: dst}
+	if c.tracer != nil {
+		return c.trace(call, next)
+	}
+	return next(call)
+}
+
+// retCodeOf : of a v5, v3 or older response body
+func retCodeOf(body []byte) (int, string) {
+	var res struct {
+		RetCode   *int   `json:"retCode"`
+		RetMsg    string `json:"retMsg"`
+		RetCodeV2 *int   `json:"ret_code"`
+		RetMsgV2  string `json:"ret_msg"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return 0, ""
+	}
+	if res.RetCode != nil {
+		return *res.RetCode, res.RetMsg
+	}
+	if res.RetCodeV2 != nil {
+		return *res.RetCodeV2, res.RetMsgV2
+	}
+	return 0, ""
+}
@@ -0,0 +1,82 @@
+package bybit
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientUse(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+	server.Handle(http.MethodPost, "/v5/order/create").RespondRetCode(110007, "ab not enough for new order")
+
+	var (
+		order []string
+		calls []Call
+	)
+	client := NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).
+		Use(func(next RoundTrip) RoundTrip {
+			return func(call *Call) error {
+				order = append(order, "outer")
+				err := next(call)
+				calls = append(calls, *call)
+				return err
+			}
+		}, func(next RoundTrip) RoundTrip {
+			return func(call *Call) error {
+				order = append(order, "inner")
+				return next(call)
+			}
+		})
+
+	_, err := client.V5().Position().GetPositionInfo(V5GetPositionInfoParam{Category: CategoryV5Linear})
+	require.NoError(t, err)
+	_, err = client.V5().Order().CreateOrder(V5CreateOrderParam{
+		Category: CategoryV5Linear, Symbol: SymbolV5BTCUSDT, Side: SideBuy, OrderType: OrderTypeMarket, Qty: "0.1",
+	})
+	var errorResponse *ErrorResponse
+	require.True(t, errors.As(err, &errorResponse), "got %v", err)
+
+	assert.Equal(t, []string{"outer", "inner", "outer", "inner"}, order)
+	require.Len(t, calls, 2)
+	assert.Equal(t, "/v5/position/list", calls[0].Path)
+	assert.Equal(t, http.StatusOK, calls[0].StatusCode)
+	assert.Equal(t, 0, calls[0].RetCode)
+	assert.NotEmpty(t, calls[0].Request.Header.Get("X-BAPI-SIGN"), "the request is signed")
+	assert.NotZero(t, calls[0].Latency)
+	assert.NotNil(t, calls[0].Dst)
+	assert.Equal(t, "/v5/order/create", calls[1].Path)
+	assert.Equal(t, 110007, calls[1].RetCode)
+	assert.Equal(t, "ab not enough for new order", calls[1].RetMsg)
+}
+
+func TestClientUsePolicy(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+
+	readOnly := errors.New("read only")
+	client := NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).
+		Use(func(next RoundTrip) RoundTrip {
+			return func(call *Call) error {
+				if call.Request.Method != http.MethodGet {
+					return readOnly
+				}
+				return next(call)
+			}
+		})
+
+	_, err := client.V5().Order().CreateOrder(V5CreateOrderParam{
+		Category: CategoryV5Linear, Symbol: SymbolV5BTCUSDT, Side: SideBuy, OrderType: OrderTypeMarket, Qty: "0.1",
+	})
+	assert.Equal(t, readOnly, err)
+	_, err = client.V5().Market().GetTickers(V5GetTickersParam{Category: CategoryV5Linear})
+	assert.NoError(t, err)
+	assert.Len(t, server.Requests(), 1, "the order never left")
+}
@@ -0,0 +1,89 @@
+package bybit
+
+import (
+	"net/http"
+)
+
+// Option : configures the client NewClient or Clone returns
+type Option func(*Client)
+
+// WithHTTPClientOption :
+func WithHTTPClientOption(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAuthOption :
+func WithAuthOption(key string, secret string) Option {
+	return func(c *Client) {
+		c.key = key
+		c.secret = secret
+	}
+}
+
+// WithBaseURLOption :
+func WithBaseURLOption(url string) Option {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithDebugOption : requests, responses and bodies are logged to logger, redacted
+func WithDebugOption(logger Logger) Option {
+	return func(c *Client) {
+		c.debug = true
+		c.logger = logger
+	}
+}
+
+// WithInstrumentRegistryOption : see Client.WithInstrumentRegistry
+func WithInstrumentRegistryOption(registry *InstrumentRegistry) Option {
+	return func(c *Client) {
+		c.instruments = registry
+	}
+}
+
+// WithMiddlewareOption : see Client.Use
+func WithMiddlewareOption(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithMetricsOption : see Client.WithMetrics
+func WithMetricsOption(sink MetricsSink) Option {
+	return func(c *Client) {
+		c.metrics = sink
+	}
+}
+
+// WithTracerOption : see Client.WithTracer
+func WithTracerOption(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// WithStructuredLoggerOption : see Client.WithStructuredLogger
+func WithStructuredLoggerOption(logger Logger, options LogOptions) Option {
+	return func(c *Client) {
+		c.logOptions = options
+		c.requestLog = structuredLogger(logger)
+	}
+}
+
+// Clone : a copy of the client with opts applied, the client itself is left as is.
+// The copy shares the http.Client and so its connection pool, e.g. a client per sub account:
+//
+//	client := bybit.NewClient(bybit.WithHTTPClientOption(httpClient))
+//	subAccount := client.Clone(bybit.WithAuthOption("sub account key", "sub account secret"))
+func (c *Client) Clone(opts ...Option) *Client {
+	copied := *c
+	copied.middlewares = append([]Middleware(nil), c.middlewares...)
+	for _, opt := range opts {
+		opt(&copied)
+	}
+
+	return &copied
+}
@@ -0,0 +1,83 @@
+package bybit
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	httpClient := &http.Client{}
+	registry := NewInstrumentRegistry(nil)
+	client := NewClient(
+		WithHTTPClientOption(httpClient),
+		WithAuthOption("key", "secret"),
+		WithBaseURLOption(TestNetBaseURL),
+		WithInstrumentRegistryOption(registry),
+	)
+
+	assert.Same(t, httpClient, client.httpClient)
+	assert.Equal(t, "key", client.key)
+	assert.Equal(t, "secret", client.secret)
+	assert.Equal(t, TestNetBaseURL, client.baseURL)
+	assert.Same(t, registry, client.instruments)
+	assert.Equal(t, MainNetBaseURL, NewClient().baseURL)
+}
+
+func TestClientClone(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(call *Call) error {
+				calls = append(calls, name)
+				return next(call)
+			}
+		}
+	}
+	client := NewClient(WithAuthOption("main key", "main secret")).Use(middleware("main"))
+
+	sub := client.Clone(WithAuthOption("sub key", "sub secret"), WithMiddlewareOption(middleware("sub")))
+	other := client.Use(middleware("other"))
+
+	assert.Equal(t, "main key", client.key, "the client is left as is")
+	assert.Len(t, client.middlewares, 1)
+	assert.Equal(t, "sub key", sub.key)
+	assert.Same(t, client.httpClient, sub.httpClient, "the connection pool is shared")
+	require.Len(t, sub.middlewares, 2)
+	require.Len(t, other.middlewares, 2)
+
+	call := &Call{}
+	_ = sub.middlewares[1](func(*Call) error { return nil })(call)
+	_ = other.middlewares[1](func(*Call) error { return nil })(call)
+	assert.Equal(t, []string{"sub", "other"}, calls, "the copies do not share their middlewares")
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+
+	client := NewTestClient(WithAuthOption(testhelper.FakeKey, testhelper.FakeSecret)).WithBaseURL(server.URL)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := client.V5().Order().GetOpenOrders(V5GetOpenOrdersParam{Category: CategoryV5Linear})
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			reconfigured := client.WithAuth("other key", "other secret").WithBaseURL("http://localhost:0")
+			assert.Equal(t, "other key", reconfigured.key)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, testhelper.FakeKey, client.key)
+	assert.Equal(t, server.URL, client.baseURL)
+}
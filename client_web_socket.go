@@ -11,6 +11,8 @@ import (
 	"os"
 	"os/signal"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 const (
@@ -23,6 +25,12 @@ type WebSocketClient struct {
 	baseURL string
 	key     string
 	secret  string
+
+	recorder *WebsocketRecorder
+	replayer *WebsocketReplayer
+	health   *WebsocketHealthMonitor
+	metrics  MetricsSink
+	tracer   Tracer
 }
 
 // NewWebsocketClient :
@@ -40,6 +48,27 @@ func (c *WebSocketClient) WithAuth(key string, secret string) *WebSocketClient {
 	return c
 }
 
+// WithRecorder : every inbound frame of the connections dialed afterwards is written to r
+func (c *WebSocketClient) WithRecorder(r *WebsocketRecorder) *WebSocketClient {
+	c.recorder = r
+
+	return c
+}
+
+// WithReplayer : connections dialed afterwards read the frames of r instead of the network
+func (c *WebSocketClient) WithReplayer(r *WebsocketReplayer) *WebSocketClient {
+	c.replayer = r
+
+	return c
+}
+
+// WithHealthMonitor : connections dialed afterwards report their pings, pongs and topics to m
+func (c *WebSocketClient) WithHealthMonitor(m *WebsocketHealthMonitor) *WebSocketClient {
+	c.health = m
+
+	return c
+}
+
 func (c *WebSocketClient) buildAuthParam() ([]byte, error) {
 	expires := time.Now().Unix()*1000 + 10000
 	req := fmt.Sprintf("GET/realtime%d", expires)
@@ -62,6 +91,42 @@ func (c *WebSocketClient) buildAuthParam() ([]byte, error) {
 	return buf, nil
 }
 
+// websocketConn : the part of *websocket.Conn the services use
+type websocketConn interface {
+	ReadMessage() (messageType int, p []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
+}
+
+// dial : opens a connection to the path under baseURL
+func (c *WebSocketClient) dial(path string) (websocketConn, error) {
+	var conn websocketConn
+	if c.replayer != nil {
+		conn = c.replayer.dial(path)
+	} else {
+		ws, _, err := websocket.DefaultDialer.Dial(c.baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		conn = ws
+	}
+	if c.health != nil {
+		conn = c.health.wrap(path, conn)
+	}
+	if c.recorder != nil {
+		conn = c.recorder.wrap(path, conn)
+	}
+	// outermost, the services hand it their messages
+	dispatch := &websocketDispatchConn{websocketConn: conn, path: path}
+	if c.metrics != nil {
+		dispatch.hooks = append(dispatch.hooks, c.metricsHook)
+	}
+	if c.tracer != nil {
+		dispatch.hooks = append(dispatch.hooks, c.tracingHook)
+	}
+	return dispatch, nil
+}
+
 // SpotWebsocketService :
 type SpotWebsocketService struct {
 	client *WebSocketClient
@@ -84,8 +149,17 @@ type WebsocketExecutor interface {
 	Ping() error
 }
 
-// Start :
+// runWebsocketExecutor : with RunContext when the executor has it, so that its message spans are children of ctx
+func runWebsocketExecutor(ctx context.Context, executor WebsocketExecutor) error {
+	if e, ok := executor.(interface{ RunContext(context.Context) error }); ok {
+		return e.RunContext(ctx)
+	}
+	return executor.Run()
+}
+
+// Start : the message spans of the executors having RunContext are children of ctx
 func (c *WebSocketClient) Start(ctx context.Context, executors []WebsocketExecutor) {
+	parent := ctx
 	done := make(chan struct{})
 
 	go func() {
@@ -93,7 +167,7 @@ func (c *WebSocketClient) Start(ctx context.Context, executors []WebsocketExecut
 
 		for {
 			for _, executor := range executors {
-				if err := executor.Run(); err != nil {
+				if err := runWebsocketExecutor(parent, executor); err != nil {
 					if IsErrWebsocketClosed(err) {
 						return
 					}
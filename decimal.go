@@ -0,0 +1,685 @@
+package bybit
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal :
+// Decimal is an exact number as the exchange sends it.
+// It reads JSON strings, JSON numbers, "" and null (both as zero), and writes a JSON string,
+// so that a value survives a round trip without going through float64.
+type Decimal struct {
+	decimal.Decimal
+}
+
+// NewDecimal :
+func NewDecimal(d decimal.Decimal) Decimal {
+	return Decimal{Decimal: d}
+}
+
+// NewDecimalFromString : "" is zero
+func NewDecimalFromString(s string) (Decimal, error) {
+	if s == "" {
+		return Decimal{}, nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{Decimal: d}, nil
+}
+
+// RequireDecimal : panics when s is not a number, for constants in code
+func RequireDecimal(s string) Decimal {
+	d, err := NewDecimalFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// UnmarshalJSON :
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte(`""`)) {
+		d.Decimal = decimal.Decimal{}
+		return nil
+	}
+	return d.Decimal.UnmarshalJSON(data)
+}
+
+// String : keeps the trailing zeros the value was read with, "0.0100" stays "0.0100"
+func (d Decimal) String() string {
+	if exp := d.Decimal.Exponent(); exp < 0 {
+		return d.Decimal.StringFixed(-exp)
+	}
+	return d.Decimal.String()
+}
+
+// MarshalJSON : always a string
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// MarshalText : like String, rather than the one of decimal.Decimal dropping the trailing zeros
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText : "" is zero
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := NewDecimalFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// EncodeValues : for query strings and forms
+func (d Decimal) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, d.String())
+	return nil
+}
+
+// decimalPtrString :
+func decimalPtrString(d *Decimal) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.String()
+	return &s
+}
+
+// decimalParser : keeps the first error so that a view is filled in one go
+type decimalParser struct {
+	err error
+}
+
+// parse :
+func (p *decimalParser) parse(name string, s string) Decimal {
+	if p.err != nil {
+		return Decimal{}
+	}
+	d, err := NewDecimalFromString(s)
+	if err != nil {
+		p.err = fmt.Errorf("%s: %w", name, err)
+	}
+	return d
+}
+
+// V5GetOrderbookBidAskDecimal :
+type V5GetOrderbookBidAskDecimal struct {
+	Price    Decimal `json:"price"`
+	Quantity Decimal `json:"quantity"`
+}
+
+// Decimal :
+func (b V5GetOrderbookBidAsk) Decimal() (V5GetOrderbookBidAskDecimal, error) {
+	p := &decimalParser{}
+	result := V5GetOrderbookBidAskDecimal{
+		Price:    p.parse("price", b.Price),
+		Quantity: p.parse("quantity", b.Quantity),
+	}
+	return result, p.err
+}
+
+// Decimal :
+func (b V5GetOrderbookBidAsks) Decimal() ([]V5GetOrderbookBidAskDecimal, error) {
+	result := make([]V5GetOrderbookBidAskDecimal, 0, len(b))
+	for _, item := range b {
+		d, err := item.Decimal()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// V5GetKlineItemDecimal :
+type V5GetKlineItemDecimal struct {
+	Open     Decimal `json:"open"`
+	High     Decimal `json:"high"`
+	Low      Decimal `json:"low"`
+	Close    Decimal `json:"close"`
+	Volume   Decimal `json:"volume"`
+	Turnover Decimal `json:"turnover"`
+}
+
+// Decimal :
+func (i V5GetKlineItem) Decimal() (V5GetKlineItemDecimal, error) {
+	p := &decimalParser{}
+	result := V5GetKlineItemDecimal{
+		Open:     p.parse("open", i.Open),
+		High:     p.parse("high", i.High),
+		Low:      p.parse("low", i.Low),
+		Close:    p.parse("close", i.Close),
+		Volume:   p.parse("volume", i.Volume),
+		Turnover: p.parse("turnover", i.Turnover),
+	}
+	return result, p.err
+}
+
+// V5GetTickersLinearInverseDecimal :
+type V5GetTickersLinearInverseDecimal struct {
+	Symbol                 SymbolV5 `json:"symbol"`
+	LastPrice              Decimal  `json:"lastPrice"`
+	IndexPrice             Decimal  `json:"indexPrice"`
+	MarkPrice              Decimal  `json:"markPrice"`
+	PrevPrice24H           Decimal  `json:"prevPrice24h"`
+	Price24HPcnt           Decimal  `json:"price24hPcnt"`
+	HighPrice24H           Decimal  `json:"highPrice24h"`
+	LowPrice24H            Decimal  `json:"lowPrice24h"`
+	PrevPrice1H            Decimal  `json:"prevPrice1h"`
+	OpenInterest           Decimal  `json:"openInterest"`
+	OpenInterestValue      Decimal  `json:"openInterestValue"`
+	Turnover24H            Decimal  `json:"turnover24h"`
+	Volume24H              Decimal  `json:"volume24h"`
+	FundingRate            Decimal  `json:"fundingRate"`
+	PredictedDeliveryPrice Decimal  `json:"predictedDeliveryPrice"`
+	BasisRate              Decimal  `json:"basisRate"`
+	DeliveryFeeRate        Decimal  `json:"deliveryFeeRate"`
+	Ask1Size               Decimal  `json:"ask1Size"`
+	Bid1Price              Decimal  `json:"bid1Price"`
+	Ask1Price              Decimal  `json:"ask1Price"`
+	Bid1Size               Decimal  `json:"bid1Size"`
+}
+
+// Decimal :
+func (r V5GetTickersLinearInverseResult) Decimal() ([]V5GetTickersLinearInverseDecimal, error) {
+	result := make([]V5GetTickersLinearInverseDecimal, 0, len(r.List))
+	for _, t := range r.List {
+		p := &decimalParser{}
+		result = append(result, V5GetTickersLinearInverseDecimal{
+			Symbol:                 t.Symbol,
+			LastPrice:              p.parse("lastPrice", t.LastPrice),
+			IndexPrice:             p.parse("indexPrice", t.IndexPrice),
+			MarkPrice:              p.parse("markPrice", t.MarkPrice),
+			PrevPrice24H:           p.parse("prevPrice24h", t.PrevPrice24H),
+			Price24HPcnt:           p.parse("price24hPcnt", t.Price24HPcnt),
+			HighPrice24H:           p.parse("highPrice24h", t.HighPrice24H),
+			LowPrice24H:            p.parse("lowPrice24h", t.LowPrice24H),
+			PrevPrice1H:            p.parse("prevPrice1h", t.PrevPrice1H),
+			OpenInterest:           p.parse("openInterest", t.OpenInterest),
+			OpenInterestValue:      p.parse("openInterestValue", t.OpenInterestValue),
+			Turnover24H:            p.parse("turnover24h", t.Turnover24H),
+			Volume24H:              p.parse("volume24h", t.Volume24H),
+			FundingRate:            p.parse("fundingRate", t.FundingRate),
+			PredictedDeliveryPrice: p.parse("predictedDeliveryPrice", t.PredictedDeliveryPrice),
+			BasisRate:              p.parse("basisRate", t.BasisRate),
+			DeliveryFeeRate:        p.parse("deliveryFeeRate", t.DeliveryFeeRate),
+			Ask1Size:               p.parse("ask1Size", t.Ask1Size),
+			Bid1Price:              p.parse("bid1Price", t.Bid1Price),
+			Ask1Price:              p.parse("ask1Price", t.Ask1Price),
+			Bid1Size:               p.parse("bid1Size", t.Bid1Size),
+		})
+		if p.err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Symbol, p.err)
+		}
+	}
+	return result, nil
+}
+
+// V5GetTickersOptionDecimal :
+type V5GetTickersOptionDecimal struct {
+	Symbol                 SymbolV5 `json:"symbol"`
+	Bid1Price              Decimal  `json:"bid1Price"`
+	Bid1Size               Decimal  `json:"bid1Size"`
+	Bid1Iv                 Decimal  `json:"bid1Iv"`
+	Ask1Price              Decimal  `json:"ask1Price"`
+	Ask1Size               Decimal  `json:"ask1Size"`
+	Ask1Iv                 Decimal  `json:"ask1Iv"`
+	LastPrice              Decimal  `json:"lastPrice"`
+	HighPrice24H           Decimal  `json:"highPrice24h"`
+	LowPrice24H            Decimal  `json:"lowPrice24h"`
+	MarkPrice              Decimal  `json:"markPrice"`
+	IndexPrice             Decimal  `json:"indexPrice"`
+	MarkIv                 Decimal  `json:"markIv"`
+	UnderlyingPrice        Decimal  `json:"underlyingPrice"`
+	OpenInterest           Decimal  `json:"openInterest"`
+	Turnover24H            Decimal  `json:"turnover24h"`
+	Volume24H              Decimal  `json:"volume24h"`
+	TotalVolume            Decimal  `json:"totalVolume"`
+	TotalTurnover          Decimal  `json:"totalTurnover"`
+	Delta                  Decimal  `json:"delta"`
+	Gamma                  Decimal  `json:"gamma"`
+	Vega                   Decimal  `json:"vega"`
+	Theta                  Decimal  `json:"theta"`
+	PredictedDeliveryPrice Decimal  `json:"predictedDeliveryPrice"`
+	Change24H              Decimal  `json:"change24h"`
+}
+
+// Decimal :
+func (r V5GetTickersOptionResult) Decimal() ([]V5GetTickersOptionDecimal, error) {
+	result := make([]V5GetTickersOptionDecimal, 0, len(r.List))
+	for _, t := range r.List {
+		p := &decimalParser{}
+		result = append(result, V5GetTickersOptionDecimal{
+			Symbol:                 t.Symbol,
+			Bid1Price:              p.parse("bid1Price", t.Bid1Price),
+			Bid1Size:               p.parse("bid1Size", t.Bid1Size),
+			Bid1Iv:                 p.parse("bid1Iv", t.Bid1Iv),
+			Ask1Price:              p.parse("ask1Price", t.Ask1Price),
+			Ask1Size:               p.parse("ask1Size", t.Ask1Size),
+			Ask1Iv:                 p.parse("ask1Iv", t.Ask1Iv),
+			LastPrice:              p.parse("lastPrice", t.LastPrice),
+			HighPrice24H:           p.parse("highPrice24h", t.HighPrice24H),
+			LowPrice24H:            p.parse("lowPrice24h", t.LowPrice24H),
+			MarkPrice:              p.parse("markPrice", t.MarkPrice),
+			IndexPrice:             p.parse("indexPrice", t.IndexPrice),
+			MarkIv:                 p.parse("markIv", t.MarkIv),
+			UnderlyingPrice:        p.parse("underlyingPrice", t.UnderlyingPrice),
+			OpenInterest:           p.parse("openInterest", t.OpenInterest),
+			Turnover24H:            p.parse("turnover24h", t.Turnover24H),
+			Volume24H:              p.parse("volume24h", t.Volume24H),
+			TotalVolume:            p.parse("totalVolume", t.TotalVolume),
+			TotalTurnover:          p.parse("totalTurnover", t.TotalTurnover),
+			Delta:                  p.parse("delta", t.Delta),
+			Gamma:                  p.parse("gamma", t.Gamma),
+			Vega:                   p.parse("vega", t.Vega),
+			Theta:                  p.parse("theta", t.Theta),
+			PredictedDeliveryPrice: p.parse("predictedDeliveryPrice", t.PredictedDeliveryPrice),
+			Change24H:              p.parse("change24h", t.Change24H),
+		})
+		if p.err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Symbol, p.err)
+		}
+	}
+	return result, nil
+}
+
+// V5GetTickersSpotDecimal :
+type V5GetTickersSpotDecimal struct {
+	Symbol        SymbolV5 `json:"symbol"`
+	Bid1Price     Decimal  `json:"bid1Price"`
+	Bid1Size      Decimal  `json:"bid1Size"`
+	Ask1Price     Decimal  `json:"ask1Price"`
+	Ask1Size      Decimal  `json:"ask1Size"`
+	LastPrice     Decimal  `json:"lastPrice"`
+	PrevPrice24H  Decimal  `json:"prevPrice24h"`
+	Price24HPcnt  Decimal  `json:"price24hPcnt"`
+	HighPrice24H  Decimal  `json:"highPrice24h"`
+	LowPrice24H   Decimal  `json:"lowPrice24h"`
+	Turnover24H   Decimal  `json:"turnover24h"`
+	Volume24H     Decimal  `json:"volume24h"`
+	UsdIndexPrice Decimal  `json:"usdIndexPrice"`
+}
+
+// Decimal :
+func (r V5GetTickersSpotResult) Decimal() ([]V5GetTickersSpotDecimal, error) {
+	result := make([]V5GetTickersSpotDecimal, 0, len(r.List))
+	for _, t := range r.List {
+		p := &decimalParser{}
+		result = append(result, V5GetTickersSpotDecimal{
+			Symbol:        t.Symbol,
+			Bid1Price:     p.parse("bid1Price", t.Bid1Price),
+			Bid1Size:      p.parse("bid1Size", t.Bid1Size),
+			Ask1Price:     p.parse("ask1Price", t.Ask1Price),
+			Ask1Size:      p.parse("ask1Size", t.Ask1Size),
+			LastPrice:     p.parse("lastPrice", t.LastPrice),
+			PrevPrice24H:  p.parse("prevPrice24h", t.PrevPrice24H),
+			Price24HPcnt:  p.parse("price24hPcnt", t.Price24HPcnt),
+			HighPrice24H:  p.parse("highPrice24h", t.HighPrice24H),
+			LowPrice24H:   p.parse("lowPrice24h", t.LowPrice24H),
+			Turnover24H:   p.parse("turnover24h", t.Turnover24H),
+			Volume24H:     p.parse("volume24h", t.Volume24H),
+			UsdIndexPrice: p.parse("usdIndexPrice", t.UsdIndexPrice),
+		})
+		if p.err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Symbol, p.err)
+		}
+	}
+	return result, nil
+}
+
+// V5GetPositionInfoItemDecimal :
+type V5GetPositionInfoItemDecimal struct {
+	Symbol         SymbolV5 `json:"symbol"`
+	Side           Side     `json:"side"`
+	PositionIdx    int      `json:"positionIdx"`
+	Leverage       Decimal  `json:"leverage"`
+	AvgPrice       Decimal  `json:"avgPrice"`
+	LiqPrice       Decimal  `json:"liqPrice"`
+	RiskLimitValue Decimal  `json:"riskLimitValue"`
+	TakeProfit     Decimal  `json:"takeProfit"`
+	PositionValue  Decimal  `json:"positionValue"`
+	TrailingStop   Decimal  `json:"trailingStop"`
+	UnrealisedPnl  Decimal  `json:"unrealisedPnl"`
+	MarkPrice      Decimal  `json:"markPrice"`
+	CumRealisedPnl Decimal  `json:"cumRealisedPnl"`
+	PositionMM     Decimal  `json:"positionMM"`
+	PositionIM     Decimal  `json:"positionIM"`
+	BustPrice      Decimal  `json:"bustPrice"`
+	Size           Decimal  `json:"size"`
+	StopLoss       Decimal  `json:"stopLoss"`
+}
+
+// Decimal :
+func (i V5GetPositionInfoItem) Decimal() (V5GetPositionInfoItemDecimal, error) {
+	p := &decimalParser{}
+	result := V5GetPositionInfoItemDecimal{
+		Symbol:         i.Symbol,
+		Side:           i.Side,
+		PositionIdx:    i.PositionIdx,
+		Leverage:       p.parse("leverage", i.Leverage),
+		AvgPrice:       p.parse("avgPrice", i.AvgPrice),
+		LiqPrice:       p.parse("liqPrice", i.LiqPrice),
+		RiskLimitValue: p.parse("riskLimitValue", i.RiskLimitValue),
+		TakeProfit:     p.parse("takeProfit", i.TakeProfit),
+		PositionValue:  p.parse("positionValue", i.PositionValue),
+		TrailingStop:   p.parse("trailingStop", i.TrailingStop),
+		UnrealisedPnl:  p.parse("unrealisedPnl", i.UnrealisedPnl),
+		MarkPrice:      p.parse("markPrice", i.MarkPrice),
+		CumRealisedPnl: p.parse("cumRealisedPnl", i.CumRealisedPnl),
+		PositionMM:     p.parse("positionMM", i.PositionMM),
+		PositionIM:     p.parse("positionIM", i.PositionIM),
+		BustPrice:      p.parse("bustPrice", i.BustPrice),
+		Size:           p.parse("size", i.Size),
+		StopLoss:       p.parse("stopLoss", i.StopLoss),
+	}
+	return result, p.err
+}
+
+// V5OrderDecimal : numbers shared by open orders and the order history
+type V5OrderDecimal struct {
+	Symbol             SymbolV5 `json:"symbol"`
+	OrderID            string   `json:"orderId"`
+	OrderLinkID        string   `json:"orderLinkId"`
+	Side               Side     `json:"side"`
+	Price              Decimal  `json:"price"`
+	Qty                Decimal  `json:"qty"`
+	AvgPrice           Decimal  `json:"avgPrice"`
+	LastPriceOnCreated Decimal  `json:"lastPriceOnCreated"`
+	TriggerPrice       Decimal  `json:"triggerPrice"`
+	TakeProfit         Decimal  `json:"takeProfit"`
+	StopLoss           Decimal  `json:"stopLoss"`
+	OrderIv            Decimal  `json:"orderIv"`
+	CumExecQty         Decimal  `json:"cumExecQty"`
+	CumExecValue       Decimal  `json:"cumExecValue"`
+	CumExecFee         Decimal  `json:"cumExecFee"`
+	LeavesQty          Decimal  `json:"leavesQty"`
+	LeavesValue        Decimal  `json:"leavesValue"`
+}
+
+// Decimal :
+func (o V5GetOpenOrder) Decimal() (V5OrderDecimal, error) {
+	p := &decimalParser{}
+	result := V5OrderDecimal{
+		Symbol:             o.Symbol,
+		OrderID:            o.OrderID,
+		OrderLinkID:        o.OrderLinkID,
+		Side:               o.Side,
+		Price:              p.parse("price", o.Price),
+		Qty:                p.parse("qty", o.Qty),
+		AvgPrice:           p.parse("avgPrice", o.AvgPrice),
+		LastPriceOnCreated: p.parse("lastPriceOnCreated", o.LastPriceOnCreated),
+		TriggerPrice:       p.parse("triggerPrice", o.TriggerPrice),
+		TakeProfit:         p.parse("takeProfit", o.TakeProfit),
+		StopLoss:           p.parse("stopLoss", o.StopLoss),
+		OrderIv:            p.parse("orderIv", o.OrderIv),
+		CumExecQty:         p.parse("cumExecQty", o.CumExecQty),
+		CumExecValue:       p.parse("cumExecValue", o.CumExecValue),
+		CumExecFee:         p.parse("cumExecFee", o.CumExecFee),
+		LeavesQty:          p.parse("leavesQty", o.LeavesQty),
+		LeavesValue:        p.parse("leavesValue", o.LeavesValue),
+	}
+	return result, p.err
+}
+
+// Decimal :
+func (o V5GetOrder) Decimal() (V5OrderDecimal, error) {
+	p := &decimalParser{}
+	result := V5OrderDecimal{
+		Symbol:             o.Symbol,
+		OrderID:            o.OrderID,
+		OrderLinkID:        o.OrderLinkID,
+		Side:               o.Side,
+		Price:              p.parse("price", o.Price),
+		Qty:                p.parse("qty", o.Qty),
+		AvgPrice:           p.parse("avgPrice", o.AvgPrice),
+		LastPriceOnCreated: p.parse("lastPriceOnCreated", o.LastPriceOnCreated),
+		TriggerPrice:       p.parse("triggerPrice", o.TriggerPrice),
+		TakeProfit:         p.parse("takeProfit", o.TakeProfit),
+		StopLoss:           p.parse("stopLoss", o.StopLoss),
+		OrderIv:            p.parse("orderIv", o.OrderIV),
+		CumExecQty:         p.parse("cumExecQty", o.CumExecQty),
+		CumExecValue:       p.parse("cumExecValue", o.CumExecValue),
+		CumExecFee:         p.parse("cumExecFee", o.CumExecFee),
+		LeavesQty:          p.parse("leavesQty", o.LeavesQty),
+		LeavesValue:        p.parse("leavesValue", o.LeavesValue),
+	}
+	return result, p.err
+}
+
+// V5GetExecutionOrderDecimal :
+type V5GetExecutionOrderDecimal struct {
+	Symbol     SymbolV5 `json:"symbol"`
+	OrderID    string   `json:"orderId"`
+	ExecID     string   `json:"execId"`
+	Side       Side     `json:"side"`
+	ExecPrice  Decimal  `json:"execPrice"`
+	ExecQty    Decimal  `json:"execQty"`
+	ExecValue  Decimal  `json:"execValue"`
+	ExecFee    Decimal  `json:"execFee"`
+	FeeRate    Decimal  `json:"feeRate"`
+	MarkPrice  Decimal  `json:"markPrice"`
+	OrderPrice Decimal  `json:"orderPrice"`
+	OrderQty   Decimal  `json:"orderQty"`
+	LeavesQty  Decimal  `json:"leavesQty"`
+	ClosedSize Decimal  `json:"closedSize"`
+}
+
+// Decimal :
+func (e V5GetExecutionOrder) Decimal() (V5GetExecutionOrderDecimal, error) {
+	p := &decimalParser{}
+	result := V5GetExecutionOrderDecimal{
+		Symbol:     e.Symbol,
+		OrderID:    e.OrderID,
+		ExecID:     e.ExecID,
+		Side:       e.Side,
+		ExecPrice:  p.parse("execPrice", e.ExecPrice),
+		ExecQty:    p.parse("execQty", e.ExecQty),
+		ExecValue:  p.parse("execValue", e.ExecValue),
+		ExecFee:    p.parse("execFee", e.ExecFee),
+		FeeRate:    p.parse("feeRate", e.FeeRate),
+		MarkPrice:  p.parse("markPrice", e.MarkPrice),
+		OrderPrice: p.parse("orderPrice", e.OrderPrice),
+		OrderQty:   p.parse("orderQty", e.OrderQty),
+		LeavesQty:  p.parse("leavesQty", e.LeavesQty),
+		ClosedSize: p.parse("closedSize", e.ClosedSize),
+	}
+	return result, p.err
+}
+
+// V5GetClosedPnlDecimal :
+type V5GetClosedPnlDecimal struct {
+	Symbol        SymbolV5 `json:"symbol"`
+	OrderID       string   `json:"orderId"`
+	Side          Side     `json:"side"`
+	Leverage      Decimal  `json:"leverage"`
+	ClosedPnl     Decimal  `json:"closedPnl"`
+	AvgEntryPrice Decimal  `json:"avgEntryPrice"`
+	AvgExitPrice  Decimal  `json:"avgExitPrice"`
+	Qty           Decimal  `json:"qty"`
+	ClosedSize    Decimal  `json:"closedSize"`
+	OrderPrice    Decimal  `json:"orderPrice"`
+	CumEntryValue Decimal  `json:"cumEntryValue"`
+	CumExitValue  Decimal  `json:"cumExitValue"`
+}
+
+// Decimal :
+func (c V5GetClosedPnl) Decimal() (V5GetClosedPnlDecimal, error) {
+	p := &decimalParser{}
+	result := V5GetClosedPnlDecimal{
+		Symbol:        c.Symbol,
+		OrderID:       c.OrderID,
+		Side:          c.Side,
+		Leverage:      p.parse("leverage", c.Leverage),
+		ClosedPnl:     p.parse("closedPnl", c.ClosedPnl),
+		AvgEntryPrice: p.parse("avgEntryPrice", c.AvgEntryPrice),
+		AvgExitPrice:  p.parse("avgExitPrice", c.AvgExitPrice),
+		Qty:           p.parse("qty", c.Qty),
+		ClosedSize:    p.parse("closedSize", c.ClosedSize),
+		OrderPrice:    p.parse("orderPrice", c.OrderPrice),
+		CumEntryValue: p.parse("cumEntryValue", c.CumEntryValue),
+		CumExitValue:  p.parse("cumExitValue", c.CumExitValue),
+	}
+	return result, p.err
+}
+
+// V5WalletBalanceCoinDecimal :
+type V5WalletBalanceCoinDecimal struct {
+	Coin                Coin    `json:"coin"`
+	AvailableToBorrow   Decimal `json:"availableToBorrow"`
+	AccruedInterest     Decimal `json:"accruedInterest"`
+	AvailableToWithdraw Decimal `json:"availableToWithdraw"`
+	TotalOrderIM        Decimal `json:"totalOrderIM"`
+	Equity              Decimal `json:"equity"`
+	TotalPositionMM     Decimal `json:"totalPositionMM"`
+	UsdValue            Decimal `json:"usdValue"`
+	UnrealisedPnl       Decimal `json:"unrealisedPnl"`
+	BorrowAmount        Decimal `json:"borrowAmount"`
+	TotalPositionIM     Decimal `json:"totalPositionIM"`
+	WalletBalance       Decimal `json:"walletBalance"`
+	CumRealisedPnl      Decimal `json:"cumRealisedPnl"`
+}
+
+// Decimal :
+func (c V5WalletBalanceCoin) Decimal() (V5WalletBalanceCoinDecimal, error) {
+	p := &decimalParser{}
+	result := V5WalletBalanceCoinDecimal{
+		Coin:                c.Coin,
+		AvailableToBorrow:   p.parse("availableToBorrow", c.AvailableToBorrow),
+		AccruedInterest:     p.parse("accruedInterest", c.AccruedInterest),
+		AvailableToWithdraw: p.parse("availableToWithdraw", c.AvailableToWithdraw),
+		TotalOrderIM:        p.parse("totalOrderIM", c.TotalOrderIM),
+		Equity:              p.parse("equity", c.Equity),
+		TotalPositionMM:     p.parse("totalPositionMM", c.TotalPositionMM),
+		UsdValue:            p.parse("usdValue", c.UsdValue),
+		UnrealisedPnl:       p.parse("unrealisedPnl", c.UnrealisedPnl),
+		BorrowAmount:        p.parse("borrowAmount", c.BorrowAmount),
+		TotalPositionIM:     p.parse("totalPositionIM", c.TotalPositionIM),
+		WalletBalance:       p.parse("walletBalance", c.WalletBalance),
+		CumRealisedPnl:      p.parse("cumRealisedPnl", c.CumRealisedPnl),
+	}
+	if p.err != nil {
+		return result, fmt.Errorf("%s: %w", c.Coin, p.err)
+	}
+	return result, nil
+}
+
+// V5WalletBalanceListDecimal :
+type V5WalletBalanceListDecimal struct {
+	AccountType            string                       `json:"accountType"`
+	TotalEquity            Decimal                      `json:"totalEquity"`
+	AccountIMRate          Decimal                      `json:"accountIMRate"`
+	TotalMarginBalance     Decimal                      `json:"totalMarginBalance"`
+	TotalInitialMargin     Decimal                      `json:"totalInitialMargin"`
+	TotalAvailableBalance  Decimal                      `json:"totalAvailableBalance"`
+	AccountMMRate          Decimal                      `json:"accountMMRate"`
+	TotalPerpUPL           Decimal                      `json:"totalPerpUPL"`
+	TotalWalletBalance     Decimal                      `json:"totalWalletBalance"`
+	TotalMaintenanceMargin Decimal                      `json:"totalMaintenanceMargin"`
+	Coin                   []V5WalletBalanceCoinDecimal `json:"coin"`
+}
+
+// Decimal :
+func (l V5WalletBalanceList) Decimal() (V5WalletBalanceListDecimal, error) {
+	p := &decimalParser{}
+	result := V5WalletBalanceListDecimal{
+		AccountType:            l.AccountType,
+		TotalEquity:            p.parse("totalEquity", l.TotalEquity),
+		AccountIMRate:          p.parse("accountIMRate", l.AccountIMRate),
+		TotalMarginBalance:     p.parse("totalMarginBalance", l.TotalMarginBalance),
+		TotalInitialMargin:     p.parse("totalInitialMargin", l.TotalInitialMargin),
+		TotalAvailableBalance:  p.parse("totalAvailableBalance", l.TotalAvailableBalance),
+		AccountMMRate:          p.parse("accountMMRate", l.AccountMMRate),
+		TotalPerpUPL:           p.parse("totalPerpUPL", l.TotalPerpUPL),
+		TotalWalletBalance:     p.parse("totalWalletBalance", l.TotalWalletBalance),
+		TotalMaintenanceMargin: p.parse("totalMaintenanceMargin", l.TotalMaintenanceMargin),
+		Coin:                   make([]V5WalletBalanceCoinDecimal, 0, len(l.Coin)),
+	}
+	if p.err != nil {
+		return result, p.err
+	}
+	for _, c := range l.Coin {
+		coin, err := c.Decimal()
+		if err != nil {
+			return result, err
+		}
+		result.Coin = append(result.Coin, coin)
+	}
+	return result, nil
+}
+
+// V5CreateOrderDecimalParam : V5CreateOrderParam with exact numbers, see Param
+type V5CreateOrderDecimalParam struct {
+	Category  CategoryV5
+	Symbol    SymbolV5
+	Side      Side
+	OrderType OrderType
+	Qty       Decimal
+
+	IsLeverage            *IsLeverage
+	Price                 *Decimal
+	TriggerDirection      *TriggerDirection
+	OrderFilter           *OrderFilter
+	TriggerPrice          *Decimal
+	TriggerBy             *TriggerBy
+	OrderIv               *Decimal
+	TimeInForce           *TimeInForce
+	PositionIdx           *PositionIdx
+	OrderLinkID           *string
+	TakeProfit            *Decimal
+	StopLoss              *Decimal
+	TpTriggerBy           *TriggerBy
+	SlTriggerBy           *TriggerBy
+	ReduceOnly            *bool
+	CloseOnTrigger        *bool
+	MarketMakerProtection *bool
+}
+
+// Param : the numbers are written in full, without exponent and without going through float64
+func (p V5CreateOrderDecimalParam) Param() V5CreateOrderParam {
+	return V5CreateOrderParam{
+		Category:              p.Category,
+		Symbol:                p.Symbol,
+		Side:                  p.Side,
+		OrderType:             p.OrderType,
+		Qty:                   p.Qty.String(),
+		IsLeverage:            p.IsLeverage,
+		Price:                 decimalPtrString(p.Price),
+		TriggerDirection:      p.TriggerDirection,
+		OrderFilter:           p.OrderFilter,
+		TriggerPrice:          decimalPtrString(p.TriggerPrice),
+		TriggerBy:             p.TriggerBy,
+		OrderIv:               decimalPtrString(p.OrderIv),
+		TimeInForce:           p.TimeInForce,
+		PositionIdx:           p.PositionIdx,
+		OrderLinkID:           p.OrderLinkID,
+		TakeProfit:            decimalPtrString(p.TakeProfit),
+		StopLoss:              decimalPtrString(p.StopLoss),
+		TpTriggerBy:           p.TpTriggerBy,
+		SlTriggerBy:           p.SlTriggerBy,
+		ReduceOnly:            p.ReduceOnly,
+		CloseOnTrigger:        p.CloseOnTrigger,
+		MarketMakerProtection: p.MarketMakerProtection,
+	}
+}
+
+// SpotPostOrderDecimalParam : SpotPostOrderParam with exact numbers
+type SpotPostOrderDecimalParam struct {
+	Symbol SymbolSpot    `url:"symbol"`
+	Qty    Decimal       `url:"qty"`
+	Side   Side          `url:"side"`
+	Type   OrderTypeSpot `url:"type"`
+
+	TimeInForce *TimeInForceSpot `url:"timeInForce,omitempty"`
+	Price       *Decimal         `url:"price,omitempty"`
+	OrderLinkID *string          `url:"orderLinkId,omitempty"`
+}
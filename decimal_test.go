@@ -0,0 +1,128 @@
+package bybit
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Run("json round trip keeps precision", func(t *testing.T) {
+		var got struct {
+			Price Decimal `json:"price"`
+			Qty   Decimal `json:"qty"`
+			Empty Decimal `json:"empty"`
+			Null  Decimal `json:"null"`
+		}
+		in := `{"price":"0.12345678901234567890","qty":0.0100,"empty":"","null":null}`
+		require.NoError(t, json.Unmarshal([]byte(in), &got))
+
+		assert.Equal(t, "0.12345678901234567890", got.Price.String())
+		assert.Equal(t, "0.0100", got.Qty.String())
+		assert.True(t, got.Empty.IsZero())
+		assert.True(t, got.Null.IsZero())
+
+		out, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.Equal(t, `{"price":"0.12345678901234567890","qty":"0.0100","empty":"0","null":"0"}`, string(out))
+	})
+	t.Run("text round trip keeps precision", func(t *testing.T) {
+		text, err := RequireDecimal("0.0100").MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "0.0100", string(text))
+
+		out, err := json.Marshal(map[Decimal]Decimal{RequireDecimal("28383.50"): RequireDecimal("1.000")})
+		require.NoError(t, err)
+		assert.Equal(t, `{"28383.50":"1.000"}`, string(out))
+
+		var got map[Decimal]Decimal
+		require.NoError(t, json.Unmarshal(out, &got))
+		for price, qty := range got {
+			assert.Equal(t, "28383.50", price.String())
+			assert.Equal(t, "1.000", qty.String())
+		}
+		var empty Decimal
+		require.NoError(t, empty.UnmarshalText(nil))
+		assert.True(t, empty.IsZero())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var d Decimal
+		assert.Error(t, json.Unmarshal([]byte(`"abc"`), &d))
+		_, err := V5GetOrderbookBidAsk{Price: "1", Quantity: "x"}.Decimal()
+		assert.Error(t, err)
+	})
+	t.Run("views", func(t *testing.T) {
+		position, err := V5GetPositionInfoItem{Symbol: SymbolV5BTCUSDT, Size: "0.001", AvgPrice: "16493.5", StopLoss: ""}.Decimal()
+		require.NoError(t, err)
+		assert.Equal(t, "0.001", position.Size.String())
+		assert.Equal(t, "16493.5", position.AvgPrice.String())
+		assert.True(t, position.StopLoss.IsZero())
+
+		wallet, err := V5WalletBalanceList{
+			TotalEquity: "3.31216591",
+			Coin:        []V5WalletBalanceCoin{{Coin: CoinBTC, Equity: "0.0000001"}},
+		}.Decimal()
+		require.NoError(t, err)
+		assert.Equal(t, "3.31216591", wallet.TotalEquity.String())
+		assert.Equal(t, "0.0000001", wallet.Coin[0].Equity.String())
+	})
+	t.Run("create order param", func(t *testing.T) {
+		price := RequireDecimal("28383.50")
+		param := V5CreateOrderDecimalParam{
+			Category:  CategoryV5Spot,
+			Symbol:    SymbolV5BTCUSDT,
+			Side:      SideBuy,
+			OrderType: OrderTypeLimit,
+			Qty:       RequireDecimal("0.000001"),
+			Price:     &price,
+		}.Param()
+		assert.Equal(t, "0.000001", param.Qty)
+		require.NotNil(t, param.Price)
+		assert.Equal(t, "28383.50", *param.Price)
+		assert.Nil(t, param.TakeProfit)
+	})
+}
+
+func TestSpotPostOrderDecimal(t *testing.T) {
+	price := RequireDecimal("28383.5")
+	param := SpotPostOrderDecimalParam{
+		Symbol: SymbolSpotBTCUSDT,
+		Qty:    RequireDecimal("0.1234567891"),
+		Side:   SideBuy,
+		Type:   OrderTypeSpotLimit,
+		Price:  &price,
+	}
+
+	path := "/spot/v1/order"
+	method := http.MethodPost
+	status := http.StatusOK
+	respBody := SpotPostOrderResponse{
+		Result: SpotPostOrderResult{
+			OrderID: "1037799004578056704",
+			Symbol:  string(param.Symbol),
+			Price:   "28383.5",
+			OrigQty: "0.1234567891",
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewServer(
+		testhelper.WithHandlerOption(path, method, status, bytesBody),
+	)
+	defer teardown()
+
+	client := NewTestClient().
+		WithBaseURL(server.URL).
+		WithAuth("test", "test")
+
+	resp, err := client.Spot().V1().(*SpotV1Service).SpotPostOrderDecimal(param)
+	require.NoError(t, err)
+
+	require.NotNil(t, resp)
+	assert.Equal(t, respBody, *resp)
+}
@@ -12,8 +12,12 @@ const (
 	CoinEOS = "EOS"
 	// CoinXRP :
 	CoinXRP = "XRP"
+	// CoinSOL :
+	CoinSOL = "SOL"
 	// CoinUSDT :
 	CoinUSDT = "USDT"
+	// CoinUSDC :
+	CoinUSDC = "USDC"
 )
 
 // SymbolInverse :
@@ -146,6 +150,12 @@ const (
 	OrderStatusCancelled = OrderStatus("Cancelled")
 	// OrderStatusPendingCancel :
 	OrderStatusPendingCancel = OrderStatus("PendingCancel")
+	// OrderStatusUntriggered : conditional order waiting for its trigger price
+	OrderStatusUntriggered = OrderStatus("Untriggered")
+	// OrderStatusTriggered :
+	OrderStatusTriggered = OrderStatus("Triggered")
+	// OrderStatusDeactivated : conditional order cancelled before it triggered
+	OrderStatusDeactivated = OrderStatus("Deactivated")
 )
 
 // OrderStatusSpot :
@@ -315,6 +325,8 @@ const (
 )
 
 // MinimumVolumeUSDT :
+//
+// Deprecated: covers a handful of symbols only, use InstrumentRegistry.Instrument(CategoryV5Linear, symbol).MinOrderQty instead.
 func MinimumVolumeUSDT(symbol SymbolUSDT) float64 {
 	switch symbol {
 	case SymbolUSDTBTC:
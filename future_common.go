@@ -148,12 +148,12 @@ type ListKlineResult struct {
 	Symbol   SymbolInverse `json:"symbol"`
 	Interval string        `json:"interval"`
 	OpenTime int           `json:"open_time"`
-	Open     float64       `json:"open"`
-	High     float64       `json:"high"`
-	Low      float64       `json:"low"`
-	Close    float64       `json:"close"`
-	Volume   float64       `json:"volume"`
-	Turnover float64       `json:"turnover"`
+	Open     float64       `json:"open,string"`
+	High     float64       `json:"high,string"`
+	Low      float64       `json:"low,string"`
+	Close    float64       `json:"close,string"`
+	Volume   float64       `json:"volume,string"`
+	Turnover float64       `json:"turnover,string"`
 }
 
 // ListKline :
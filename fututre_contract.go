@@ -97,7 +97,7 @@ type ContractExecutionList struct {
 	LeavesQty        decimal.Decimal `json:"leavesQty"`
 	ClosedSize       decimal.Decimal `json:"closedSize"`
 	LastLiquidityInd string          `json:"lastLiquidityInd"`
-	TradeTimeMs      string          `json:"execTime"`
+	TradeTimeMs      MillisTime      `json:"execTime"`
 }
 
 // ContractExecutionHistoryListParam :
@@ -259,7 +259,7 @@ type ContractTicker struct {
 	TotalVolume       string        `json:"total_volume"`
 	Volume24h         string        `json:"volume24h"`
 	FundingRate       string        `json:"fundingRate"`
-	NextFundingTime   string        `json:"nextFundingTime"`
+	NextFundingTime   MillisTime    `json:"nextFundingTime"`
 }
 
 // Tickers :
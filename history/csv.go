@@ -0,0 +1,82 @@
+package history
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// columns : start is in epoch milliseconds, the others are decimal strings
+func columns(kind Kind) []string {
+	if kind == KindTrade || kind == "" {
+		return []string{"start", "open", "high", "low", "close", "volume", "turnover"}
+	}
+	return []string{"start", "open", "high", "low", "close"}
+}
+
+func (b Bar) values(n int) []bybit.Decimal {
+	return []bybit.Decimal{b.Open, b.High, b.Low, b.Close, b.Volume, b.Turnover}[:n-1]
+}
+
+// WriteCSV : header is false when appending to a file that has one
+func WriteCSV(w io.Writer, kind Kind, bars []Bar, header bool) error {
+	names := columns(kind)
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(names); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(names))
+	for _, bar := range bars {
+		record[0] = strconv.FormatInt(bar.Start.UnixMilli(), 10)
+		for i, value := range bar.values(len(names)) {
+			record[i+1] = value.String()
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV : reads what WriteCSV wrote, with or without volume and turnover
+func ReadCSV(r io.Reader) ([]Bar, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) != 5 && len(header) != 7 || header[0] != "start" {
+		return nil, fmt.Errorf("unexpected csv header %v", header)
+	}
+
+	var bars []Bar
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return bars, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ms, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("start: %w", err)
+		}
+		bar, err := newBar(time.UnixMilli(ms), record[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		bars = append(bars, bar)
+	}
+}
@@ -0,0 +1,360 @@
+// Package history downloads kline history of any length page by page and keeps it in CSV or Parquet files.
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// Kind : which kline endpoint to download
+type Kind string
+
+const (
+	// KindTrade : /v5/market/kline, the only one with volume and turnover
+	KindTrade = Kind("trade")
+	// KindMark : /v5/market/mark-price-kline
+	KindMark = Kind("mark")
+	// KindIndex : /v5/market/index-price-kline
+	KindIndex = Kind("index")
+	// KindPremiumIndex : /v5/market/premium-index-price-kline
+	KindPremiumIndex = Kind("premium")
+)
+
+// maxPageLimit : bybit returns at most 200 bars per call
+const maxPageLimit = 200
+
+// Bar : one kline
+type Bar struct {
+	Start time.Time
+	Open  bybit.Decimal
+	High  bybit.Decimal
+	Low   bybit.Decimal
+	Close bybit.Decimal
+	// Volume, Turnover : zero except for KindTrade
+	Volume   bybit.Decimal
+	Turnover bybit.Decimal
+}
+
+// Request : bars starting in [Start, End], End defaults to now
+type Request struct {
+	Category bybit.CategoryV5
+	Symbol   bybit.SymbolV5
+	Interval bybit.Interval
+	// Kind : KindTrade when empty
+	Kind  Kind
+	Start time.Time
+	End   time.Time
+}
+
+// Gap : bars missing from the history, From is the start of the first missing bar and To the start of the bar after the last one
+type Gap struct {
+	From time.Time
+	To   time.Time
+	Bars int
+}
+
+// Result : Bars are the oldest first, without duplicates and without the bar that is still open
+type Result struct {
+	Bars []Bar
+	Gaps []Gap
+}
+
+// Downloader :
+type Downloader struct {
+	market    bybit.V5MarketServiceI
+	pageLimit int
+	pause     time.Duration
+	now       func() time.Time
+}
+
+// NewDownloader : market is usually client.V5().Market()
+func NewDownloader(market bybit.V5MarketServiceI) *Downloader {
+	return &Downloader{
+		market:    market,
+		pageLimit: maxPageLimit,
+		now:       time.Now,
+	}
+}
+
+// WithPageLimit : bars per call, 200 by default
+func (d *Downloader) WithPageLimit(limit int) *Downloader {
+	if limit > 0 && limit <= maxPageLimit {
+		d.pageLimit = limit
+	}
+	return d
+}
+
+// WithPause : wait between calls to stay below the rate limit, none by default
+func (d *Downloader) WithPause(pause time.Duration) *Downloader {
+	d.pause = pause
+	return d
+}
+
+// Download : walks from End back to Start one page at a time
+func (d *Downloader) Download(ctx context.Context, req Request) (Result, error) {
+	req, err := d.normalize(req)
+	if err != nil {
+		return Result{}, err
+	}
+
+	seen := map[int64]Bar{}
+	end := req.End
+	for {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		page, err := d.page(req, end)
+		if err != nil {
+			return Result{}, fmt.Errorf("%s %s %s until %s: %w", req.Kind, req.Symbol, req.Interval, end.UTC().Format(time.RFC3339), err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		oldest := page[0].Start
+		for _, bar := range page {
+			if bar.Start.Before(oldest) {
+				oldest = bar.Start
+			}
+			if bar.Start.Before(req.Start) || bar.Start.After(req.End) {
+				continue
+			}
+			seen[bar.Start.UnixMilli()] = bar
+		}
+		// a short page is the last one, so is a page that does not go further back
+		if len(page) < d.pageLimit || !oldest.After(req.Start) || !oldest.Before(end) {
+			break
+		}
+		end = oldest.Add(-time.Millisecond)
+
+		if d.pause > 0 {
+			select {
+			case <-ctx.Done():
+				return Result{}, ctx.Err()
+			case <-time.After(d.pause):
+			}
+		}
+	}
+
+	now := d.now()
+	bars := make([]Bar, 0, len(seen))
+	for _, bar := range seen {
+		if closes, err := nextStart(bar.Start, req.Interval); err == nil && closes.After(now) {
+			continue
+		}
+		bars = append(bars, bar)
+	}
+	sort.Slice(bars, func(i, j int) bool { return bars[i].Start.Before(bars[j].Start) })
+
+	gaps, err := FindGaps(bars, req.Interval, req.Start, req.End)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Bars: bars, Gaps: gaps}, nil
+}
+
+func (d *Downloader) normalize(req Request) (Request, error) {
+	if req.Kind == "" {
+		req.Kind = KindTrade
+	}
+	if req.End.IsZero() {
+		req.End = d.now()
+	}
+	if req.Start.After(req.End) {
+		return req, errors.New("start is after end")
+	}
+	if _, err := nextStart(req.Start, req.Interval); err != nil {
+		return req, err
+	}
+	return req, nil
+}
+
+func (d *Downloader) page(req Request, end time.Time) ([]Bar, error) {
+	start := req.Start
+	limit := d.pageLimit
+	var bars []Bar
+	switch req.Kind {
+	case KindTrade:
+		res, err := d.market.GetKline(bybit.V5GetKlineParam{
+			Category: req.Category, Symbol: req.Symbol, Interval: req.Interval, Start: &start, End: &end, Limit: &limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Result.List {
+			bar, err := newBar(item.StartTime.Time, item.Open, item.High, item.Low, item.Close, item.Volume, item.Turnover)
+			if err != nil {
+				return nil, err
+			}
+			bars = append(bars, bar)
+		}
+	case KindMark:
+		res, err := d.market.GetMarkPriceKline(bybit.V5GetMarkPriceKlineParam{
+			Category: req.Category, Symbol: req.Symbol, Interval: req.Interval, Start: &start, End: &end, Limit: &limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Result.List {
+			bar, err := newBar(item.StartTime.Time, item.Open, item.High, item.Low, item.Close)
+			if err != nil {
+				return nil, err
+			}
+			bars = append(bars, bar)
+		}
+	case KindIndex:
+		res, err := d.market.GetIndexPriceKline(bybit.V5GetIndexPriceKlineParam{
+			Category: req.Category, Symbol: req.Symbol, Interval: req.Interval, Start: &start, End: &end, Limit: &limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Result.List {
+			bar, err := newBar(item.StartTime.Time, item.Open, item.High, item.Low, item.Close)
+			if err != nil {
+				return nil, err
+			}
+			bars = append(bars, bar)
+		}
+	case KindPremiumIndex:
+		res, err := d.market.GetPremiumIndexPriceKline(bybit.V5GetPremiumIndexPriceKlineParam{
+			Category: req.Category, Symbol: req.Symbol, Interval: req.Interval, Start: &start, End: &end, Limit: &limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Result.List {
+			bar, err := newBar(item.StartTime.Time, item.Open, item.High, item.Low, item.Close)
+			if err != nil {
+				return nil, err
+			}
+			bars = append(bars, bar)
+		}
+	default:
+		return nil, fmt.Errorf("unknown kind %q", req.Kind)
+	}
+	return bars, nil
+}
+
+// newBar : values are open, high, low, close and optionally volume and turnover
+func newBar(start time.Time, values ...string) (Bar, error) {
+	parsed := make([]bybit.Decimal, 6)
+	for i, value := range values {
+		d, err := bybit.NewDecimalFromString(value)
+		if err != nil {
+			return Bar{}, fmt.Errorf("bar at %d: %w", start.UnixMilli(), err)
+		}
+		parsed[i] = d
+	}
+	return Bar{
+		Start:    start.UTC(),
+		Open:     parsed[0],
+		High:     parsed[1],
+		Low:      parsed[2],
+		Close:    parsed[3],
+		Volume:   parsed[4],
+		Turnover: parsed[5],
+	}, nil
+}
+
+// FindGaps : the bars missing between from and to, bars must be the oldest first
+func FindGaps(bars []Bar, interval bybit.Interval, from, to time.Time) ([]Gap, error) {
+	expected, err := barStart(from, interval)
+	if err != nil {
+		return nil, err
+	}
+	if expected.Before(from) {
+		if expected, err = nextStart(expected, interval); err != nil {
+			return nil, err
+		}
+	}
+
+	var gaps []Gap
+	report := func(until time.Time) error {
+		gap := Gap{From: expected}
+		for expected.Before(until) {
+			gap.Bars++
+			if expected, err = nextStart(expected, interval); err != nil {
+				return err
+			}
+		}
+		if gap.Bars > 0 {
+			gap.To = expected
+			gaps = append(gaps, gap)
+		}
+		return nil
+	}
+	for _, bar := range bars {
+		if err := report(bar.Start); err != nil {
+			return nil, err
+		}
+		if !bar.Start.Before(expected) {
+			if expected, err = nextStart(bar.Start, interval); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// only closed bars are expected at the end
+	last, err := barStart(to, interval)
+	if err != nil {
+		return nil, err
+	}
+	if closes, err := nextStart(last, interval); err == nil && !closes.After(to) {
+		last = closes
+	}
+	if err := report(last); err != nil {
+		return nil, err
+	}
+	return gaps, nil
+}
+
+// barStart : the start of the bar that t falls in, in UTC as bybit counts them
+func barStart(t time.Time, interval bybit.Interval) (time.Time, error) {
+	t = t.UTC()
+	switch interval {
+	case bybit.IntervalD:
+		return t.Truncate(24 * time.Hour), nil
+	case bybit.IntervalW:
+		// weeks start on monday, the epoch was a thursday
+		monday := time.Unix(0, 0).UTC().AddDate(0, 0, 4)
+		return monday.Add(t.Sub(monday) / (7 * 24 * time.Hour) * (7 * 24 * time.Hour)), nil
+	case bybit.IntervalM:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	minutes, err := intervalMinutes(interval)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Truncate(time.Duration(minutes) * time.Minute), nil
+}
+
+// nextStart : the start of the bar after the one starting at t
+func nextStart(t time.Time, interval bybit.Interval) (time.Time, error) {
+	switch interval {
+	case bybit.IntervalD:
+		return t.AddDate(0, 0, 1), nil
+	case bybit.IntervalW:
+		return t.AddDate(0, 0, 7), nil
+	case bybit.IntervalM:
+		return t.AddDate(0, 1, 0), nil
+	}
+	minutes, err := intervalMinutes(interval)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(time.Duration(minutes) * time.Minute), nil
+}
+
+func intervalMinutes(interval bybit.Interval) (int, error) {
+	minutes, err := strconv.Atoi(string(interval))
+	if err != nil || minutes <= 0 {
+		return 0, fmt.Errorf("unsupported interval %q", interval)
+	}
+	return minutes, nil
+}
@@ -0,0 +1,270 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var listing = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestKlineServer : 1 minute bars from listing, bars 100 to 104 missing.
+// Pages overlap by one bar like the exchange does with the bar that contains end.
+func newTestKlineServer(t *testing.T, bars int) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		// not require: the handler runs outside the test goroutine
+		fail := func(err error) {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		q := r.URL.Query()
+		start, err := strconv.ParseInt(q.Get("start"), 10, 64)
+		if err != nil {
+			fail(err)
+			return
+		}
+		end, err := strconv.ParseInt(q.Get("end"), 10, 64)
+		if err != nil {
+			fail(err)
+			return
+		}
+		limit, err := strconv.Atoi(q.Get("limit"))
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		list := [][]string{}
+		for i := bars - 1; i >= 0 && len(list) < limit; i-- {
+			ms := listing.Add(time.Duration(i) * time.Minute).UnixMilli()
+			if i >= 100 && i < 105 || ms < start || ms > end+time.Minute.Milliseconds() {
+				continue
+			}
+			price := fmt.Sprintf("%d.50", 16000+i)
+			item := []string{strconv.FormatInt(ms, 10), price, price, price, price}
+			if r.URL.Path == "/v5/market/kline" {
+				item = append(item, "1.000", fmt.Sprintf("%d.5", 16000+i))
+			}
+			list = append(list, item)
+		}
+		body, err := json.Marshal(map[string]interface{}{
+			"retCode": 0,
+			"retMsg":  "OK",
+			"result":  map[string]interface{}{"category": q.Get("category"), "symbol": q.Get("symbol"), "list": list},
+		})
+		if err != nil {
+			fail(err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	return server, &calls
+}
+
+func newTestDownloader(url string) *Downloader {
+	return NewDownloader(bybit.NewTestClient().WithBaseURL(url).V5().Market()).WithPageLimit(50)
+}
+
+func starts(bars []Bar) []int64 {
+	result := make([]int64, len(bars))
+	for i, bar := range bars {
+		result[i] = bar.Start.Sub(listing).Milliseconds() / time.Minute.Milliseconds()
+	}
+	return result
+}
+
+func minutes(from, to int) []int64 {
+	result := []int64{}
+	for i := from; i < to; i++ {
+		if i < 100 || i >= 105 {
+			result = append(result, int64(i))
+		}
+	}
+	return result
+}
+
+func TestDownload(t *testing.T) {
+	server, calls := newTestKlineServer(t, 500)
+	defer server.Close()
+
+	req := Request{
+		Category: bybit.CategoryV5Linear,
+		Symbol:   bybit.SymbolV5BTCUSDT,
+		Interval: bybit.Interval1,
+		Start:    listing.Add(-10 * time.Minute),
+		End:      listing.Add(499 * time.Minute),
+	}
+	result, err := newTestDownloader(server.URL).Download(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, minutes(0, 500), starts(result.Bars))
+	assert.Equal(t, 11, *calls)
+	assert.Equal(t, "16042.50", result.Bars[42].Close.String())
+	assert.Equal(t, "16042.5", result.Bars[42].Turnover.String())
+	assert.Equal(t, []Gap{
+		{From: listing.Add(-10 * time.Minute), To: listing, Bars: 10},
+		{From: listing.Add(100 * time.Minute), To: listing.Add(105 * time.Minute), Bars: 5},
+	}, result.Gaps)
+
+	req.Kind = KindMark
+	req.Start = listing.Add(400 * time.Minute)
+	result, err = newTestDownloader(server.URL).Download(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, minutes(400, 500), starts(result.Bars))
+	assert.True(t, result.Bars[0].Volume.IsZero())
+	assert.Empty(t, result.Gaps)
+}
+
+func TestSync(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatParquet} {
+		t.Run(string(format), func(t *testing.T) {
+			server, _ := newTestKlineServer(t, 500)
+			defer server.Close()
+			dir := t.TempDir()
+
+			req := Request{
+				Category: bybit.CategoryV5Linear,
+				Symbol:   bybit.SymbolV5BTCUSDT,
+				Interval: bybit.Interval1,
+				Start:    listing,
+				End:      listing.Add(299 * time.Minute),
+			}
+			downloader := newTestDownloader(server.URL)
+			result, err := downloader.Sync(context.Background(), req, dir, format)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, "linear_BTCUSDT_1."+string(format)), result.Path)
+			assert.Equal(t, 295, result.Added)
+			assert.Len(t, result.Gaps, 1)
+
+			req.End = listing.Add(499 * time.Minute)
+			result, err = downloader.Sync(context.Background(), req, dir, format)
+			require.NoError(t, err)
+			assert.Equal(t, 200, result.Added)
+			assert.Empty(t, result.Gaps)
+
+			result, err = downloader.Sync(context.Background(), req, dir, format)
+			require.NoError(t, err)
+			assert.Equal(t, 0, result.Added)
+
+			stored, err := readFile(result.Path, format)
+			require.NoError(t, err)
+			assert.Equal(t, minutes(0, 500), starts(stored))
+			assert.Equal(t, "16499.50", stored[len(stored)-1].Open.String())
+			assert.Equal(t, "1.000", stored[len(stored)-1].Volume.String())
+		})
+	}
+}
+
+func TestParquet(t *testing.T) {
+	bars := []Bar{
+		{Start: listing, Open: bybit.RequireDecimal("16500.00"), High: bybit.RequireDecimal("16510.5"), Low: bybit.RequireDecimal("16490"), Close: bybit.RequireDecimal("16505.25")},
+		{Start: listing.Add(time.Hour), Open: bybit.RequireDecimal("16505.25"), High: bybit.RequireDecimal("16600"), Low: bybit.RequireDecimal("16500"), Close: bybit.RequireDecimal("16590")},
+	}
+	for _, kind := range []Kind{KindTrade, KindIndex} {
+		var buf bytes.Buffer
+		require.NoError(t, WriteParquet(&buf, kind, bars))
+		got, err := ReadParquet(&buf)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, listing.Add(time.Hour), got[1].Start)
+		assert.Equal(t, "16500.00", got[0].Open.String())
+		assert.Equal(t, "16505.25", got[0].Close.String())
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteParquet(&buf, KindTrade, nil))
+	got, err := ReadParquet(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = ReadParquet(bytes.NewBufferString("start,open\n"))
+	assert.Error(t, err)
+}
+
+func TestParquetUnsupported(t *testing.T) {
+	root := map[int16]interface{}{4: []byte("schema")}
+	start := map[int16]interface{}{1: int64(parquetInt64), 3: int64(parquetRequired), 4: []byte("start"), 6: int64(parquetTimestampMillis)}
+	price := func(repetition, converted int64) map[int16]interface{} {
+		return map[int16]interface{}{1: int64(parquetByteArray), 3: repetition, 4: []byte("open"), 6: converted}
+	}
+	millis := map[int16]interface{}{1: int64(parquetInt64), 4: []byte("start"), 10: map[int16]interface{}{8: map[int16]interface{}{1: false, 2: map[int16]interface{}{1: map[int16]interface{}{}}}}}
+	assert.NoError(t, checkParquetSchema([]map[int16]interface{}{root, start, price(parquetRequired, parquetUTF8)}))
+	assert.NoError(t, checkParquetSchema([]map[int16]interface{}{root, millis}), "a TIMESTAMP logical type without converted type")
+
+	nanos := map[int16]interface{}{1: int64(parquetInt64), 4: []byte("start"), 10: map[int16]interface{}{8: map[int16]interface{}{1: false, 2: map[int16]interface{}{3: map[int16]interface{}{}}}}}
+	for _, tt := range []struct {
+		columns []map[int16]interface{}
+		message string
+	}{
+		{columns: []map[int16]interface{}{nanos}, message: "column start is not an INT64 timestamp in milliseconds"},
+		{columns: []map[int16]interface{}{start, price(1, parquetUTF8)}, message: "column open is nullable, only required columns are supported"},
+		{columns: []map[int16]interface{}{start, price(parquetRequired, parquetDecimal)}, message: "column open is DECIMAL, only UTF8 prices are supported"},
+		{columns: []map[int16]interface{}{start, {1: int64(parquetByteArray), 4: []byte("price")}}, message: "unknown column price"},
+	} {
+		err := checkParquetSchema(append([]map[int16]interface{}{root}, tt.columns...))
+		assert.ErrorIs(t, err, ErrUnsupportedParquet)
+		assert.EqualError(t, err, "unsupported parquet layout: "+tt.message)
+	}
+
+	header := &thriftWriter{}
+	header.begin()
+	header.i32(1, 2)
+	header.i32(2, 0)
+	header.i32(3, 0)
+	header.end()
+	_, err := readParquetPage(append([]byte(parquetMagic), header.buf.Bytes()...), 4, 2)
+	assert.ErrorIs(t, err, ErrUnsupportedParquet)
+	assert.EqualError(t, err, "unsupported parquet layout: the first page is a DICTIONARY_PAGE, only one DATA_PAGE per column is supported")
+}
+
+func TestDecimalCastSQL(t *testing.T) {
+	assert.Equal(t, "start, CAST(open AS DECIMAL(18, 8)) AS open, CAST(high AS DECIMAL(18, 8)) AS high, CAST(low AS DECIMAL(18, 8)) AS low, CAST(close AS DECIMAL(18, 8)) AS close",
+		DecimalCastSQL(KindMark, 18, 8))
+}
+
+func TestFindGaps(t *testing.T) {
+	month := func(m time.Month) Bar { return Bar{Start: time.Date(2023, m, 1, 0, 0, 0, 0, time.UTC)} }
+	gaps, err := FindGaps([]Bar{month(1), month(2), month(5)}, bybit.IntervalM, time.Date(2022, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2023, 7, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []Gap{
+		{From: month(3).Start, To: month(5).Start, Bars: 2},
+		{From: month(6).Start, To: month(7).Start, Bars: 1},
+	}, gaps)
+
+	monday := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	start, err := barStart(monday.Add(3*24*time.Hour), bybit.IntervalW)
+	require.NoError(t, err)
+	assert.Equal(t, monday, start)
+
+	_, err = FindGaps(nil, bybit.SpotInterval1h, monday, monday)
+	assert.Error(t, err)
+}
+
+func TestReadCSV(t *testing.T) {
+	var buf bytes.Buffer
+	bars := []Bar{{Start: listing, Open: bybit.RequireDecimal("1.10"), High: bybit.RequireDecimal("1.2"), Low: bybit.RequireDecimal("1"), Close: bybit.RequireDecimal("1.15")}}
+	require.NoError(t, WriteCSV(&buf, KindPremiumIndex, bars, true))
+	assert.Equal(t, "start,open,high,low,close\n1672531200000,1.10,1.2,1,1.15\n", buf.String())
+
+	got, err := ReadCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, listing, got[0].Start)
+	assert.Equal(t, "1.10", got[0].Open.String())
+
+	_, err = ReadCSV(bytes.NewBufferString("time,price\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,577 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Parquet files are written without a dependency: one row group, one uncompressed PLAIN data page per column,
+// start as INT64 TIMESTAMP_MILLIS and the prices as UTF8 strings so that they stay exact.
+// The prices are not DECIMAL: a DECIMAL column has one scale for the whole file,
+// while the exchange sends each price with the digits of the tick size it had then, and "16500.00" would come back as "16500.0000".
+// DecimalCastSQL casts them when they are read with SQL.
+// ReadParquet only reads files laid out like that, any other layout is an ErrUnsupportedParquet naming it.
+// TestParquetPyarrow checks both against pyarrow, it is skipped where pyarrow is not installed.
+
+const parquetMagic = "PAR1"
+
+// ErrUnsupportedParquet : of a parquet file not laid out like WriteParquet does, the error names what differs
+var ErrUnsupportedParquet = errors.New("unsupported parquet layout")
+
+// parquet.thrift enums
+const (
+	parquetInt64           = 2
+	parquetByteArray       = 6
+	parquetRequired        = 0
+	parquetUTF8            = 0
+	parquetDecimal         = 5
+	parquetTimestampMillis = 9
+	parquetPlain           = 0
+	parquetRLE             = 3
+	parquetUncompressed    = 0
+	parquetDataPage        = 0
+)
+
+var parquetPageTypes = map[int64]string{0: "DATA_PAGE", 1: "INDEX_PAGE", 2: "DICTIONARY_PAGE", 3: "DATA_PAGE_V2"}
+
+var parquetCodecs = map[int64]string{1: "SNAPPY", 2: "GZIP", 3: "LZO", 4: "BROTLI", 5: "LZ4", 6: "ZSTD", 7: "LZ4_RAW"}
+
+var parquetEncodings = map[int64]string{2: "PLAIN_DICTIONARY", 5: "DELTA_BINARY_PACKED", 6: "DELTA_LENGTH_BYTE_ARRAY", 7: "DELTA_BYTE_ARRAY", 8: "RLE_DICTIONARY", 9: "BYTE_STREAM_SPLIT"}
+
+var parquetTimeUnits = map[int16]string{1: "MILLIS", 2: "MICROS", 3: "NANOS"}
+
+// thrift compact protocol types
+const (
+	thriftStop   = 0
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+// WriteParquet :
+func WriteParquet(w io.Writer, kind Kind, bars []Bar) error {
+	names := columns(kind)
+	out := bytes.NewBufferString(parquetMagic)
+
+	var chunks []parquetChunk
+	if len(bars) > 0 {
+		for i, name := range names {
+			var page bytes.Buffer
+			for _, bar := range bars {
+				if i == 0 {
+					_ = binary.Write(&page, binary.LittleEndian, bar.Start.UnixMilli())
+					continue
+				}
+				value := bar.values(len(names))[i-1].String()
+				_ = binary.Write(&page, binary.LittleEndian, uint32(len(value)))
+				page.WriteString(value)
+			}
+
+			header := &thriftWriter{}
+			header.begin()
+			header.i32(1, parquetDataPage)
+			header.i32(2, int32(page.Len()))
+			header.i32(3, int32(page.Len()))
+			header.beginStruct(5)
+			header.i32(1, int32(len(bars)))
+			header.i32(2, parquetPlain)
+			header.i32(3, parquetRLE)
+			header.i32(4, parquetRLE)
+			header.end()
+			header.end()
+
+			chunks = append(chunks, parquetChunk{
+				name:   name,
+				typ:    parquetColumnType(i),
+				offset: int64(out.Len()),
+				size:   int64(header.buf.Len() + page.Len()),
+			})
+			out.Write(header.buf.Bytes())
+			out.Write(page.Bytes())
+		}
+	}
+
+	footer := &thriftWriter{}
+	footer.begin()
+	footer.i32(1, 1)
+	footer.listHeader(2, thriftStruct, len(names)+1)
+	footer.begin()
+	footer.binary(4, "schema")
+	footer.i32(5, int32(len(names)))
+	footer.end()
+	for i, name := range names {
+		footer.begin()
+		footer.i32(1, parquetColumnType(i))
+		footer.i32(3, parquetRequired)
+		footer.binary(4, name)
+		if i == 0 {
+			footer.i32(6, parquetTimestampMillis)
+		} else {
+			footer.i32(6, parquetUTF8)
+		}
+		footer.end()
+	}
+	footer.i64(3, int64(len(bars)))
+	if len(chunks) == 0 {
+		footer.listHeader(4, thriftStruct, 0)
+	} else {
+		var total int64
+		footer.listHeader(4, thriftStruct, 1)
+		footer.begin()
+		footer.listHeader(1, thriftStruct, len(chunks))
+		for _, chunk := range chunks {
+			total += chunk.size
+			footer.begin()
+			footer.i64(2, chunk.offset)
+			footer.beginStruct(3)
+			footer.i32(1, chunk.typ)
+			footer.listHeader(2, thriftI32, 2)
+			footer.varint(zigzag(parquetPlain))
+			footer.varint(zigzag(parquetRLE))
+			footer.listHeader(3, thriftBinary, 1)
+			footer.varint(uint64(len(chunk.name)))
+			footer.buf.WriteString(chunk.name)
+			footer.i32(4, parquetUncompressed)
+			footer.i64(5, int64(len(bars)))
+			footer.i64(6, chunk.size)
+			footer.i64(7, chunk.size)
+			footer.i64(9, chunk.offset)
+			footer.end()
+			footer.end()
+		}
+		footer.i64(2, total)
+		footer.i64(3, int64(len(bars)))
+		footer.end()
+	}
+	footer.binary(6, "github.com/oneart-dev/bybit/history")
+	footer.end()
+
+	out.Write(footer.buf.Bytes())
+	_ = binary.Write(out, binary.LittleEndian, uint32(footer.buf.Len()))
+	out.WriteString(parquetMagic)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// ReadParquet : reads what WriteParquet wrote
+func ReadParquet(r io.Reader) ([]Bar, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 12 || string(data[:4]) != parquetMagic || string(data[len(data)-4:]) != parquetMagic {
+		return nil, errors.New("not a parquet file")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if footerLen > len(data)-12 {
+		return nil, errors.New("parquet footer is truncated")
+	}
+	meta, err := newThriftReader(data[len(data)-8-footerLen : len(data)-8]).readStruct()
+	if err != nil {
+		return nil, fmt.Errorf("parquet footer: %w", err)
+	}
+	if err := checkParquetSchema(thriftStructs(meta[2])); err != nil {
+		return nil, err
+	}
+
+	var bars []Bar
+	for _, rowGroup := range thriftStructs(meta[4]) {
+		numRows := int(thriftInt(rowGroup[3]))
+		group := make([]Bar, numRows)
+		values := make([][]string, numRows)
+		for _, chunk := range thriftStructs(rowGroup[1]) {
+			column, ok := chunk[3].(map[int16]interface{})
+			if !ok {
+				return nil, errors.New("parquet column chunk has no metadata")
+			}
+			path, _ := column[3].([]interface{})
+			if len(path) != 1 {
+				return nil, errors.New("unexpected parquet column path")
+			}
+			name := string(path[0].([]byte))
+			if codec := thriftInt(column[4]); codec != parquetUncompressed {
+				return nil, unsupportedParquet("column %s is compressed with %s, only uncompressed columns are supported", name, parquetName(parquetCodecs, codec))
+			}
+			page, err := readParquetPage(data, thriftInt(column[9]), numRows)
+			if err != nil {
+				return nil, fmt.Errorf("parquet column %s: %w", name, err)
+			}
+			for i := 0; i < numRows; i++ {
+				if name == "start" {
+					if len(page) < 8 {
+						return nil, errors.New("parquet start column is truncated")
+					}
+					group[i].Start = time.UnixMilli(int64(binary.LittleEndian.Uint64(page))).UTC()
+					page = page[8:]
+					continue
+				}
+				if len(page) < 4 || int(binary.LittleEndian.Uint32(page)) > len(page)-4 {
+					return nil, fmt.Errorf("parquet column %s is truncated", name)
+				}
+				n := int(binary.LittleEndian.Uint32(page))
+				values[i] = append(values[i], string(page[4:4+n]))
+				page = page[4+n:]
+			}
+		}
+		for i := range group {
+			bar, err := newBar(group[i].Start, values[i]...)
+			if err != nil {
+				return nil, err
+			}
+			group[i] = bar
+		}
+		bars = append(bars, group...)
+	}
+	return bars, nil
+}
+
+type parquetChunk struct {
+	name   string
+	typ    int32
+	offset int64
+	size   int64
+}
+
+func parquetColumnType(i int) int32 {
+	if i == 0 {
+		return parquetInt64
+	}
+	return parquetByteArray
+}
+
+func readParquetPage(data []byte, offset int64, numRows int) ([]byte, error) {
+	if offset < 4 || offset >= int64(len(data)) {
+		return nil, errors.New("page offset is out of the file")
+	}
+	r := newThriftReader(data[offset:])
+	header, err := r.readStruct()
+	if err != nil {
+		return nil, err
+	}
+	if typ := thriftInt(header[1]); typ != parquetDataPage {
+		return nil, unsupportedParquet("the first page is a %s, only one DATA_PAGE per column is supported", parquetName(parquetPageTypes, typ))
+	}
+	dataPage, _ := header[5].(map[int16]interface{})
+	if encoding := thriftInt(dataPage[2]); encoding != parquetPlain {
+		return nil, unsupportedParquet("the page is %s encoded, only PLAIN is supported", parquetName(parquetEncodings, encoding))
+	}
+	if n := int(thriftInt(dataPage[1])); n != numRows {
+		return nil, unsupportedParquet("the page has %d of the %d rows, only one page per column is supported", n, numRows)
+	}
+	size := int(thriftInt(header[3]))
+	start := int(offset) + r.pos
+	if size < 0 || start+size > len(data) {
+		return nil, errors.New("page is truncated")
+	}
+	return data[start : start+size], nil
+}
+
+// checkParquetSchema : the columns are the required flat ones WriteParquet writes
+func checkParquetSchema(elements []map[int16]interface{}) error {
+	if len(elements) < 2 {
+		return errors.New("parquet schema has no columns")
+	}
+	known := map[string]bool{}
+	for _, name := range columns(KindTrade) {
+		known[name] = true
+	}
+	for _, element := range elements[1:] {
+		name, _ := element[4].([]byte)
+		switch {
+		case element[5] != nil:
+			return unsupportedParquet("column %s is nested, only flat columns are supported", name)
+		case !known[string(name)]:
+			return unsupportedParquet("unknown column %s", name)
+		case thriftInt(element[3]) != parquetRequired:
+			return unsupportedParquet("column %s is nullable, only required columns are supported", name)
+		}
+		converted, annotated := element[6]
+		if string(name) == "start" {
+			if thriftInt(element[1]) != parquetInt64 || parquetTimestampUnit(element) != "MILLIS" && !(annotated && thriftInt(converted) == parquetTimestampMillis) {
+				return unsupportedParquet("column start is not an INT64 timestamp in milliseconds")
+			}
+			continue
+		}
+		if annotated && thriftInt(converted) == parquetDecimal {
+			return unsupportedParquet("column %s is DECIMAL, only UTF8 prices are supported", name)
+		}
+		if thriftInt(element[1]) != parquetByteArray || annotated && thriftInt(converted) != parquetUTF8 {
+			return unsupportedParquet("column %s is not a UTF8 string", name)
+		}
+	}
+	return nil
+}
+
+// parquetTimestampUnit : of the TIMESTAMP logical type of a schema element, "" when it has none
+func parquetTimestampUnit(element map[int16]interface{}) string {
+	logical, _ := element[10].(map[int16]interface{})
+	timestamp, _ := logical[8].(map[int16]interface{})
+	unit, _ := timestamp[2].(map[int16]interface{})
+	for id := range unit {
+		return parquetTimeUnits[id]
+	}
+	return ""
+}
+
+func unsupportedParquet(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedParquet, fmt.Sprintf(format, args...))
+}
+
+func parquetName(names map[int64]string, v int64) string {
+	if name, ok := names[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("type %d", v)
+}
+
+// DecimalCastSQL : the select list reading the columns of kind from a file of WriteParquet,
+// the prices cast to DECIMAL(precision, scale), e.g. for DuckDB
+//
+//	SELECT <DecimalCastSQL(KindTrade, 18, 8)> FROM 'data/linear_BTCUSDT_60.parquet'
+func DecimalCastSQL(kind Kind, precision, scale int) string {
+	names := columns(kind)
+	list := []string{names[0]}
+	for _, name := range names[1:] {
+		list = append(list, fmt.Sprintf("CAST(%s AS DECIMAL(%d, %d)) AS %s", name, precision, scale, name))
+	}
+	return strings.Join(list, ", ")
+}
+
+// thriftWriter : the compact protocol, just what the parquet metadata needs
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16
+}
+
+func (w *thriftWriter) begin() {
+	w.last = append(w.last, 0)
+}
+
+func (w *thriftWriter) end() {
+	w.buf.WriteByte(thriftStop)
+	w.last = w.last[:len(w.last)-1]
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	delta := id - w.last[len(w.last)-1]
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(zigzag(int64(id)))
+	}
+	w.last[len(w.last)-1] = id
+}
+
+func (w *thriftWriter) beginStruct(id int16) {
+	w.field(id, thriftStruct)
+	w.begin()
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(zigzag(v))
+}
+
+func (w *thriftWriter) binary(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.varint(uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+// listHeader : the elements follow, structs between begin and end
+func (w *thriftWriter) listHeader(id int16, elem byte, n int) {
+	w.field(id, thriftList)
+	if n < 15 {
+		w.buf.WriteByte(byte(n)<<4 | elem)
+		return
+	}
+	w.buf.WriteByte(0xf0 | elem)
+	w.varint(uint64(n))
+}
+
+func (w *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// thriftReader : decodes any compact protocol struct into field id -> value,
+// integers as int64, binaries as []byte, lists and sets as []interface{}, structs as map[int16]interface{}
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func newThriftReader(data []byte) *thriftReader {
+	return &thriftReader{data: data}
+}
+
+func (r *thriftReader) readStruct() (map[int16]interface{}, error) {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if b == thriftStop {
+			return fields, nil
+		}
+		typ := b & 0x0f
+		if delta := int16(b >> 4); delta != 0 {
+			last += delta
+		} else {
+			id, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			last = int16(unzigzag(id))
+		}
+		switch typ {
+		case thriftTrue:
+			fields[last] = true
+		case thriftFalse:
+			fields[last] = false
+		default:
+			v, err := r.value(typ)
+			if err != nil {
+				return nil, err
+			}
+			fields[last] = v
+		}
+	}
+}
+
+func (r *thriftReader) value(typ byte) (interface{}, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		b, err := r.byte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := r.byte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		v, err := r.varint()
+		return unzigzag(v), err
+	case thriftDouble:
+		if r.pos+8 > len(r.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		r.pos += 8
+		return r.data[r.pos-8 : r.pos], nil
+	case thriftBinary:
+		n, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(r.data)-r.pos) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		r.pos += int(n)
+		return r.data[r.pos-int(n) : r.pos], nil
+	case thriftList, thriftSet:
+		b, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(b >> 4)
+		if n == 15 {
+			if n, err = r.varint(); err != nil {
+				return nil, err
+			}
+		}
+		if n > uint64(len(r.data)-r.pos) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		list := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			v, err := r.value(b & 0x0f)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case thriftMap:
+		n, err := r.varint()
+		if err != nil || n == 0 {
+			return nil, err
+		}
+		types, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < n; i++ {
+			if _, err := r.value(types >> 4); err != nil {
+				return nil, err
+			}
+			if _, err := r.value(types & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case thriftStruct:
+		return r.readStruct()
+	}
+	return nil, fmt.Errorf("unknown thrift type %d", typ)
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.pos++
+	return r.data[r.pos-1], nil
+}
+
+func (r *thriftReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.pos += n
+	return v, nil
+}
+
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func thriftInt(v interface{}) int64 {
+	i, _ := v.(int64)
+	return i
+}
+
+func thriftStructs(v interface{}) []map[int16]interface{} {
+	list, _ := v.([]interface{})
+	structs := make([]map[int16]interface{}, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(map[int16]interface{}); ok {
+			structs = append(structs, s)
+		}
+	}
+	return structs
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pyarrowScript : writes the columns read from stdin to a file the way WriteParquet lays it out,
+// or prints the schema and the columns of a file as pyarrow reads them
+const pyarrowScript = `
+import json, sys
+import pyarrow as pa, pyarrow.parquet as pq
+
+mode, path = sys.argv[1], sys.argv[2]
+if mode == "write":
+    columns = json.load(sys.stdin)
+    names = sys.argv[3].split(",")
+    fields = [pa.field(names[0], pa.timestamp("ms"), nullable=False)]
+    fields += [pa.field(name, pa.string(), nullable=False) for name in names[1:]]
+    schema = pa.schema(fields)
+    arrays = [pa.array(columns[f.name], type=f.type) for f in fields]
+    pq.write_table(pa.Table.from_arrays(arrays, schema=schema), path, use_dictionary=False,
+                   compression="NONE", data_page_version="1.0", write_statistics=False)
+else:
+    table = pq.read_table(path)
+    out = {"types": {}, "nullable": {}, "columns": {}}
+    for f in table.schema:
+        out["types"][f.name] = str(f.type)
+        out["nullable"][f.name] = f.nullable
+        column = table.column(f.name)
+        if f.name == "start":
+            column = column.cast(pa.int64())
+        out["columns"][f.name] = column.to_pylist()
+    json.dump(out, sys.stdout)
+`
+
+// runPyarrow : skips the test when python3 with pyarrow is not installed
+func runPyarrow(t *testing.T, stdin []byte, args ...string) []byte {
+	t.Helper()
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not installed")
+	}
+	if err := exec.Command(python, "-c", "import pyarrow.parquet").Run(); err != nil {
+		t.Skip("pyarrow is not installed")
+	}
+	cmd := exec.Command(python, append([]string{"-c", pyarrowScript}, args...)...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	require.NoError(t, err, stderr.String())
+	return out
+}
+
+func pyarrowBars() []Bar {
+	return []Bar{
+		{Start: listing, Open: bybit.RequireDecimal("16500.00"), High: bybit.RequireDecimal("16510.5"), Low: bybit.RequireDecimal("16490"), Close: bybit.RequireDecimal("16505.25"), Volume: bybit.RequireDecimal("12.345"), Turnover: bybit.RequireDecimal("203766.56")},
+		{Start: listing.Add(time.Hour), Open: bybit.RequireDecimal("16505.25"), High: bybit.RequireDecimal("16600"), Low: bybit.RequireDecimal("16500"), Close: bybit.RequireDecimal("16590"), Volume: bybit.RequireDecimal("0"), Turnover: bybit.RequireDecimal("0")},
+	}
+}
+
+// pyarrowColumns : the bars as columns of the names, start in milliseconds
+func pyarrowColumns(names []string, bars []Bar) map[string]interface{} {
+	columns := map[string]interface{}{}
+	var starts []int64
+	values := make([][]string, len(names)-1)
+	for _, bar := range bars {
+		starts = append(starts, bar.Start.UnixMilli())
+		for i, value := range bar.values(len(names)) {
+			values[i] = append(values[i], value.String())
+		}
+	}
+	columns[names[0]] = starts
+	for i, name := range names[1:] {
+		columns[name] = values[i]
+	}
+	return columns
+}
+
+// TestParquetPyarrow : the files of WriteParquet are read by pyarrow, and ReadParquet reads the files pyarrow writes
+func TestParquetPyarrow(t *testing.T) {
+	bars := pyarrowBars()
+	for _, kind := range []Kind{KindTrade, KindIndex} {
+		names := columns(kind)
+		t.Run(string(kind)+" written by WriteParquet", func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteParquet(&buf, kind, bars))
+			path := filepath.Join(t.TempDir(), "bars.parquet")
+			require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+			var got struct {
+				Types    map[string]string      `json:"types"`
+				Nullable map[string]bool        `json:"nullable"`
+				Columns  map[string]interface{} `json:"columns"`
+			}
+			require.NoError(t, json.Unmarshal(runPyarrow(t, nil, "read", path), &got))
+			assert.Equal(t, "timestamp[ms]", got.Types["start"])
+			for _, name := range names {
+				assert.False(t, got.Nullable[name], name)
+				if name != "start" {
+					assert.Equal(t, "string", got.Types[name], name)
+				}
+			}
+			want, err := json.Marshal(pyarrowColumns(names, bars))
+			require.NoError(t, err)
+			gotColumns, err := json.Marshal(got.Columns)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(gotColumns))
+		})
+		t.Run(string(kind)+" written by pyarrow", func(t *testing.T) {
+			input, err := json.Marshal(pyarrowColumns(names, bars))
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), "bars.parquet")
+			runPyarrow(t, input, "write", path, strings.Join(names, ","))
+			f, err := os.Open(path)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := ReadParquet(f)
+			require.NoError(t, err)
+			require.Len(t, got, len(bars))
+			for i := range bars {
+				assert.Equal(t, bars[i].Start, got[i].Start)
+				for j, value := range bars[i].values(len(names)) {
+					assert.Equal(t, value.String(), got[i].values(len(names))[j].String(), names[j+1])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format : of the files Sync keeps
+type Format string
+
+const (
+	// FormatCSV : appended to on every sync
+	FormatCSV = Format("csv")
+	// FormatParquet : rewritten on every sync
+	FormatParquet = Format("parquet")
+)
+
+// SyncResult :
+type SyncResult struct {
+	Path string
+	// Added : bars written by this sync
+	Added int
+	// Gaps : in the range this sync downloaded, including the one right after the last stored bar
+	Gaps []Gap
+}
+
+// FileName : one file per category, symbol, interval and kind, e.g. linear_BTCUSDT_60.csv or linear_BTCUSDT_60_mark.parquet
+func FileName(req Request, format Format) string {
+	name := fmt.Sprintf("%s_%s_%s", req.Category, req.Symbol, req.Interval)
+	if req.Kind != "" && req.Kind != KindTrade {
+		name += "_" + string(req.Kind)
+	}
+	return name + "." + string(format)
+}
+
+// Sync : downloads req into its file under dir, starting after the last stored bar when the file exists
+func (d *Downloader) Sync(ctx context.Context, req Request, dir string, format Format) (SyncResult, error) {
+	if format != FormatCSV && format != FormatParquet {
+		return SyncResult{}, fmt.Errorf("unknown format %q", format)
+	}
+	req, err := d.normalize(req)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	result := SyncResult{Path: filepath.Join(dir, FileName(req, format))}
+
+	stored, err := readFile(result.Path, format)
+	if err != nil {
+		return result, fmt.Errorf("read %s: %w", result.Path, err)
+	}
+	if len(stored) > 0 {
+		resume, err := nextStart(stored[len(stored)-1].Start, req.Interval)
+		if err != nil {
+			return result, err
+		}
+		if resume.After(req.Start) {
+			req.Start = resume
+		}
+		if req.Start.After(req.End) {
+			return result, nil
+		}
+	}
+
+	downloaded, err := d.Download(ctx, req)
+	if err != nil {
+		return result, err
+	}
+	result.Added = len(downloaded.Bars)
+	result.Gaps = downloaded.Gaps
+	if len(downloaded.Bars) == 0 && len(stored) > 0 {
+		return result, nil
+	}
+
+	if format == FormatCSV {
+		f, err := os.OpenFile(result.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return result, err
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return result, err
+		}
+		if err := WriteCSV(f, req.Kind, downloaded.Bars, info.Size() == 0); err != nil {
+			f.Close()
+			return result, err
+		}
+		return result, f.Close()
+	}
+	return result, writeParquetFile(result.Path, req.Kind, append(stored, downloaded.Bars...))
+}
+
+func readFile(path string, format Format) ([]Bar, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if format == FormatParquet {
+		return ReadParquet(f)
+	}
+	return ReadCSV(f)
+}
+
+// writeParquetFile : through a temporary file so that an interrupted sync leaves the old file
+func writeParquetFile(path string, kind Kind, bars []Bar) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), strings.TrimSuffix(filepath.Base(path), ".parquet")+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := WriteParquet(tmp, kind, bars); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,432 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInstrumentNotFound :
+var ErrInstrumentNotFound = errors.New("instrument not found")
+
+// V5Instrument : trading rules of one symbol, zero when the exchange does not apply the rule to the category
+type V5Instrument struct {
+	Category   CategoryV5
+	Symbol     SymbolV5
+	Status     InstrumentStatus
+	BaseCoin   Coin
+	QuoteCoin  Coin
+	SettleCoin Coin
+
+	// LaunchTime and DeliveryTime : linear, inverse and option only
+	LaunchTime   MillisTime
+	DeliveryTime MillisTime
+
+	// TickSize, MinPrice and MaxPrice : from priceFilter, spot has only a tick size
+	TickSize Decimal
+	MinPrice Decimal
+	MaxPrice Decimal
+
+	// QtyStep : from lotSizeFilter, basePrecision for spot
+	QtyStep     Decimal
+	MinOrderQty Decimal
+	MaxOrderQty Decimal
+	// MinOrderAmt and MaxOrderAmt : spot only, in quote coin
+	MinOrderAmt Decimal
+	MaxOrderAmt Decimal
+
+	// MinLeverage, MaxLeverage and LeverageStep : from leverageFilter, linear and inverse only
+	MinLeverage  Decimal
+	MaxLeverage  Decimal
+	LeverageStep Decimal
+}
+
+// Tradable : whether the status lets orders in
+func (i V5Instrument) Tradable() bool {
+	switch i.Status {
+	case InstrumentStatusTrading, InstrumentStatusOnline, InstrumentStatusAvailable:
+		return true
+	}
+	return false
+}
+
+// InstrumentRegistry :
+// InstrumentRegistry keeps the tick size, lot size and leverage rules of every symbol,
+// so that orders can be rounded and checked before they are sent.
+type InstrumentRegistry struct {
+	client          *Client
+	categories      []CategoryV5
+	optionBaseCoins []Coin
+	refreshInterval time.Duration
+	pageLimit       int
+
+	mu          sync.RWMutex
+	instruments map[CategoryV5]map[SymbolV5]V5Instrument
+	lastLoaded  time.Time
+}
+
+// defaultOptionBaseCoins : the exchange lists option instruments per base coin, BTC only when none is given
+var defaultOptionBaseCoins = []Coin{CoinBTC, CoinETH, CoinSOL}
+
+// NewInstrumentRegistry : client only needs to reach public endpoints
+func NewInstrumentRegistry(client *Client) *InstrumentRegistry {
+	return &InstrumentRegistry{
+		client:          client,
+		categories:      []CategoryV5{CategoryV5Spot, CategoryV5Linear, CategoryV5Inverse, CategoryV5Option},
+		optionBaseCoins: defaultOptionBaseCoins,
+		refreshInterval: time.Hour,
+		pageLimit:       1000,
+		instruments:     map[CategoryV5]map[SymbolV5]V5Instrument{},
+	}
+}
+
+// WithCategories : categories Load fetches, all of them by default
+func (r *InstrumentRegistry) WithCategories(categories ...CategoryV5) *InstrumentRegistry {
+	r.categories = categories
+
+	return r
+}
+
+// WithOptionBaseCoins : base coins Load fetches the option instruments of, BTC, ETH and SOL by default
+func (r *InstrumentRegistry) WithOptionBaseCoins(coins ...Coin) *InstrumentRegistry {
+	r.optionBaseCoins = coins
+
+	return r
+}
+
+// WithRefreshInterval : how often Start reloads, an hour by default
+func (r *InstrumentRegistry) WithRefreshInterval(d time.Duration) *InstrumentRegistry {
+	r.refreshInterval = d
+
+	return r
+}
+
+// Load : fetches every page of every category and replaces the cached rules.
+// A category that fails keeps its previous rules, and LastLoaded is left as is.
+func (r *InstrumentRegistry) Load() error {
+	var errs []error
+	for _, category := range r.categories {
+		instruments, err := r.fetch(category)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", category, err))
+			continue
+		}
+		r.mu.Lock()
+		r.instruments[category] = instruments
+		r.mu.Unlock()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("load instruments: %v", errs)
+	}
+	r.mu.Lock()
+	r.lastLoaded = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// Start : loads at once, then reloads every refresh interval until ctx is done,
+// errors are logged and the previous rules kept
+func (r *InstrumentRegistry) Start(ctx context.Context) {
+	if err := r.Load(); err != nil {
+		log.Println(err)
+	}
+	ticker := time.NewTicker(r.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Load(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// LastLoaded : when Load last succeeded for every category, zero until then
+func (r *InstrumentRegistry) LastLoaded() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastLoaded
+}
+
+// Set : adds or replaces instruments by hand, for tests and offline use
+func (r *InstrumentRegistry) Set(instruments ...V5Instrument) *InstrumentRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, instrument := range instruments {
+		if r.instruments[instrument.Category] == nil {
+			r.instruments[instrument.Category] = map[SymbolV5]V5Instrument{}
+		}
+		r.instruments[instrument.Category][instrument.Symbol] = instrument
+	}
+	return r
+}
+
+// Instrument :
+func (r *InstrumentRegistry) Instrument(category CategoryV5, symbol SymbolV5) (V5Instrument, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	instrument, ok := r.instruments[category][symbol]
+	if !ok {
+		return V5Instrument{}, fmt.Errorf("%s %s: %w", category, symbol, ErrInstrumentNotFound)
+	}
+	return instrument, nil
+}
+
+// Instruments : every cached instrument of the category
+func (r *InstrumentRegistry) Instruments(category CategoryV5) []V5Instrument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]V5Instrument, 0, len(r.instruments[category]))
+	for _, instrument := range r.instruments[category] {
+		result = append(result, instrument)
+	}
+	return result
+}
+
+// RoundPrice : to the nearest multiple of the tick size
+func (r *InstrumentRegistry) RoundPrice(category CategoryV5, symbol SymbolV5, price Decimal) (Decimal, error) {
+	instrument, err := r.Instrument(category, symbol)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewDecimal(roundToStep(price.Decimal, instrument.TickSize.Decimal, false)), nil
+}
+
+// RoundQty : down to a multiple of the qty step, so that it never exceeds what the caller asked for
+func (r *InstrumentRegistry) RoundQty(category CategoryV5, symbol SymbolV5, qty Decimal) (Decimal, error) {
+	instrument, err := r.Instrument(category, symbol)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewDecimal(roundToStep(qty.Decimal, instrument.QtyStep.Decimal, true)), nil
+}
+
+// ValidateOrder : checks status, qty and prices against the cached rules
+func (r *InstrumentRegistry) ValidateOrder(param V5CreateOrderParam) error {
+	instrument, err := r.Instrument(param.Category, param.Symbol)
+	if err != nil {
+		return err
+	}
+	if !instrument.Tradable() {
+		return fmt.Errorf("%s is not trading: %s", param.Symbol, instrument.Status)
+	}
+
+	qty, err := NewDecimalFromString(param.Qty)
+	if err != nil {
+		return fmt.Errorf("qty: %w", err)
+	}
+	if !qty.IsPositive() {
+		return fmt.Errorf("qty must be positive: %s", param.Qty)
+	}
+	// a spot market buy is sized in quote coin unless told otherwise
+	if param.Category == CategoryV5Spot && param.OrderType == OrderTypeMarket && param.Side == SideBuy {
+		if err := checkRange("order amount", qty, instrument.MinOrderAmt, instrument.MaxOrderAmt); err != nil {
+			return err
+		}
+	} else {
+		if err := checkRange("qty", qty, instrument.MinOrderQty, instrument.MaxOrderQty); err != nil {
+			return err
+		}
+		if err := checkStep("qty", qty, instrument.QtyStep); err != nil {
+			return err
+		}
+	}
+
+	prices := []struct {
+		name  string
+		value *string
+	}{
+		{"price", param.Price},
+		{"trigger price", param.TriggerPrice},
+		{"take profit", param.TakeProfit},
+		{"stop loss", param.StopLoss},
+	}
+	for _, p := range prices {
+		if p.value == nil || *p.value == "" {
+			continue
+		}
+		if p.name == "price" && param.OrderType == OrderTypeMarket {
+			continue
+		}
+		price, err := NewDecimalFromString(*p.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.name, err)
+		}
+		if err := checkRange(p.name, price, instrument.MinPrice, instrument.MaxPrice); err != nil {
+			return err
+		}
+		if err := checkStep(p.name, price, instrument.TickSize); err != nil {
+			return err
+		}
+	}
+
+	if param.Category == CategoryV5Spot && param.OrderType == OrderTypeLimit && param.Price != nil {
+		price, _ := NewDecimalFromString(*param.Price)
+		amount := NewDecimal(qty.Mul(price.Decimal))
+		if err := checkRange("order amount", amount, instrument.MinOrderAmt, instrument.MaxOrderAmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetch : every page of the category
+func (r *InstrumentRegistry) fetch(category CategoryV5) (map[SymbolV5]V5Instrument, error) {
+	result := map[SymbolV5]V5Instrument{}
+	baseCoins := []*Coin{nil}
+	if category == CategoryV5Option && len(r.optionBaseCoins) > 0 {
+		baseCoins = baseCoins[:0]
+		for i := range r.optionBaseCoins {
+			baseCoins = append(baseCoins, &r.optionBaseCoins[i])
+		}
+	}
+	for _, baseCoin := range baseCoins {
+		var cursor *string
+		for {
+			param := V5GetInstrumentsInfoParam{
+				Category: category,
+				BaseCoin: baseCoin,
+				Cursor:   cursor,
+			}
+			if category != CategoryV5Spot {
+				limit := r.pageLimit
+				param.Limit = &limit
+			}
+			res, err := r.client.V5().Market().GetInstrumentsInfo(param)
+			if err != nil {
+				return nil, err
+			}
+			next, err := collectInstruments(category, res.Result, result)
+			if err != nil {
+				return nil, err
+			}
+			if next == "" || (cursor != nil && next == *cursor) {
+				break
+			}
+			cursor = &next
+		}
+	}
+	return result, nil
+}
+
+// collectInstruments : adds the page to dst and returns the next page cursor
+func collectInstruments(category CategoryV5, page V5GetInstrumentsInfoResult, dst map[SymbolV5]V5Instrument) (string, error) {
+	p := &decimalParser{}
+	switch {
+	case page.LinearInverse != nil:
+		for _, item := range page.LinearInverse.List {
+			dst[item.Symbol] = V5Instrument{
+				Category:     category,
+				Symbol:       item.Symbol,
+				Status:       item.Status,
+				BaseCoin:     item.BaseCoin,
+				QuoteCoin:    item.QuoteCoin,
+				SettleCoin:   item.SettleCoin,
+				LaunchTime:   item.LaunchTime,
+				DeliveryTime: item.DeliveryTime,
+				TickSize:     p.parse("tickSize", item.PriceFilter.TickSize),
+				MinPrice:     p.parse("minPrice", item.PriceFilter.MinPrice),
+				MaxPrice:     p.parse("maxPrice", item.PriceFilter.MaxPrice),
+				QtyStep:      p.parse("qtyStep", item.LotSizeFilter.QtyStep),
+				MinOrderQty:  p.parse("minOrderQty", item.LotSizeFilter.MinOrderQty),
+				MaxOrderQty:  p.parse("maxOrderQty", item.LotSizeFilter.MaxOrderQty),
+				MinLeverage:  p.parse("minLeverage", item.LeverageFilter.MinLeverage),
+				MaxLeverage:  p.parse("maxLeverage", item.LeverageFilter.MaxLeverage),
+				LeverageStep: p.parse("leverageStep", item.LeverageFilter.LeverageStep),
+			}
+			if p.err != nil {
+				return "", fmt.Errorf("%s: %w", item.Symbol, p.err)
+			}
+		}
+		return page.LinearInverse.NextPageCursor, nil
+	case page.Option != nil:
+		for _, item := range page.Option.List {
+			dst[item.Symbol] = V5Instrument{
+				Category:     category,
+				Symbol:       item.Symbol,
+				Status:       item.Status,
+				BaseCoin:     item.BaseCoin,
+				QuoteCoin:    item.QuoteCoin,
+				SettleCoin:   item.SettleCoin,
+				LaunchTime:   item.LaunchTime,
+				DeliveryTime: item.DeliveryTime,
+				TickSize:     p.parse("tickSize", item.PriceFilter.TickSize),
+				MinPrice:     p.parse("minPrice", item.PriceFilter.MinPrice),
+				MaxPrice:     p.parse("maxPrice", item.PriceFilter.MaxPrice),
+				QtyStep:      p.parse("qtyStep", item.LotSizeFilter.QtyStep),
+				MinOrderQty:  p.parse("minOrderQty", item.LotSizeFilter.MinOrderQty),
+				MaxOrderQty:  p.parse("maxOrderQty", item.LotSizeFilter.MaxOrderQty),
+			}
+			if p.err != nil {
+				return "", fmt.Errorf("%s: %w", item.Symbol, p.err)
+			}
+		}
+		return page.Option.NextPageCursor, nil
+	case page.Spot != nil:
+		for _, item := range page.Spot.List {
+			dst[item.Symbol] = V5Instrument{
+				Category:    category,
+				Symbol:      item.Symbol,
+				Status:      item.Status,
+				BaseCoin:    item.BaseCoin,
+				QuoteCoin:   item.QuoteCoin,
+				TickSize:    p.parse("tickSize", item.PriceFilter.TickSize),
+				QtyStep:     p.parse("basePrecision", item.LotSizeFilter.BasePrecision),
+				MinOrderQty: p.parse("minOrderQty", item.LotSizeFilter.MinOrderQty),
+				MaxOrderQty: p.parse("maxOrderQty", item.LotSizeFilter.MaxOrderQty),
+				MinOrderAmt: p.parse("minOrderAmt", item.LotSizeFilter.MinOrderAmt),
+				MaxOrderAmt: p.parse("maxOrderAmt", item.LotSizeFilter.MaxOrderAmt),
+			}
+			if p.err != nil {
+				return "", fmt.Errorf("%s: %w", item.Symbol, p.err)
+			}
+		}
+		return "", nil
+	}
+	return "", nil
+}
+
+// roundToStep : a zero step leaves the value as is
+func roundToStep(value decimal.Decimal, step decimal.Decimal, down bool) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	steps := value.Div(step)
+	if down {
+		steps = steps.Floor()
+	} else {
+		steps = steps.Round(0)
+	}
+	return steps.Mul(step)
+}
+
+// checkRange : a zero bound is not checked
+func checkRange(name string, value Decimal, min Decimal, max Decimal) error {
+	if min.IsPositive() && value.LessThan(min.Decimal) {
+		return fmt.Errorf("%s %s is below the minimum %s", name, value, min)
+	}
+	if max.IsPositive() && value.GreaterThan(max.Decimal) {
+		return fmt.Errorf("%s %s is above the maximum %s", name, value, max)
+	}
+	return nil
+}
+
+// checkStep : a zero step is not checked
+func checkStep(name string, value Decimal, step Decimal) error {
+	if !step.IsPositive() {
+		return nil
+	}
+	if !value.Mod(step.Decimal).IsZero() {
+		return fmt.Errorf("%s %s is not a multiple of %s", name, value, step)
+	}
+	return nil
+}
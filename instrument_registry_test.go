@@ -0,0 +1,219 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInstrumentServer(t *testing.T) (*httptest.Server, *[]string) {
+	requests := []string{}
+	pages := map[string]map[string]interface{}{
+		"linear": {
+			"category":       "linear",
+			"nextPageCursor": "page2",
+			"list": []map[string]interface{}{
+				{
+					"symbol":         "BTCUSDT",
+					"status":         "Trading",
+					"baseCoin":       "BTC",
+					"quoteCoin":      "USDT",
+					"settleCoin":     "USDT",
+					"launchTime":     "1584230400000",
+					"deliveryTime":   "0",
+					"leverageFilter": map[string]interface{}{"minLeverage": "1", "maxLeverage": "100.00", "leverageStep": "0.01"},
+					"priceFilter":    map[string]interface{}{"minPrice": "0.50", "maxPrice": "999999.00", "tickSize": "0.50"},
+					"lotSizeFilter":  map[string]interface{}{"maxOrderQty": "100.000", "minOrderQty": "0.001", "qtyStep": "0.001"},
+				},
+			},
+		},
+		"linear/page2": {
+			"category":       "linear",
+			"nextPageCursor": "",
+			"list": []map[string]interface{}{
+				{
+					"symbol":         "ETHUSDT",
+					"status":         "Settling",
+					"baseCoin":       "ETH",
+					"quoteCoin":      "USDT",
+					"settleCoin":     "USDT",
+					"launchTime":     "1615766400000",
+					"deliveryTime":   "0",
+					"leverageFilter": map[string]interface{}{"minLeverage": "1", "maxLeverage": "100.00", "leverageStep": "0.01"},
+					"priceFilter":    map[string]interface{}{"minPrice": "0.05", "maxPrice": "99999.90", "tickSize": "0.05"},
+					"lotSizeFilter":  map[string]interface{}{"maxOrderQty": "1500.00", "minOrderQty": "0.01", "qtyStep": "0.01"},
+				},
+			},
+		},
+		"spot": {
+			"category": "spot",
+			"list": []map[string]interface{}{
+				{
+					"symbol":        "BTCUSDT",
+					"status":        "Trading",
+					"baseCoin":      "BTC",
+					"quoteCoin":     "USDT",
+					"lotSizeFilter": map[string]interface{}{"basePrecision": "0.000001", "quotePrecision": "0.00000001", "minOrderQty": "0.000048", "maxOrderQty": "71.73956243", "minOrderAmt": "1", "maxOrderAmt": "2000000"},
+					"priceFilter":   map[string]interface{}{"tickSize": "0.01"},
+				},
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("category")
+		if baseCoin := r.URL.Query().Get("baseCoin"); baseCoin != "" {
+			key += "/" + baseCoin
+		}
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			key += "/" + cursor
+		}
+		requests = append(requests, key)
+		page, ok := pages[key]
+		if !ok {
+			page = map[string]interface{}{"category": r.URL.Query().Get("category"), "list": []interface{}{}}
+		}
+		body, err := json.Marshal(map[string]interface{}{"retCode": 0, "retMsg": "OK", "result": page})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	return server, &requests
+}
+
+func TestInstrumentRegistry(t *testing.T) {
+	server, requests := newTestInstrumentServer(t)
+	defer server.Close()
+
+	client := NewTestClient().WithBaseURL(server.URL)
+	registry := NewInstrumentRegistry(client.Client).WithCategories(CategoryV5Linear, CategoryV5Spot)
+	require.NoError(t, registry.Load())
+	assert.Equal(t, []string{"linear", "linear/page2", "spot"}, *requests)
+	assert.Len(t, registry.Instruments(CategoryV5Linear), 2)
+
+	t.Run("instrument", func(t *testing.T) {
+		instrument, err := registry.Instrument(CategoryV5Linear, SymbolV5BTCUSDT)
+		require.NoError(t, err)
+		assert.Equal(t, "0.50", instrument.TickSize.String())
+		assert.Equal(t, "0.001", instrument.MinOrderQty.String())
+		assert.Equal(t, "100.00", instrument.MaxLeverage.String())
+
+		_, err = registry.Instrument(CategoryV5Inverse, SymbolV5BTCUSDT)
+		assert.True(t, errors.Is(err, ErrInstrumentNotFound))
+	})
+	t.Run("round", func(t *testing.T) {
+		price, err := registry.RoundPrice(CategoryV5Linear, SymbolV5BTCUSDT, RequireDecimal("16493.74"))
+		require.NoError(t, err)
+		assert.True(t, price.Equal(RequireDecimal("16493.5").Decimal))
+
+		qty, err := registry.RoundQty(CategoryV5Linear, SymbolV5BTCUSDT, RequireDecimal("0.0129"))
+		require.NoError(t, err)
+		assert.True(t, qty.Equal(RequireDecimal("0.012").Decimal))
+	})
+	t.Run("validate", func(t *testing.T) {
+		price := "16493.5"
+		offTick := "16493.7"
+		param := V5CreateOrderParam{
+			Category:  CategoryV5Linear,
+			Symbol:    SymbolV5BTCUSDT,
+			Side:      SideBuy,
+			OrderType: OrderTypeLimit,
+			Qty:       "0.012",
+			Price:     &price,
+		}
+		assert.NoError(t, registry.ValidateOrder(param))
+
+		tooSmall := param
+		tooSmall.Qty = "0.0001"
+		assert.Error(t, registry.ValidateOrder(tooSmall))
+
+		offStep := param
+		offStep.Qty = "0.0125"
+		assert.Error(t, registry.ValidateOrder(offStep))
+
+		badPrice := param
+		badPrice.Price = &offTick
+		assert.Error(t, registry.ValidateOrder(badPrice))
+
+		settling := param
+		settling.Symbol = SymbolV5("ETHUSDT")
+		settling.Qty = "0.01"
+		settling.Price = nil
+		assert.Error(t, registry.ValidateOrder(settling))
+
+		spotBuy := V5CreateOrderParam{
+			Category:  CategoryV5Spot,
+			Symbol:    SymbolV5BTCUSDT,
+			Side:      SideBuy,
+			OrderType: OrderTypeMarket,
+			Qty:       "0.5",
+		}
+		assert.Error(t, registry.ValidateOrder(spotBuy), "below the minimum order amount")
+		spotBuy.Qty = "10"
+		assert.NoError(t, registry.ValidateOrder(spotBuy))
+	})
+	t.Run("create order is rejected before sending", func(t *testing.T) {
+		before := len(*requests)
+		client := NewTestClient().
+			WithBaseURL(server.URL).
+			WithAuth("test", "test").
+			WithInstrumentRegistry(registry)
+		_, err := client.V5().Order().CreateOrder(V5CreateOrderParam{
+			Category:  CategoryV5Linear,
+			Symbol:    SymbolV5BTCUSDT,
+			Side:      SideBuy,
+			OrderType: OrderTypeMarket,
+			Qty:       "0.0001",
+		})
+		assert.Error(t, err)
+		assert.Equal(t, before, len(*requests))
+	})
+}
+
+func TestInstrumentRegistryLoad(t *testing.T) {
+	t.Run("option base coins", func(t *testing.T) {
+		server, requests := newTestInstrumentServer(t)
+		defer server.Close()
+
+		registry := NewInstrumentRegistry(NewTestClient().WithBaseURL(server.URL).Client).WithCategories(CategoryV5Option)
+		require.NoError(t, registry.Load())
+		assert.Equal(t, []string{"option/BTC", "option/ETH", "option/SOL"}, *requests)
+	})
+	t.Run("last loaded on success only", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		registry := NewInstrumentRegistry(NewTestClient().WithBaseURL(server.URL).Client).WithCategories(CategoryV5Linear, CategoryV5Spot)
+		assert.Error(t, registry.Load())
+		assert.True(t, registry.LastLoaded().IsZero())
+	})
+	t.Run("start loads at once", func(t *testing.T) {
+		server, _ := newTestInstrumentServer(t)
+		defer server.Close()
+
+		registry := NewInstrumentRegistry(NewTestClient().WithBaseURL(server.URL).Client).
+			WithCategories(CategoryV5Linear).
+			WithRefreshInterval(time.Hour)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			registry.Start(ctx)
+			close(done)
+		}()
+		defer func() {
+			cancel()
+			<-done
+		}()
+
+		require.Eventually(t, func() bool { return !registry.LastLoaded().IsZero() }, time.Second, 10*time.Millisecond)
+		assert.Len(t, registry.Instruments(CategoryV5Linear), 2)
+	})
+}
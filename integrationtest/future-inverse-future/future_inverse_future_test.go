@@ -13,7 +13,7 @@ import (
 
 func TestBalance(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().InverseFuture().Balance(bybit.CoinUSDT)
 		{
 			require.NoError(t, err)
@@ -27,14 +27,14 @@ func TestBalance(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().InverseFuture().Balance(bybit.CoinBTC)
 		require.Error(t, err)
 	})
 }
 
 func TestOrderBook(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().OrderBook(bybit.SymbolInverseBTCUSD)
 	{
 		require.NoError(t, err)
@@ -48,7 +48,7 @@ func TestOrderBook(t *testing.T) {
 }
 
 func TestListKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().ListKline(bybit.ListKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.Interval120,
@@ -66,7 +66,7 @@ func TestListKline(t *testing.T) {
 }
 
 func TestTickers(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().Tickers(bybit.SymbolInverseBTCUSD)
 	{
 		require.NoError(t, err)
@@ -80,7 +80,7 @@ func TestTickers(t *testing.T) {
 }
 
 func TestTradingRecords(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	limit := 10
 	res, err := client.Future().InverseFuture().TradingRecords(bybit.TradingRecordsParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
@@ -98,7 +98,7 @@ func TestTradingRecords(t *testing.T) {
 }
 
 func TestSymbols(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().Symbols()
 	{
 		require.NoError(t, err)
@@ -112,7 +112,7 @@ func TestSymbols(t *testing.T) {
 }
 
 func TestMarkPriceKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().MarkPriceKline(bybit.MarkPriceKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.IntervalD,
@@ -130,7 +130,7 @@ func TestMarkPriceKline(t *testing.T) {
 }
 
 func TestIndexPriceKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().IndexPriceKline(bybit.IndexPriceKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.IntervalD,
@@ -148,7 +148,7 @@ func TestIndexPriceKline(t *testing.T) {
 }
 
 func TestOpenInterest(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().OpenInterest(bybit.OpenInterestParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
 		Period: bybit.Period1h,
@@ -165,7 +165,7 @@ func TestOpenInterest(t *testing.T) {
 }
 
 func TestBigDeal(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().BigDeal(bybit.BigDealParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
 	})
@@ -181,7 +181,7 @@ func TestBigDeal(t *testing.T) {
 }
 
 func TestAccountRatio(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	limit := 10
 	res, err := client.Future().InverseFuture().AccountRatio(bybit.AccountRatioParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
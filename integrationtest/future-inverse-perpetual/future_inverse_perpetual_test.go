@@ -13,7 +13,7 @@ import (
 
 func TestBalance(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().InversePerpetual().Balance(bybit.CoinUSDT)
 		{
 			require.NoError(t, err)
@@ -27,14 +27,14 @@ func TestBalance(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().InversePerpetual().Balance(bybit.CoinBTC)
 		require.Error(t, err)
 	})
 }
 
 func TestOrderBook(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().OrderBook(bybit.SymbolInverseBTCUSD)
 	{
 		require.NoError(t, err)
@@ -48,7 +48,7 @@ func TestOrderBook(t *testing.T) {
 }
 
 func TestListKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().ListKline(bybit.ListKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.Interval120,
@@ -66,7 +66,7 @@ func TestListKline(t *testing.T) {
 }
 
 func TestTickers(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().Tickers(bybit.SymbolInverseBTCUSD)
 	{
 		require.NoError(t, err)
@@ -80,7 +80,7 @@ func TestTickers(t *testing.T) {
 }
 
 func TestTradingRecords(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	limit := 10
 	res, err := client.Future().InversePerpetual().TradingRecords(bybit.TradingRecordsParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
@@ -98,7 +98,7 @@ func TestTradingRecords(t *testing.T) {
 }
 
 func TestSymbols(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().Symbols()
 	{
 		require.NoError(t, err)
@@ -112,7 +112,7 @@ func TestSymbols(t *testing.T) {
 }
 
 func TestMarkPriceKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InverseFuture().MarkPriceKline(bybit.MarkPriceKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.IntervalD,
@@ -130,7 +130,7 @@ func TestMarkPriceKline(t *testing.T) {
 }
 
 func TestIndexPriceKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().IndexPriceKline(bybit.IndexPriceKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.IntervalD,
@@ -148,7 +148,7 @@ func TestIndexPriceKline(t *testing.T) {
 }
 
 func TestOpenInterest(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().OpenInterest(bybit.OpenInterestParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
 		Period: bybit.Period1h,
@@ -165,7 +165,7 @@ func TestOpenInterest(t *testing.T) {
 }
 
 func TestBigDeal(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().BigDeal(bybit.BigDealParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
 	})
@@ -181,7 +181,7 @@ func TestBigDeal(t *testing.T) {
 }
 
 func TestAccountRatio(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	limit := 10
 	res, err := client.Future().InversePerpetual().AccountRatio(bybit.AccountRatioParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
@@ -200,7 +200,7 @@ func TestAccountRatio(t *testing.T) {
 }
 
 func TestPremiumIndexKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().InversePerpetual().PremiumIndexKline(bybit.PremiumIndexKlineParam{
 		Symbol:   bybit.SymbolInverseBTCUSD,
 		Interval: bybit.Interval120,
@@ -219,7 +219,7 @@ func TestPremiumIndexKline(t *testing.T) {
 
 func TestCreateOrder(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		price := 28383.5
 		res, err := client.Future().InversePerpetual().CreateOrder(bybit.CreateOrderParam{
 			Side:        bybit.SideBuy,
@@ -251,7 +251,7 @@ func TestCreateOrder(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		price := 28383.5
 		_, err := client.Future().InversePerpetual().CreateOrder(bybit.CreateOrderParam{
 			Side:        bybit.SideBuy,
@@ -267,7 +267,7 @@ func TestCreateOrder(t *testing.T) {
 
 func TestListOrder(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		var orderID string
 		{
 			price := 10000.0
@@ -309,7 +309,7 @@ func TestListOrder(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		price := 28383.5
 		_, err := client.Future().InversePerpetual().CreateOrder(bybit.CreateOrderParam{
 			Side:        bybit.SideBuy,
@@ -325,7 +325,7 @@ func TestListOrder(t *testing.T) {
 
 func TestListPosition(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().InversePerpetual().ListPosition(bybit.SymbolInverseBTCUSD)
 		{
 			require.NoError(t, err)
@@ -338,7 +338,7 @@ func TestListPosition(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().InversePerpetual().ListPosition(bybit.SymbolInverseBTCUSD)
 		require.Error(t, err)
 	})
@@ -346,7 +346,7 @@ func TestListPosition(t *testing.T) {
 
 func TestListPositions(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().InversePerpetual().ListPositions()
 		{
 			require.NoError(t, err)
@@ -359,7 +359,7 @@ func TestListPositions(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().InversePerpetual().ListPositions()
 		require.Error(t, err)
 	})
@@ -367,7 +367,7 @@ func TestListPositions(t *testing.T) {
 
 func TestCancelOrder(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		var orderID string
 		{
 			price := 28383.5
@@ -401,7 +401,7 @@ func TestCancelOrder(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().InversePerpetual().CancelOrder(bybit.CancelOrderParam{})
 		require.Error(t, err)
 	})
@@ -409,7 +409,7 @@ func TestCancelOrder(t *testing.T) {
 
 func TestSaveLeverage(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		{
 			res, err := client.Future().InversePerpetual().SaveLeverage(bybit.SaveLeverageParam{
 				Symbol:   bybit.SymbolInverseBTCUSD,
@@ -427,7 +427,7 @@ func TestSaveLeverage(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().InversePerpetual().CancelOrder(bybit.CancelOrderParam{})
 		require.Error(t, err)
 	})
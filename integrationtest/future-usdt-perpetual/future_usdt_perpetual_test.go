@@ -12,7 +12,7 @@ import (
 
 func TestBalance(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().USDTPerpetual().Balance(bybit.CoinUSDT)
 		{
 			require.NoError(t, err)
@@ -26,14 +26,14 @@ func TestBalance(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().Balance(bybit.CoinBTC)
 		require.Error(t, err)
 	})
 }
 
 func TestOrderBook(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().USDTPerpetual().OrderBook(bybit.SymbolInverseBTCUSD)
 	{
 		require.NoError(t, err)
@@ -47,7 +47,7 @@ func TestOrderBook(t *testing.T) {
 }
 
 func TestTickers(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().USDTPerpetual().Tickers(bybit.SymbolInverseBTCUSD)
 	{
 		require.NoError(t, err)
@@ -61,7 +61,7 @@ func TestTickers(t *testing.T) {
 }
 
 func TestSymbols(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().USDTPerpetual().Symbols()
 	{
 		require.NoError(t, err)
@@ -75,7 +75,7 @@ func TestSymbols(t *testing.T) {
 }
 
 func TestOpenInterest(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().USDTPerpetual().OpenInterest(bybit.OpenInterestParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
 		Period: bybit.Period1h,
@@ -92,7 +92,7 @@ func TestOpenInterest(t *testing.T) {
 }
 
 func TestBigDeal(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Future().USDTPerpetual().BigDeal(bybit.BigDealParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
 	})
@@ -108,7 +108,7 @@ func TestBigDeal(t *testing.T) {
 }
 
 func TestAccountRatio(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	limit := 10
 	res, err := client.Future().USDTPerpetual().AccountRatio(bybit.AccountRatioParam{
 		Symbol: bybit.SymbolInverseBTCUSD,
@@ -128,7 +128,7 @@ func TestAccountRatio(t *testing.T) {
 
 func TestCreateLinearOrder(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		price := 28383.5
 		res, err := client.Future().USDTPerpetual().CreateLinearOrder(bybit.CreateLinearOrderParam{
 			Side:        bybit.SideBuy,
@@ -149,7 +149,7 @@ func TestCreateLinearOrder(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		price := 28383.5
 		_, err := client.Future().USDTPerpetual().CreateLinearOrder(bybit.CreateLinearOrderParam{
 			Side:        bybit.SideBuy,
@@ -165,7 +165,7 @@ func TestCreateLinearOrder(t *testing.T) {
 
 func TestListLinearPosition(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().USDTPerpetual().ListLinearPosition(bybit.SymbolUSDTBTC)
 		{
 			require.NoError(t, err)
@@ -178,7 +178,7 @@ func TestListLinearPosition(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().ListLinearPosition(bybit.SymbolUSDTBTC)
 		require.Error(t, err)
 	})
@@ -186,7 +186,7 @@ func TestListLinearPosition(t *testing.T) {
 
 func TestListLinearPositions(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().USDTPerpetual().ListLinearPositions()
 		{
 			require.NoError(t, err)
@@ -199,7 +199,7 @@ func TestListLinearPositions(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().ListLinearPositions()
 		require.Error(t, err)
 	})
@@ -207,7 +207,7 @@ func TestListLinearPositions(t *testing.T) {
 
 func TestCancelLinearOrder(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 
 		var orderID string
 		{
@@ -240,7 +240,7 @@ func TestCancelLinearOrder(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().CancelLinearOrder(bybit.CancelLinearOrderParam{})
 		require.Error(t, err)
 	})
@@ -248,7 +248,7 @@ func TestCancelLinearOrder(t *testing.T) {
 
 func TestSaveLinearLeverage(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().USDTPerpetual().SaveLinearLeverage(bybit.SaveLinearLeverageParam{
 			Symbol:       bybit.SymbolUSDTBTC,
 			BuyLeverage:  2.0,
@@ -261,7 +261,7 @@ func TestSaveLinearLeverage(t *testing.T) {
 	})
 
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().CancelLinearOrder(bybit.CancelLinearOrderParam{})
 		require.Error(t, err)
 	})
@@ -269,7 +269,7 @@ func TestSaveLinearLeverage(t *testing.T) {
 
 func TestLinearExecutionList(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().USDTPerpetual().LinearExecutionList(bybit.LinearExecutionListParam{
 			Symbol: bybit.SymbolUSDTBTC,
 		})
@@ -284,7 +284,7 @@ func TestLinearExecutionList(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().LinearExecutionList(bybit.LinearExecutionListParam{})
 		require.Error(t, err)
 	})
@@ -292,7 +292,7 @@ func TestLinearExecutionList(t *testing.T) {
 
 func TestAccountService_LinearCancelAllOrder(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
-		client := bybit.NewTestClient().WithAuthFromEnv()
+		client := testhelper.NewClientWithAuth(t)
 		res, err := client.Future().USDTPerpetual().LinearCancelAllOrder(bybit.LinearCancelAllParam{
 			Symbol: bybit.SymbolUSDTBTC,
 		})
@@ -307,7 +307,7 @@ func TestAccountService_LinearCancelAllOrder(t *testing.T) {
 		}
 	})
 	t.Run("auth error", func(t *testing.T) {
-		client := bybit.NewTestClient()
+		client := testhelper.NewClient(t)
 		_, err := client.Future().USDTPerpetual().LinearCancelAllOrder(bybit.LinearCancelAllParam{})
 		require.Error(t, err)
 	})
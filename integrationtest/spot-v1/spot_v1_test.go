@@ -11,7 +11,7 @@ import (
 )
 
 func TestSpotSymbols(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Spot().V1().SpotSymbols()
 	{
 		require.NoError(t, err)
@@ -25,7 +25,7 @@ func TestSpotSymbols(t *testing.T) {
 }
 
 func TestSpotQuoteDepth(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Spot().V1().SpotQuoteDepth(bybit.SpotQuoteDepthParam{
 		Symbol: bybit.SymbolSpotBTCUSDT,
 	})
@@ -41,7 +41,7 @@ func TestSpotQuoteDepth(t *testing.T) {
 }
 
 func TestSpotQuoteDepthMerged(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Spot().V1().SpotQuoteDepthMerged(bybit.SpotQuoteDepthMergedParam{
 		Symbol: bybit.SymbolSpotBTCUSDT,
 	})
@@ -57,7 +57,7 @@ func TestSpotQuoteDepthMerged(t *testing.T) {
 }
 
 func TestSpotQuoteTrades(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Spot().V1().SpotQuoteTrades(bybit.SpotQuoteTradesParam{
 		Symbol: bybit.SymbolSpotBTCUSDT,
 	})
@@ -72,7 +72,7 @@ func TestSpotQuoteTrades(t *testing.T) {
 }
 
 func TestSpotQuoteKline(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	res, err := client.Spot().V1().SpotQuoteKline(bybit.SpotQuoteKlineParam{
 		Symbol:   bybit.SymbolSpotBTCUSDT,
 		Interval: bybit.SpotInterval1d,
@@ -88,7 +88,7 @@ func TestSpotQuoteKline(t *testing.T) {
 }
 
 func TestSpotQuoteTicker24hr(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	symbol := bybit.SymbolSpotBTCUSDT
 	res, err := client.Spot().V1().SpotQuoteTicker24hr(bybit.SpotQuoteTicker24hrParam{
 		Symbol: &symbol,
@@ -104,7 +104,7 @@ func TestSpotQuoteTicker24hr(t *testing.T) {
 }
 
 func TestSpotQuoteTickerPrice(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	symbol := bybit.SymbolSpotBTCUSDT
 	res, err := client.Spot().V1().SpotQuoteTickerPrice(bybit.SpotQuoteTickerPriceParam{
 		Symbol: &symbol,
@@ -120,7 +120,7 @@ func TestSpotQuoteTickerPrice(t *testing.T) {
 }
 
 func TestSpotQuoteTickerBookTicker(t *testing.T) {
-	client := bybit.NewTestClient()
+	client := testhelper.NewClient(t)
 	symbol := bybit.SymbolSpotBTCUSDT
 	res, err := client.Spot().V1().SpotQuoteTickerBookTicker(bybit.SpotQuoteTickerBookTickerParam{
 		Symbol: &symbol,
@@ -136,7 +136,7 @@ func TestSpotQuoteTickerBookTicker(t *testing.T) {
 }
 
 func TestSpotPostOrder(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 	price := 18383.5
 	res, err := client.Spot().V1().SpotPostOrder(bybit.SpotPostOrderParam{
 		Symbol: bybit.SymbolSpotBTCUSDT,
@@ -166,7 +166,7 @@ func TestSpotPostOrder(t *testing.T) {
 }
 
 func TestSpotGetOrder(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 
 	var orderID string
 	{
@@ -207,7 +207,7 @@ func TestSpotGetOrder(t *testing.T) {
 }
 
 func TestSpotDeleteOrder(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 
 	var orderID string
 	{
@@ -239,7 +239,7 @@ func TestSpotDeleteOrder(t *testing.T) {
 }
 
 func TestSpotDeleteFastOrder(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 
 	var orderID string
 	var symbol bybit.SymbolSpot
@@ -274,7 +274,7 @@ func TestSpotDeleteFastOrder(t *testing.T) {
 }
 
 func TestSpotOrderBatchCancel(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 
 	var symbol bybit.SymbolSpot
 	{
@@ -306,7 +306,7 @@ func TestSpotOrderBatchCancel(t *testing.T) {
 }
 
 func TestSpotOrderBatchFastCancel(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 
 	var symbol bybit.SymbolSpot
 	{
@@ -338,7 +338,7 @@ func TestSpotOrderBatchFastCancel(t *testing.T) {
 }
 
 func TestSpotOrderBatchCancelByIDs(t *testing.T) {
-	client := bybit.NewTestClient().WithAuthFromEnv()
+	client := testhelper.NewClientWithAuth(t)
 
 	var orderID string
 	{
@@ -0,0 +1,357 @@
+package testhelper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/require"
+)
+
+// CassetteMode : how a Cassette answers requests
+type CassetteMode int
+
+const (
+	// CassetteReplay : answers from the cassette file without reaching the network
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord : sends requests to the network and keeps the interactions to be saved
+	CassetteRecord
+	// CassettePassthrough : sends requests to the network and keeps nothing
+	CassettePassthrough
+)
+
+// scrubbed : what secrets, signatures and timestamps are replaced with
+const scrubbed = "[scrubbed]"
+
+// volatileParams : query and body parameters of the signature of the older APIs
+var volatileParams = []string{"api_key", "sign", "timestamp", "recv_window"}
+
+// keptResponseHeaders : response headers a cassette keeps, the rate limit ones are read by the client
+var keptResponseHeaders = []string{"Content-Type", "X-Bapi-Limit", "X-Bapi-Limit-Status", "X-Bapi-Limit-Reset-Timestamp"}
+
+// Cassette : an http.RoundTripper recording interactions to a file and replaying them, e.g.
+//
+//	cassette, err := testhelper.NewCassette("./testdata/cassettes/TestBalance.json", testhelper.CassetteReplay)
+//	client := bybit.NewTestClient().WithHTTPClient(cassette.HTTPClient())
+//
+// Request headers are not kept, so neither are X-BAPI-API-KEY and X-BAPI-SIGN, api_key, sign, timestamp
+// and recv_window are scrubbed from queries and bodies, and the secrets given to WithSecrets
+// are scrubbed from everything else.
+type Cassette struct {
+	path      string
+	mode      CassetteMode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	secrets      []string
+	interactions []Interaction
+	played       []bool
+}
+
+// Interaction : a request and the response it got
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest : scrubbed, the query is encoded in lexical order
+type CassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse :
+type CassetteResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewCassette : replaying loads path, which must exist
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode, transport: http.DefaultTransport}
+	if mode != CassetteReplay {
+		return c, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	c.interactions = file.Interactions
+	c.played = make([]bool, len(file.Interactions))
+	return c, nil
+}
+
+// WithTransport : the one recording and passing through use, http.DefaultTransport by default
+func (c *Cassette) WithTransport(transport http.RoundTripper) *Cassette {
+	c.transport = transport
+	return c
+}
+
+// WithSecrets : values scrubbed wherever they appear, e.g. the api key echoed by a response
+func (c *Cassette) WithSecrets(secrets ...string) *Cassette {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, secret := range secrets {
+		if secret != "" {
+			c.secrets = append(c.secrets, secret)
+		}
+	}
+	return c
+}
+
+// Mode :
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// HTTPClient : to give to Client.WithHTTPClient
+func (c *Cassette) HTTPClient() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Interactions : recorded or loaded, oldest first
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip : replaying answers with the first interaction not played yet of the same method and path
+// whose scrubbed query and body are the same, or failing that, whatever they are,
+// so that parameters like a start time computed from now still replay in order
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	recorded := c.scrubRequest(req, body)
+
+	if c.mode == CassetteReplay {
+		interaction, err := c.play(recorded)
+		if err != nil {
+			return nil, err
+		}
+		return interaction.Response.httpResponse(req), nil
+	}
+
+	res, err := c.transport.RoundTrip(req)
+	if err != nil || c.mode == CassettePassthrough {
+		return res, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	header := http.Header{}
+	for _, name := range keptResponseHeaders {
+		if value := res.Header.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+	response := CassetteResponse{Status: res.StatusCode, Header: header, Body: c.scrub(string(resBody))}
+	c.mu.Lock()
+	c.interactions = append(c.interactions, Interaction{Request: recorded, Response: response})
+	c.mu.Unlock()
+	return res, nil
+}
+
+// Save : writes the recorded interactions, creating the directory, only when recording
+func (c *Cassette) Save() error {
+	if c.mode != CassetteRecord {
+		return nil
+	}
+	c.mu.Lock()
+	b, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return SaveToFile(c.path, b)
+}
+
+func (c *Cassette) play(req CassetteRequest) (*Interaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fallback := -1
+	for i, interaction := range c.interactions {
+		if c.played[i] || interaction.Request.Method != req.Method || pathOf(interaction.Request.URL) != pathOf(req.URL) {
+			continue
+		}
+		if interaction.Request.URL == req.URL && interaction.Request.Body == req.Body {
+			c.played[i] = true
+			return &c.interactions[i], nil
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	if fallback < 0 {
+		return nil, fmt.Errorf("cassette %s: no interaction left for %s %s", c.path, req.Method, req.URL)
+	}
+	c.played[fallback] = true
+	return &c.interactions[fallback], nil
+}
+
+func pathOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Path
+}
+
+func (c *Cassette) scrubRequest(req *http.Request, body []byte) CassetteRequest {
+	u := *req.URL
+	u.RawQuery = scrubValues(u.Query()).Encode()
+
+	scrubbedBody := string(body)
+	if len(body) > 0 {
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+			if values, err := url.ParseQuery(string(body)); err == nil {
+				scrubbedBody = scrubValues(values).Encode()
+			}
+		} else {
+			fields := map[string]interface{}{}
+			if err := json.Unmarshal(body, &fields); err == nil {
+				for _, param := range volatileParams {
+					if _, ok := fields[param]; ok {
+						fields[param] = scrubbed
+					}
+				}
+				if b, err := json.Marshal(fields); err == nil {
+					scrubbedBody = string(b)
+				}
+			}
+		}
+	}
+	return CassetteRequest{
+		Method: req.Method,
+		URL:    c.scrub(u.String()),
+		Body:   c.scrub(scrubbedBody),
+	}
+}
+
+func scrubValues(values url.Values) url.Values {
+	for _, param := range volatileParams {
+		if _, ok := values[param]; ok {
+			values.Set(param, scrubbed)
+		}
+	}
+	return values
+}
+
+func (c *Cassette) scrub(s string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, secret := range c.secrets {
+		s = strings.ReplaceAll(s, secret, scrubbed)
+	}
+	return s
+}
+
+func (r CassetteResponse) httpResponse(req *http.Request) *http.Response {
+	header := http.Header{}
+	for name, values := range r.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+var (
+	cassettesMu sync.Mutex
+	cassettes   = map[*testing.T]*Cassette{}
+)
+
+// UseCassette : the cassette of the test, ./testdata/cassettes/<test name>.json, shared by the clients of the test.
+// It records when BYBIT_TEST_UPDATED is true, saving when the test ends, and replays otherwise,
+// failing the test when the file does not exist rather than reaching testnet.
+func UseCassette(t *testing.T) *Cassette {
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+	if cassette, ok := cassettes[t]; ok {
+		return cassette
+	}
+
+	path := filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	mode := CassetteReplay
+	if updated() {
+		mode = CassetteRecord
+	} else if _, err := os.Stat(path); err != nil {
+		t.Fatalf("no cassette to replay, record %s with BYBIT_TEST_UPDATED=true: %v", path, err)
+	}
+	cassette, err := NewCassette(path, mode)
+	require.NoError(t, err)
+
+	cassettes[t] = cassette
+	t.Cleanup(func() {
+		cassettesMu.Lock()
+		delete(cassettes, t)
+		cassettesMu.Unlock()
+		require.NoError(t, cassette.Save())
+	})
+	return cassette
+}
+
+// NewClient : a testnet client going through the cassette of the test
+func NewClient(t *testing.T) *bybit.Client {
+	return bybit.NewTestClient().WithHTTPClient(UseCassette(t).HTTPClient())
+}
+
+// NewClientWithAuth : NewClient authenticated from BYBIT_TEST_KEY and BYBIT_TEST_SECRET,
+// which replaying does not need since signatures are not kept
+func NewClientWithAuth(t *testing.T) *bybit.Client {
+	cassette := UseCassette(t)
+	client := bybit.NewTestClient()
+	if cassette.Mode() == CassetteReplay {
+		return client.WithHTTPClient(cassette.HTTPClient()).WithAuth(scrubbed, scrubbed)
+	}
+	client = client.WithAuthFromEnv()
+	cassette.WithSecrets(os.Getenv("BYBIT_TEST_KEY"), os.Getenv("BYBIT_TEST_SECRET"))
+	return client.WithHTTPClient(cassette.HTTPClient())
+}
+
+func updated() bool {
+	updatedString, ok := os.LookupEnv("BYBIT_TEST_UPDATED")
+	return ok && updatedString == "true"
+}
@@ -0,0 +1,64 @@
+package testhelper
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oneart-dev/bybit"
+	fake "github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette(t *testing.T) {
+	server, teardown := fake.NewFakeServer()
+	server.WithGoldenResponses()
+	server.Handle(http.MethodGet, "/v2/private/wallet/balance").RespondJSON(http.StatusOK, map[string]interface{}{
+		"ret_code": 0,
+		"ret_msg":  "OK",
+		"result":   map[string]interface{}{"BTC": map[string]interface{}{"equity": 1.5}},
+	})
+	path := filepath.Join(t.TempDir(), "cassettes", "TestCassette.json")
+
+	call := func(client *bybit.Client) (string, float64) {
+		apiKey, err := client.V5().User().GetAPIKey()
+		require.NoError(t, err)
+		balance, err := client.Future().InversePerpetual().Balance(bybit.CoinBTC)
+		require.NoError(t, err)
+		return apiKey.Result.ID, balance.Result.Balance[bybit.CoinBTC].Equity
+	}
+
+	recorder, err := NewCassette(path, CassetteRecord)
+	require.NoError(t, err)
+	recorder.WithSecrets(fake.FakeKey, fake.FakeSecret)
+	id, equity := call(bybit.NewTestClient().WithBaseURL(server.URL).WithHTTPClient(recorder.HTTPClient()).WithAuth(fake.FakeKey, fake.FakeSecret))
+	require.NoError(t, recorder.Save())
+	teardown()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), fake.FakeKey)
+	assert.NotContains(t, string(b), fake.FakeSecret)
+	interactions := recorder.Interactions()
+	require.Len(t, interactions, 2)
+	signed, err := url.Parse(interactions[1].Request.URL)
+	require.NoError(t, err)
+	assert.Equal(t, scrubbed, signed.Query().Get("sign"))
+	assert.Equal(t, scrubbed, signed.Query().Get("api_key"))
+
+	player, err := NewCassette(path, CassetteReplay)
+	require.NoError(t, err)
+	client := bybit.NewTestClient().WithBaseURL(server.URL).WithHTTPClient(player.HTTPClient()).WithAuth(scrubbed, scrubbed)
+	replayedID, replayedEquity := call(client)
+	assert.Equal(t, id, replayedID)
+	assert.Equal(t, equity, replayedEquity)
+
+	_, err = client.V5().User().GetAPIKey()
+	assert.Error(t, err, "every interaction was played")
+
+	_, err = NewCassette(filepath.Join(t.TempDir(), "missing.json"), CassetteReplay)
+	assert.Error(t, err)
+}
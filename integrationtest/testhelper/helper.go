@@ -2,6 +2,7 @@ package testhelper
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -16,13 +17,13 @@ func Compare(
 	goldenFilename string,
 	got []byte,
 ) {
-	switch _, err := os.Stat(goldenFilename); err {
-	case nil:
+	switch _, err := os.Stat(goldenFilename); {
+	case err == nil:
 		want, err := os.ReadFile(goldenFilename)
 		require.NoError(t, err)
 
 		assert.JSONEq(t, string(want), string(got))
-	case os.ErrNotExist:
+	case errors.Is(err, os.ErrNotExist):
 		// noop
 	default:
 		t.Error(fmt.Errorf("unexpected error happened: %w", err))
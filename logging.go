@@ -0,0 +1,218 @@
+package bybit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redacted : what secrets are replaced with in logs
+const redacted = "[REDACTED]"
+
+// redactedFields : query params and JSON keys whose values never reach the logs, compared case insensitively
+var redactedFields = []string{"api_key", "apiKey", "sign", "secret", "X-BAPI-API-KEY", "X-BAPI-SIGN"}
+
+// LogBodies : which bodies structured logging writes
+type LogBodies int
+
+const (
+	// LogBodiesNone : no body
+	LogBodiesNone LogBodies = iota
+	// LogBodiesOnError : the request and response bodies of failed requests
+	LogBodiesOnError
+	// LogBodiesAll : the request and response bodies of every request
+	LogBodiesAll
+)
+
+// LogOptions : of structured logging
+type LogOptions struct {
+	// Bodies : none by default
+	Bodies LogBodies
+	// Redact : more query params and JSON keys to redact, besides api keys, signatures and secrets
+	Redact []string
+}
+
+// RequestLog : the fields logged for a request, redacted
+type RequestLog struct {
+	Method     string
+	Path       string
+	Query      string
+	StatusCode int
+	RetCode    int
+	RetMsg     string
+	Latency    time.Duration
+	Err        error
+	// RequestBody, ResponseBody : empty unless the LogBodies option asks for them
+	RequestBody  string
+	ResponseBody string
+}
+
+// keyvals : name and value pairs in a stable order, empty ones left out
+func (r RequestLog) keyvals() []interface{} {
+	keyvals := []interface{}{"method", r.Method, "path", r.Path}
+	if r.Query != "" {
+		keyvals = append(keyvals, "query", r.Query)
+	}
+	keyvals = append(keyvals, "status", r.StatusCode, "retCode", r.RetCode)
+	if r.RetMsg != "" {
+		keyvals = append(keyvals, "retMsg", r.RetMsg)
+	}
+	keyvals = append(keyvals, "latency", r.Latency)
+	if r.Err != nil {
+		keyvals = append(keyvals, "error", r.Err.Error())
+	}
+	if r.RequestBody != "" {
+		keyvals = append(keyvals, "requestBody", r.RequestBody)
+	}
+	if r.ResponseBody != "" {
+		keyvals = append(keyvals, "responseBody", r.ResponseBody)
+	}
+	return keyvals
+}
+
+// String : key=value pairs, values quoted when needed
+func (r RequestLog) String() string {
+	keyvals := r.keyvals()
+	pairs := make([]string, 0, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		value := fmt.Sprint(keyvals[i+1])
+		if value == "" || strings.ContainsAny(value, " \"=\n") {
+			value = strconv.Quote(value)
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", keyvals[i], value))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// WithStructuredLogger : a line of key=value fields per request through logger, at Debugf, or Errorf
+// when the request fails, with api keys, signatures and secrets redacted
+func (c *Client) WithStructuredLogger(logger Logger, options LogOptions) *Client {
+	return c.Clone(WithStructuredLoggerOption(logger, options))
+}
+
+func structuredLogger(logger Logger) func(context.Context, RequestLog) {
+	return func(_ context.Context, record RequestLog) {
+		if record.Err != nil {
+			logger.Errorf("bybit request %s", record)
+			return
+		}
+		logger.Debugf("bybit request %s", record)
+	}
+}
+
+// logRequest : to the structured logger, if any
+func (c *Client) logRequest(call *Call, err error) {
+	if c.requestLog == nil {
+		return
+	}
+	record := RequestLog{
+		Method:     call.Request.Method,
+		Path:       call.Path,
+		Query:      c.redactQuery(call.Request.URL.Query()),
+		StatusCode: call.StatusCode,
+		RetCode:    call.RetCode,
+		RetMsg:     call.RetMsg,
+		Latency:    call.Latency,
+		Err:        err,
+	}
+	if c.logOptions.Bodies == LogBodiesAll || (c.logOptions.Bodies == LogBodiesOnError && err != nil) {
+		if call.Request.GetBody != nil {
+			if body, err := call.Request.GetBody(); err == nil {
+				b, _ := io.ReadAll(body)
+				_ = body.Close()
+				record.RequestBody = c.redactBody(b, strings.HasPrefix(call.Request.Header.Get("Content-Type"), "application/x-www-form-urlencoded"))
+			}
+		}
+		record.ResponseBody = c.redactBody(call.body, false)
+	}
+	c.requestLog(call.Request.Context(), record)
+}
+
+func (c *Client) isRedacted(name string) bool {
+	for _, field := range redactedFields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	for _, field := range c.logOptions.Redact {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL : of the debug logs
+func (c *Client) redactURL(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.RawQuery = c.redactQuery(u.Query())
+	return redactedURL.String()
+}
+
+func (c *Client) redactQuery(query url.Values) string {
+	for name, values := range query {
+		for i, value := range values {
+			if c.isRedacted(name) {
+				values[i] = redacted
+				continue
+			}
+			values[i] = c.redactSecrets(value)
+		}
+	}
+	return strings.ReplaceAll(query.Encode(), url.QueryEscape(redacted), redacted)
+}
+
+// redactBody : JSON, or form encoded
+func (c *Client) redactBody(body []byte, form bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if form {
+		if query, err := url.ParseQuery(string(body)); err == nil {
+			return c.redactQuery(query)
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err == nil {
+		if b, err := json.Marshal(c.redactJSON(v)); err == nil {
+			return c.redactSecrets(string(b))
+		}
+	}
+	return c.redactSecrets(string(body))
+}
+
+func (c *Client) redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if c.isRedacted(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = c.redactJSON(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = c.redactJSON(value)
+		}
+	}
+	return v
+}
+
+// redactSecrets : the api key and secret of the client wherever they appear
+func (c *Client) redactSecrets(s string) string {
+	for _, secret := range []string{c.key, c.secret} {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
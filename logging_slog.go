@@ -0,0 +1,48 @@
+//go:build go1.21
+
+package bybit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithSlog : a record per request through handler, at Debug, or Error when the request fails,
+// with api keys, signatures and secrets redacted
+func (c *Client) WithSlog(handler slog.Handler, options LogOptions) *Client {
+	return c.Clone(WithSlogOption(handler, options))
+}
+
+// WithSlogOption : see Client.WithSlog
+func WithSlogOption(handler slog.Handler, options LogOptions) Option {
+	logger := slog.New(handler)
+	return func(c *Client) {
+		c.logOptions = options
+		c.requestLog = func(ctx context.Context, record RequestLog) {
+			level := slog.LevelDebug
+			if record.Err != nil {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "bybit request", record.attrs()...)
+		}
+	}
+}
+
+// attrs : the fields as slog attributes
+func (r RequestLog) attrs() []slog.Attr {
+	keyvals := r.keyvals()
+	attrs := make([]slog.Attr, 0, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := keyvals[i].(string)
+		switch value := keyvals[i+1].(type) {
+		case int:
+			attrs = append(attrs, slog.Int(key, value))
+		case time.Duration:
+			attrs = append(attrs, slog.Duration(key, value))
+		default:
+			attrs = append(attrs, slog.Any(key, value))
+		}
+	}
+	return attrs
+}
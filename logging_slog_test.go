@@ -0,0 +1,47 @@
+//go:build go1.21
+
+package bybit
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithSlog(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	client := NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).
+		WithSlog(handler, LogOptions{Bodies: LogBodiesAll, Redact: []string{"orderLinkId"}})
+	linkID := "link-1"
+
+	_, err := client.V5().Order().CreateOrder(V5CreateOrderParam{
+		Category: CategoryV5Linear, Symbol: SymbolV5BTCUSDT, Side: SideBuy, OrderType: OrderTypeMarket, Qty: "0.1", OrderLinkID: &linkID,
+	})
+	require.NoError(t, err)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "DEBUG", record["level"])
+	assert.Equal(t, "bybit request", record["msg"])
+	assert.Equal(t, http.MethodPost, record["method"])
+	assert.Equal(t, "/v5/order/create", record["path"])
+	assert.Equal(t, float64(http.StatusOK), record["status"])
+	assert.Equal(t, float64(0), record["retCode"])
+	assert.Contains(t, record, "latency")
+	assert.Contains(t, record["requestBody"], `"orderLinkId":"[REDACTED]"`)
+	assert.Contains(t, record["requestBody"], `"symbol":"BTCUSDT"`)
+	assert.Contains(t, record["responseBody"], `"orderLinkId":"[REDACTED]"`)
+	assert.NotContains(t, buf.String(), testhelper.FakeKey)
+	assert.NotContains(t, buf.String(), linkID)
+}
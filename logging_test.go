@@ -0,0 +1,81 @@
+package bybit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogger struct {
+	mu     sync.Mutex
+	debugs []string
+	errors []string
+}
+
+func (l *testLogger) Debugf(template string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.debugs = append(l.debugs, fmt.Sprintf(template, args...))
+}
+
+func (l *testLogger) Infof(template string, args ...interface{}) {}
+
+func (l *testLogger) Errorf(template string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprintf(template, args...))
+}
+
+func TestClientWithStructuredLogger(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+	server.Handle(http.MethodGet, "/v5/order/realtime").RespondRetCode(10001, "params error")
+
+	logger := &testLogger{}
+	client := NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).
+		WithStructuredLogger(logger, LogOptions{Bodies: LogBodiesOnError})
+
+	_, err := client.V5().Order().CreateOrder(V5CreateOrderParam{
+		Category: CategoryV5Linear, Symbol: SymbolV5BTCUSDT, Side: SideBuy, OrderType: OrderTypeMarket, Qty: "0.1",
+	})
+	require.NoError(t, err)
+	_, err = client.V5().Order().GetOpenOrders(V5GetOpenOrdersParam{Category: CategoryV5Linear})
+	require.Error(t, err)
+
+	require.Len(t, logger.debugs, 1)
+	assert.Regexp(t, `^bybit request method=POST path=/v5/order/create status=200 retCode=0 retMsg=OK latency=\S+$`, logger.debugs[0])
+	require.Len(t, logger.errors, 1)
+	assert.Regexp(t, `^bybit request method=GET path=/v5/order/realtime query="category=linear" status=200 retCode=10001 retMsg="params error" latency=\S+ error=.+ responseBody=.+$`, logger.errors[0])
+}
+
+func TestClientRedact(t *testing.T) {
+	client := NewTestClient().WithAuth("my-key", "my-secret")
+	client.logOptions = LogOptions{Redact: []string{"orderLinkId"}}
+
+	t.Run("query", func(t *testing.T) {
+		query := url.Values{"api_key": {"my-key"}, "sign": {"abc"}, "symbol": {"BTCUSDT"}, "note": {"my-secret"}}
+		assert.Equal(t, "api_key=[REDACTED]&note=[REDACTED]&sign=[REDACTED]&symbol=BTCUSDT", client.redactQuery(query))
+	})
+	t.Run("json body", func(t *testing.T) {
+		body := []byte(`{"qty":"0.1","orders":[{"orderLinkId":"link-1","price":1.50}],"secret":"x"}`)
+		assert.Equal(t, `{"orders":[{"orderLinkId":"[REDACTED]","price":1.50}],"qty":"0.1","secret":"[REDACTED]"}`, client.redactBody(body, false))
+	})
+	t.Run("form body", func(t *testing.T) {
+		assert.Equal(t, "api_key=[REDACTED]&qty=1", client.redactBody([]byte("api_key=my-key&qty=1"), true))
+	})
+	t.Run("other body", func(t *testing.T) {
+		assert.Equal(t, "key [REDACTED] in text", client.redactBody([]byte("key my-key in text"), false))
+	})
+	t.Run("url", func(t *testing.T) {
+		u, err := url.Parse("https://api.bybit.com/v2/private/order?api_key=my-key&sign=abc&symbol=BTCUSD")
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.bybit.com/v2/private/order?api_key=[REDACTED]&sign=[REDACTED]&symbol=BTCUSD", client.redactURL(u))
+	})
+}
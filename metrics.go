@@ -0,0 +1,63 @@
+package bybit
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// MetricsSink : receives the measures of the REST and websocket traffic, see Client.WithMetrics
+// and WebSocketClient.WithMetrics. It is called from the goroutines doing the traffic
+// and must be safe for concurrent use, the prometheus package has one.
+type MetricsSink interface {
+	// ObserveRequest : a REST request to path got statusCode and retCode, both 0 when err happened before
+	ObserveRequest(path string, statusCode, retCode int, latency time.Duration, err error)
+	// SetRateLimit : the X-Bapi-Limit and X-Bapi-Limit-Status headers of a response of path
+	SetRateLimit(path string, limit, remaining int)
+	// IncWebsocketMessage : a data frame of topic was read on a connection to path
+	IncWebsocketMessage(path, topic string)
+	// ObserveWebsocketHandler : the time from a data frame of topic being read until the connection
+	// is read again, which is how long the handler took in the Run loop
+	ObserveWebsocketHandler(path, topic string, latency time.Duration)
+	// IncWebsocketReconnect : a connection of a pool to path was lost, its topics are subscribed again elsewhere
+	IncWebsocketReconnect(path string)
+}
+
+// WithMetrics : every request is reported to sink
+func (c *Client) WithMetrics(sink MetricsSink) *Client {
+	return c.Clone(WithMetricsOption(sink))
+}
+
+// observe : reports the call to the metrics sink, if any
+func (c *Client) observe(call *Call, err error) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.ObserveRequest(call.Path, call.StatusCode, call.RetCode, call.Latency, err)
+
+	limit, err := strconv.Atoi(call.Header.Get("X-Bapi-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(call.Header.Get("X-Bapi-Limit-Status"))
+	if err != nil {
+		return
+	}
+	c.metrics.SetRateLimit(call.Path, limit, remaining)
+}
+
+// WithMetrics : connections dialed afterwards report their messages, handler latencies and reconnects to sink
+func (c *WebSocketClient) WithMetrics(sink MetricsSink) *WebSocketClient {
+	c.metrics = sink
+
+	return c
+}
+
+// metricsHook : counts the data frames and times their handlers
+func (c *WebSocketClient) metricsHook(ctx context.Context, path, topic string) (context.Context, func(error)) {
+	c.metrics.IncWebsocketMessage(path, topic)
+	start := time.Now()
+	return ctx, func(error) {
+		c.metrics.ObserveWebsocketHandler(path, topic, time.Since(start))
+	}
+}
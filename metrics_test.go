@@ -0,0 +1,96 @@
+package bybit
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetricsSink struct {
+	mu         sync.Mutex
+	requests   []string
+	retCodes   []int
+	remaining  map[string]int
+	messages   map[string]int
+	handlers   map[string]int
+	reconnects int
+}
+
+func newTestMetricsSink() *testMetricsSink {
+	return &testMetricsSink{remaining: map[string]int{}, messages: map[string]int{}, handlers: map[string]int{}}
+}
+
+func (s *testMetricsSink) ObserveRequest(path string, statusCode, retCode int, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, path)
+	s.retCodes = append(s.retCodes, retCode)
+}
+
+func (s *testMetricsSink) SetRateLimit(path string, limit, remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining[path] = remaining
+}
+
+func (s *testMetricsSink) IncWebsocketMessage(path, topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages[topic]++
+}
+
+func (s *testMetricsSink) ObserveWebsocketHandler(path, topic string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[topic]++
+}
+
+func (s *testMetricsSink) IncWebsocketReconnect(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reconnects++
+}
+
+func TestClientWithMetrics(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.Handle(http.MethodGet, "/v5/market/tickers").RespondFile("v5/market/tickers.json").RespondRateLimit()
+
+	sink := newTestMetricsSink()
+	market := NewTestClient().WithBaseURL(server.URL).WithMetrics(sink).V5().Market()
+	for i := 0; i < 2; i++ {
+		_, _ = market.GetTickers(V5GetTickersParam{Category: CategoryV5Linear})
+	}
+
+	assert.Equal(t, []string{"/v5/market/tickers", "/v5/market/tickers"}, sink.requests)
+	assert.Equal(t, []int{0, 10006}, sink.retCodes)
+	assert.Equal(t, map[string]int{"/v5/market/tickers": 0}, sink.remaining)
+}
+
+func TestWebsocketClientWithMetrics(t *testing.T) {
+	server, teardown := testhelper.NewFakeWebsocketServer()
+	defer teardown()
+
+	sink := newTestMetricsSink()
+	svc, err := NewTestWebsocketClient().WithBaseURL(server.URL).WithMetrics(sink).V5().Public(CategoryV5Linear)
+	require.NoError(t, err)
+	_, err = svc.SubscribeTicker(V5WebsocketPublicTickerParamKey{Symbol: SymbolV5BTCUSDT}, func(V5WebsocketPublicTickerResponse) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.Run(), "subscribe acknowledged")
+	require.NoError(t, server.WaitSubscribed("tickers.BTCUSDT", time.Second))
+
+	for i := 0; i < 2; i++ {
+		server.PublishJSON("tickers.BTCUSDT", map[string]interface{}{"topic": "tickers.BTCUSDT", "type": "snapshot", "data": map[string]interface{}{"symbol": "BTCUSDT"}})
+		require.NoError(t, svc.Run())
+	}
+
+	assert.Equal(t, map[string]int{"tickers.BTCUSDT": 2}, sink.messages)
+	assert.Equal(t, map[string]int{"tickers.BTCUSDT": 2}, sink.handlers, "timed when the handler returns")
+}
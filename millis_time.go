@@ -0,0 +1,71 @@
+package bybit
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// MillisTime : a timestamp the exchange sends as epoch milliseconds, either as a string ("1672217748287") or as a number.
+// "", "0" and null read as the zero time. It is written back as a string of milliseconds, the zero time as "0"
+// like the exchange sends it, unless the field is tagged `json:",omitzero"`, which leaves it out.
+type MillisTime struct {
+	time.Time
+}
+
+// NewMillisTime :
+func NewMillisTime(ms int64) MillisTime {
+	if ms == 0 {
+		return MillisTime{}
+	}
+	return MillisTime{Time: time.UnixMilli(ms)}
+}
+
+// ParseMillisTime : "" and "0" are the zero time
+func ParseMillisTime(s string) (MillisTime, error) {
+	if s == "" {
+		return MillisTime{}, nil
+	}
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return MillisTime{}, err
+	}
+	return NewMillisTime(ms), nil
+}
+
+// Millis : 0 for the zero time
+func (t MillisTime) Millis() int64 {
+	if t.Time.IsZero() {
+		return 0
+	}
+	return t.Time.UnixMilli()
+}
+
+// UnmarshalJSON :
+func (t *MillisTime) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*t = MillisTime{}
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	parsed, err := ParseMillisTime(string(data))
+	if err != nil {
+		return errors.New("millis time must be epoch milliseconds: " + string(data))
+	}
+	*t = parsed
+	return nil
+}
+
+// IsZero : also for the times of 0 milliseconds, which omitzero relies on
+func (t MillisTime) IsZero() bool {
+	return t.Millis() == 0
+}
+
+// MarshalJSON :
+func (t MillisTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + strconv.FormatInt(t.Millis(), 10) + `"`), nil
+}
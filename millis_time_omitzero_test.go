@@ -0,0 +1,22 @@
+//go:build go1.24
+
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMillisTimeOmitZero(t *testing.T) {
+	out, err := json.Marshal(struct {
+		Zero  MillisTime `json:"zero,omitzero"`
+		Epoch MillisTime `json:"epoch,omitzero"`
+		Set   MillisTime `json:"set,omitzero"`
+	}{Epoch: MillisTime{Time: time.UnixMilli(0)}, Set: NewMillisTime(1672217748287)})
+	require.NoError(t, err)
+	assert.Equal(t, `{"set":"1672217748287"}`, string(out))
+}
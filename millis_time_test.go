@@ -0,0 +1,54 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMillisTime(t *testing.T) {
+	t.Run("string and number", func(t *testing.T) {
+		var got struct {
+			String MillisTime `json:"string"`
+			Number MillisTime `json:"number"`
+			Empty  MillisTime `json:"empty"`
+			Zero   MillisTime `json:"zero"`
+			Null   MillisTime `json:"null"`
+		}
+		in := `{"string":"1672217748287","number":1672217748287,"empty":"","zero":"0","null":null}`
+		require.NoError(t, json.Unmarshal([]byte(in), &got))
+
+		want := time.Date(2022, 12, 28, 8, 55, 48, 287000000, time.UTC)
+		assert.True(t, want.Equal(got.String.Time))
+		assert.True(t, want.Equal(got.Number.Time))
+		assert.Equal(t, int64(1672217748287), got.String.Millis())
+		assert.True(t, got.Empty.IsZero())
+		assert.True(t, got.Zero.IsZero())
+		assert.True(t, got.Null.IsZero())
+
+		out, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.Equal(t, `{"string":"1672217748287","number":"1672217748287","empty":"0","zero":"0","null":"0"}`, string(out))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var got MillisTime
+		assert.Error(t, json.Unmarshal([]byte(`"2022-12-28T08:55:48Z"`), &got))
+	})
+	t.Run("params", func(t *testing.T) {
+		start := time.UnixMilli(1672217748287)
+		values, err := query.Values(V5GetKlineParam{
+			Category: CategoryV5Linear,
+			Symbol:   SymbolV5BTCUSDT,
+			Interval: Interval1,
+			Start:    &start,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1672217748287", values.Get("start"))
+		assert.False(t, values.Has("end"))
+	})
+}
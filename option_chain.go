@@ -0,0 +1,172 @@
+package bybit
+
+import (
+	"sort"
+	"time"
+)
+
+// V5OptionChain : the options of one base coin settled in one coin, by expiry and strike
+type V5OptionChain struct {
+	BaseCoin Coin
+	// SettleCoin : BTC-27DEC24-100000-C and BTC-27DEC24-100000-C-USDT are in different chains
+	SettleCoin Coin
+	// Expiries : the nearest first
+	Expiries []V5OptionExpiry
+}
+
+// V5OptionExpiry :
+type V5OptionExpiry struct {
+	Expiry time.Time
+	// Strikes : the lowest first
+	Strikes []V5OptionStrike
+}
+
+// V5OptionStrike : either side is nil when it is not listed
+type V5OptionStrike struct {
+	Strike Decimal
+	Call   *V5OptionContract
+	Put    *V5OptionContract
+}
+
+// V5OptionContract : Instrument or Ticker is nil when the builder was not given it
+type V5OptionContract struct {
+	V5OptionSymbol
+	Instrument *V5Instrument
+	// Ticker : bid, ask, IV and greeks
+	Ticker *V5GetTickersOptionDecimal
+}
+
+// BuildV5OptionChains : groups the instruments and tickers, either can be nil.
+// Chains are sorted by base coin then settle coin, symbols that do not parse as options are skipped.
+func BuildV5OptionChains(instruments *V5GetInstrumentsInfoOptionResult, tickers *V5GetTickersOptionResult) ([]V5OptionChain, error) {
+	contracts := map[SymbolV5]*V5OptionContract{}
+	contract := func(symbol SymbolV5) *V5OptionContract {
+		if c, ok := contracts[symbol]; ok {
+			return c
+		}
+		parsed, err := ParseOptionSymbol(symbol)
+		if err != nil {
+			return nil
+		}
+		c := &V5OptionContract{V5OptionSymbol: parsed}
+		contracts[symbol] = c
+		return c
+	}
+
+	if instruments != nil {
+		page := map[SymbolV5]V5Instrument{}
+		if _, err := collectInstruments(CategoryV5Option, V5GetInstrumentsInfoResult{Option: instruments}, page); err != nil {
+			return nil, err
+		}
+		for symbol, instrument := range page {
+			instrument := instrument
+			if c := contract(symbol); c != nil {
+				c.Instrument = &instrument
+			}
+		}
+	}
+	if tickers != nil {
+		list, err := tickers.Decimal()
+		if err != nil {
+			return nil, err
+		}
+		for i := range list {
+			if c := contract(list[i].Symbol); c != nil {
+				c.Ticker = &list[i]
+			}
+		}
+	}
+
+	type chainKey struct {
+		baseCoin   Coin
+		settleCoin Coin
+	}
+	type strikeKey struct {
+		chainKey
+		expiry int64
+		strike string
+	}
+	strikes := map[strikeKey]*V5OptionStrike{}
+	for _, c := range contracts {
+		key := strikeKey{
+			chainKey: chainKey{baseCoin: c.BaseCoin, settleCoin: c.SettleCoin},
+			expiry:   c.Expiry.Unix(),
+			strike:   c.Strike.Decimal.String(),
+		}
+		s, ok := strikes[key]
+		if !ok {
+			s = &V5OptionStrike{Strike: c.Strike}
+			strikes[key] = s
+		}
+		if c.OptionsType == OptionsTypePut {
+			s.Put = c
+		} else {
+			s.Call = c
+		}
+	}
+
+	chains := map[chainKey]map[int64]*V5OptionExpiry{}
+	for key, s := range strikes {
+		if chains[key.chainKey] == nil {
+			chains[key.chainKey] = map[int64]*V5OptionExpiry{}
+		}
+		e, ok := chains[key.chainKey][key.expiry]
+		if !ok {
+			e = &V5OptionExpiry{Expiry: time.Unix(key.expiry, 0).UTC()}
+			chains[key.chainKey][key.expiry] = e
+		}
+		e.Strikes = append(e.Strikes, *s)
+	}
+
+	result := make([]V5OptionChain, 0, len(chains))
+	for key, expiries := range chains {
+		chain := V5OptionChain{BaseCoin: key.baseCoin, SettleCoin: key.settleCoin}
+		for _, e := range expiries {
+			sort.Slice(e.Strikes, func(i, j int) bool { return e.Strikes[i].Strike.LessThan(e.Strikes[j].Strike.Decimal) })
+			chain.Expiries = append(chain.Expiries, *e)
+		}
+		sort.Slice(chain.Expiries, func(i, j int) bool { return chain.Expiries[i].Expiry.Before(chain.Expiries[j].Expiry) })
+		result = append(result, chain)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BaseCoin != result[j].BaseCoin {
+			return result[i].BaseCoin < result[j].BaseCoin
+		}
+		return result[i].SettleCoin < result[j].SettleCoin
+	})
+	return result, nil
+}
+
+// Expiry : the expiry at t, compared as an instant
+func (c V5OptionChain) Expiry(t time.Time) (V5OptionExpiry, bool) {
+	for _, e := range c.Expiries {
+		if e.Expiry.Equal(t) {
+			return e, true
+		}
+	}
+	return V5OptionExpiry{}, false
+}
+
+// Strike :
+func (e V5OptionExpiry) Strike(strike Decimal) (V5OptionStrike, bool) {
+	for _, s := range e.Strikes {
+		if s.Strike.Equal(strike.Decimal) {
+			return s, true
+		}
+	}
+	return V5OptionStrike{}, false
+}
+
+// Nearest : the strike closest to price, at the money when price is the underlying price; false when there is no strike
+func (e V5OptionExpiry) Nearest(price Decimal) (V5OptionStrike, bool) {
+	if len(e.Strikes) == 0 {
+		return V5OptionStrike{}, false
+	}
+	best := e.Strikes[0]
+	for _, s := range e.Strikes[1:] {
+		if s.Strike.Sub(price.Decimal).Abs().LessThan(best.Strike.Sub(price.Decimal).Abs()) {
+			best = s
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,95 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildV5OptionChains(t *testing.T) {
+	var instruments V5GetInstrumentsInfoOptionResult
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"category": "option",
+		"nextPageCursor": "",
+		"list": [
+			{"symbol": "BTC-29DEC23-40000-C", "optionsType": "Call", "status": "Trading", "baseCoin": "BTC", "quoteCoin": "USD", "settleCoin": "USDC", "launchTime": "1672905600000", "deliveryTime": "1703836800000", "deliveryFeeRate": "0.00015", "priceFilter": {"minPrice": "5", "maxPrice": "10000000", "tickSize": "5"}, "lotSizeFilter": {"maxOrderQty": "10000", "minOrderQty": "0.01", "qtyStep": "0.01"}},
+			{"symbol": "BTC-29DEC23-40000-P", "optionsType": "Put", "status": "Trading", "baseCoin": "BTC", "quoteCoin": "USD", "settleCoin": "USDC", "launchTime": "1672905600000", "deliveryTime": "1703836800000", "deliveryFeeRate": "0.00015", "priceFilter": {"minPrice": "5", "maxPrice": "10000000", "tickSize": "5"}, "lotSizeFilter": {"maxOrderQty": "10000", "minOrderQty": "0.01", "qtyStep": "0.01"}},
+			{"symbol": "BTC-29DEC23-35000-C", "optionsType": "Call", "status": "Trading", "baseCoin": "BTC", "quoteCoin": "USD", "settleCoin": "USDC", "launchTime": "1672905600000", "deliveryTime": "1703836800000", "deliveryFeeRate": "0.00015", "priceFilter": {"minPrice": "5", "maxPrice": "10000000", "tickSize": "5"}, "lotSizeFilter": {"maxOrderQty": "10000", "minOrderQty": "0.01", "qtyStep": "0.01"}},
+			{"symbol": "BTC-24NOV23-38000-P", "optionsType": "Put", "status": "Trading", "baseCoin": "BTC", "quoteCoin": "USD", "settleCoin": "USDC", "launchTime": "1672905600000", "deliveryTime": "1700812800000", "deliveryFeeRate": "0.00015", "priceFilter": {"minPrice": "5", "maxPrice": "10000000", "tickSize": "5"}, "lotSizeFilter": {"maxOrderQty": "10000", "minOrderQty": "0.01", "qtyStep": "0.01"}}
+		]
+	}`), &instruments))
+	var tickers V5GetTickersOptionResult
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"category": "option",
+		"list": [
+			{"symbol": "BTC-29DEC23-40000-C", "bid1Price": "1250", "bid1Size": "2.5", "bid1Iv": "0.4512", "ask1Price": "1300", "ask1Size": "1", "ask1Iv": "0.4688", "lastPrice": "1275", "markPrice": "1271.14", "markIv": "0.4601", "underlyingPrice": "37512.34", "indexPrice": "37498.5", "delta": "0.40112", "gamma": "0.00004", "vega": "61.2", "theta": "-21.3"},
+			{"symbol": "ETH-29DEC23-2000-C", "bid1Price": "95", "ask1Price": "100"}
+		]
+	}`), &tickers))
+
+	chains, err := BuildV5OptionChains(&instruments, &tickers)
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+	assert.Equal(t, Coin("BTC"), chains[0].BaseCoin)
+	assert.Equal(t, Coin("ETH"), chains[1].BaseCoin)
+
+	btc := chains[0]
+	require.Len(t, btc.Expiries, 2)
+	assert.Equal(t, time.Date(2023, 11, 24, 8, 0, 0, 0, time.UTC), btc.Expiries[0].Expiry)
+
+	dec, ok := btc.Expiry(time.Date(2023, 12, 29, 8, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Len(t, dec.Strikes, 2)
+	assert.Equal(t, "35000", dec.Strikes[0].Strike.String())
+	assert.Nil(t, dec.Strikes[0].Put)
+
+	strike, ok := dec.Strike(RequireDecimal("40000"))
+	require.True(t, ok)
+	require.NotNil(t, strike.Call)
+	require.NotNil(t, strike.Put)
+	require.NotNil(t, strike.Call.Instrument)
+	assert.Equal(t, "5", strike.Call.Instrument.TickSize.String())
+	require.NotNil(t, strike.Call.Ticker)
+	assert.Equal(t, "0.4601", strike.Call.Ticker.MarkIv.String())
+	assert.Equal(t, "0.40112", strike.Call.Ticker.Delta.String())
+	assert.Nil(t, strike.Put.Ticker)
+
+	atm, ok := dec.Nearest(strike.Call.Ticker.UnderlyingPrice)
+	require.True(t, ok)
+	assert.Equal(t, "40000", atm.Strike.String())
+}
+
+func TestBuildV5OptionChainsSettleCoins(t *testing.T) {
+	var tickers V5GetTickersOptionResult
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"category": "option",
+		"list": [
+			{"symbol": "BTC-27DEC24-100000-C-USDT", "bid1Price": "2010", "ask1Price": "2050"},
+			{"symbol": "BTC-27DEC24-100000-C", "bid1Price": "2000", "ask1Price": "2040"},
+			{"symbol": "BTC-27DEC24-100000-P-USDT", "bid1Price": "5010", "ask1Price": "5050"}
+		]
+	}`), &tickers))
+
+	for i := 0; i < 10; i++ {
+		chains, err := BuildV5OptionChains(nil, &tickers)
+		require.NoError(t, err)
+		require.Len(t, chains, 2)
+		assert.Equal(t, Coin("USDC"), chains[0].SettleCoin)
+		assert.Equal(t, Coin("USDT"), chains[1].SettleCoin)
+
+		usdc := chains[0].Expiries[0].Strikes[0]
+		require.NotNil(t, usdc.Call)
+		assert.Equal(t, SymbolV5("BTC-27DEC24-100000-C"), usdc.Call.Symbol)
+		assert.Equal(t, "2000", usdc.Call.Ticker.Bid1Price.String())
+		assert.Nil(t, usdc.Put)
+
+		usdt := chains[1].Expiries[0].Strikes[0]
+		require.NotNil(t, usdt.Call)
+		require.NotNil(t, usdt.Put)
+		assert.Equal(t, SymbolV5("BTC-27DEC24-100000-C-USDT"), usdt.Call.Symbol)
+		assert.Equal(t, "2010", usdt.Call.Ticker.Bid1Price.String())
+	}
+}
@@ -0,0 +1,115 @@
+package paper
+
+import (
+	"sort"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/shopspring/decimal"
+)
+
+// margins : of the positions and open orders of one settle coin
+type margins struct {
+	unrealised decimal.Decimal
+	positionIM decimal.Decimal
+	positionMM decimal.Decimal
+	orderIM    decimal.Decimal
+}
+
+func (e *Exchange) margins(coin bybit.Coin) margins {
+	var m margins
+	for key, p := range e.positions {
+		if p.size.IsZero() || e.settleCoin(key) != coin {
+			continue
+		}
+		mark := e.mark(key)
+		m.unrealised = m.unrealised.Add(p.unrealised(mark))
+		m.positionIM = m.positionIM.Add(p.initialMargin())
+		m.positionMM = m.positionMM.Add(p.maintenanceMargin(mark, e.maintenanceRate))
+	}
+	for _, o := range e.open {
+		if o.reduceOnly || e.settleCoin(o.key) != coin {
+			continue
+		}
+		price := decimal.Max(o.price, o.triggerPrice)
+		if price.IsZero() {
+			continue
+		}
+		leverage := e.defaultLeverage
+		if p, ok := e.positions[o.key]; ok {
+			leverage = p.leverage
+		}
+		m.orderIM = m.orderIM.Add(o.qty.Sub(o.cumQty).Mul(price).Div(leverage))
+	}
+	return m
+}
+
+// available : for new orders, the equity less the margin already in use
+func (e *Exchange) available(coin bybit.Coin) decimal.Decimal {
+	m := e.margins(coin)
+	return e.wallet(coin).balance.Add(m.unrealised).Sub(m.positionIM).Sub(m.orderIM)
+}
+
+// GetWalletBalance : every coin is valued one to one in USD, the settle coins being stable coins
+func (e *Exchange) GetWalletBalance(at bybit.AccountType, coins []bybit.Coin) (*bybit.V5WalletBalanceResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(coins) == 0 {
+		for coin, w := range e.wallets {
+			if !w.balance.IsZero() {
+				coins = append(coins, coin)
+			}
+		}
+		sort.Slice(coins, func(i, j int) bool { return coins[i] < coins[j] })
+	}
+
+	var equity, walletBalance, unrealised, positionIM, positionMM, orderIM decimal.Decimal
+	list := bybit.V5WalletBalanceList{AccountType: string(at)}
+	for _, coin := range coins {
+		w := e.wallet(coin)
+		m := e.margins(coin)
+		coinEquity := w.balance.Add(m.unrealised)
+		withdrawable := decimal.Max(decimal.Min(w.balance, coinEquity.Sub(m.positionIM).Sub(m.orderIM)), decimal.Zero)
+		list.Coin = append(list.Coin, bybit.V5WalletBalanceCoin{
+			AvailableToBorrow:   "",
+			AccruedInterest:     "0",
+			AvailableToWithdraw: withdrawable.String(),
+			TotalOrderIM:        m.orderIM.String(),
+			Equity:              coinEquity.String(),
+			TotalPositionMM:     m.positionMM.String(),
+			UsdValue:            coinEquity.String(),
+			UnrealisedPnl:       m.unrealised.String(),
+			BorrowAmount:        "0",
+			TotalPositionIM:     m.positionIM.String(),
+			WalletBalance:       w.balance.String(),
+			CumRealisedPnl:      w.cumRealised.String(),
+			Coin:                coin,
+		})
+		equity = equity.Add(coinEquity)
+		walletBalance = walletBalance.Add(w.balance)
+		unrealised = unrealised.Add(m.unrealised)
+		positionIM = positionIM.Add(m.positionIM)
+		positionMM = positionMM.Add(m.positionMM)
+		orderIM = orderIM.Add(m.orderIM)
+	}
+
+	initialMargin := positionIM.Add(orderIM)
+	list.TotalEquity = equity.String()
+	list.TotalMarginBalance = equity.String()
+	list.TotalWalletBalance = walletBalance.String()
+	list.TotalPerpUPL = unrealised.String()
+	list.TotalInitialMargin = initialMargin.String()
+	list.TotalMaintenanceMargin = positionMM.String()
+	list.TotalAvailableBalance = equity.Sub(initialMargin).String()
+	list.AccountIMRate = "0"
+	list.AccountMMRate = "0"
+	if equity.IsPositive() {
+		list.AccountIMRate = initialMargin.Div(equity).Round(4).String()
+		list.AccountMMRate = positionMM.Div(equity).Round(4).String()
+	}
+
+	return &bybit.V5WalletBalanceResponse{
+		CommonV5Response: e.response(),
+		Result:           bybit.V5WalletBalanceResult{List: []bybit.V5WalletBalanceList{list}},
+	}, nil
+}
@@ -0,0 +1,54 @@
+package paper
+
+import (
+	"context"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// The Context methods of the services, ctx is not used since nothing leaves the process.
+
+// CreateOrderContext :
+func (e *Exchange) CreateOrderContext(_ context.Context, param bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error) {
+	return e.CreateOrder(param)
+}
+
+// CancelOrderContext :
+func (e *Exchange) CancelOrderContext(_ context.Context, param bybit.V5CancelOrderParam) (*bybit.V5CancelOrderResponse, error) {
+	return e.CancelOrder(param)
+}
+
+// GetOpenOrdersContext :
+func (e *Exchange) GetOpenOrdersContext(_ context.Context, param bybit.V5GetOpenOrdersParam) (*bybit.V5GetOpenOrdersResponse, error) {
+	return e.GetOpenOrders(param)
+}
+
+// GetOrderListContext :
+func (e *Exchange) GetOrderListContext(_ context.Context, param bybit.V5GetOrderListParam) (*bybit.V5GetOrderListResponse, error) {
+	return e.GetOrderList(param)
+}
+
+// GetExecutionListContext :
+func (e *Exchange) GetExecutionListContext(_ context.Context, param bybit.V5GetExecutionListParam) (*bybit.V5GetExecutionListResponse, error) {
+	return e.GetExecutionList(param)
+}
+
+// GetClosedPnlContext :
+func (e *Exchange) GetClosedPnlContext(_ context.Context, param bybit.V5GetClosedPnlParam) (*bybit.V5GetClosedPnlResponse, error) {
+	return e.GetClosedPnl(param)
+}
+
+// GetPositionInfoContext :
+func (e *Exchange) GetPositionInfoContext(_ context.Context, param bybit.V5GetPositionInfoParam) (*bybit.V5GetPositionInfoResponse, error) {
+	return e.GetPositionInfo(param)
+}
+
+// SetLeverageContext :
+func (e *Exchange) SetLeverageContext(_ context.Context, param bybit.V5SetLeverageParam) (*bybit.V5SetLeverageResponse, error) {
+	return e.SetLeverage(param)
+}
+
+// GetWalletBalanceContext :
+func (e *Exchange) GetWalletBalanceContext(_ context.Context, at bybit.AccountType, coins []bybit.Coin) (*bybit.V5WalletBalanceResponse, error) {
+	return e.GetWalletBalance(at, coins)
+}
@@ -0,0 +1,315 @@
+// Package paper is an in-process exchange that fills v5 orders against a price feed,
+// so that a strategy written against bybit.V5ServiceI can trade on paper.
+package paper
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/shopspring/decimal"
+)
+
+// retCodes returned like the exchange does
+const (
+	retCodeParams          = 10001
+	retCodeOrderNotExists  = 110001
+	retCodeNotEnoughMargin = 110007
+	retCodeReduceOnly      = 110017
+	retCodeLeverageNotMod  = 110043
+)
+
+func newError(code int, format string, args ...interface{}) error {
+	return &bybit.ErrorResponse{RetCode: code, RetMsg: fmt.Sprintf(format, args...)}
+}
+
+// Quote : the prices an order is matched against, zero when unknown
+type Quote struct {
+	Last bybit.Decimal
+	Bid  bybit.Decimal
+	Ask  bybit.Decimal
+	// Mark : unrealised PnL and liquidation use it, Last when unknown
+	Mark bybit.Decimal
+}
+
+type symbolKey struct {
+	category bybit.CategoryV5
+	symbol   bybit.SymbolV5
+}
+
+type wallet struct {
+	balance     decimal.Decimal
+	cumRealised decimal.Decimal
+}
+
+// Exchange : simulates the linear USDT and USDC contracts in one-way mode with isolated margin.
+// Orders fill in full against the quote, market orders at the touch and resting limit orders at their price
+// once the last price reaches it. Other categories are rejected.
+type Exchange struct {
+	makerFee        decimal.Decimal
+	takerFee        decimal.Decimal
+	maintenanceRate decimal.Decimal
+	defaultLeverage decimal.Decimal
+	instruments     *bybit.InstrumentRegistry
+	now             func() time.Time
+	onExecution     func(bybit.V5GetExecutionOrder)
+
+	mu         sync.Mutex
+	wallets    map[bybit.Coin]*wallet
+	quotes     map[symbolKey]*Quote
+	positions  map[symbolKey]*position
+	orders     []*order
+	open       []*order
+	executions []bybit.V5GetExecutionOrder
+	closedPnl  []bybit.V5GetClosedPnl
+	lastID     int64
+}
+
+var (
+	_ bybit.V5OrderServiceI    = (*Exchange)(nil)
+	_ bybit.V5PositionServiceI = (*Exchange)(nil)
+	_ bybit.V5AccountServiceI  = (*Exchange)(nil)
+)
+
+// NewExchange : with bybit's base fees, 0.02% maker and 0.055% taker, and 10x leverage
+func NewExchange() *Exchange {
+	return &Exchange{
+		makerFee:        decimal.RequireFromString("0.0002"),
+		takerFee:        decimal.RequireFromString("0.00055"),
+		maintenanceRate: decimal.RequireFromString("0.005"),
+		defaultLeverage: decimal.NewFromInt(10),
+		now:             time.Now,
+		wallets:         map[bybit.Coin]*wallet{},
+		quotes:          map[symbolKey]*Quote{},
+		positions:       map[symbolKey]*position{},
+	}
+}
+
+// WithBalance : deposit
+func (e *Exchange) WithBalance(coin bybit.Coin, amount bybit.Decimal) *Exchange {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.wallet(coin).balance = amount.Decimal
+	return e
+}
+
+// WithFees : rates, a negative maker rate is a rebate
+func (e *Exchange) WithFees(maker, taker bybit.Decimal) *Exchange {
+	e.makerFee = maker.Decimal
+	e.takerFee = taker.Decimal
+	return e
+}
+
+// WithMaintenanceMarginRate : 0.005 by default
+func (e *Exchange) WithMaintenanceMarginRate(rate bybit.Decimal) *Exchange {
+	e.maintenanceRate = rate.Decimal
+	return e
+}
+
+// WithDefaultLeverage : of symbols SetLeverage was not called for
+func (e *Exchange) WithDefaultLeverage(leverage bybit.Decimal) *Exchange {
+	e.defaultLeverage = leverage.Decimal
+	return e
+}
+
+// WithInstrumentRegistry : orders are checked against the tick and lot sizes like the client does
+func (e *Exchange) WithInstrumentRegistry(registry *bybit.InstrumentRegistry) *Exchange {
+	e.instruments = registry
+	return e
+}
+
+// WithClock : the simulated time, for replaying history
+func (e *Exchange) WithClock(now func() time.Time) *Exchange {
+	e.now = now
+	return e
+}
+
+// OnExecution : called for every fill, outside of the lock
+func (e *Exchange) OnExecution(f func(bybit.V5GetExecutionOrder)) *Exchange {
+	e.onExecution = f
+	return e
+}
+
+// V5 : base with orders, positions and the wallet served by the exchange, e.g. exchange.V5(client.V5())
+func (e *Exchange) V5(base bybit.V5ServiceI) bybit.V5ServiceI {
+	return &v5Service{V5ServiceI: base, exchange: e}
+}
+
+type v5Service struct {
+	bybit.V5ServiceI
+	exchange *Exchange
+}
+
+// Order :
+func (s *v5Service) Order() bybit.V5OrderServiceI {
+	return s.exchange
+}
+
+// Position :
+func (s *v5Service) Position() bybit.V5PositionServiceI {
+	return s.exchange
+}
+
+// Account :
+func (s *v5Service) Account() bybit.V5AccountServiceI {
+	return s.exchange
+}
+
+// UpdateQuote : zero fields keep their previous value, then open orders, take profits, stop losses and liquidations are checked
+func (e *Exchange) UpdateQuote(category bybit.CategoryV5, symbol bybit.SymbolV5, quote Quote) {
+	e.mu.Lock()
+	key := symbolKey{category: category, symbol: symbol}
+	q, ok := e.quotes[key]
+	if !ok {
+		q = &Quote{}
+		e.quotes[key] = q
+	}
+	for _, field := range []struct{ dst, src *bybit.Decimal }{
+		{&q.Last, &quote.Last}, {&q.Bid, &quote.Bid}, {&q.Ask, &quote.Ask}, {&q.Mark, &quote.Mark},
+	} {
+		if !field.src.IsZero() {
+			*field.dst = *field.src
+		}
+	}
+	var fills []bybit.V5GetExecutionOrder
+	e.match(key, &fills)
+	e.mu.Unlock()
+
+	e.emit(fills)
+}
+
+// SetPrice : a trade at price
+func (e *Exchange) SetPrice(category bybit.CategoryV5, symbol bybit.SymbolV5, price bybit.Decimal) {
+	e.UpdateQuote(category, symbol, Quote{Last: price})
+}
+
+// FeedV5Ticker : can be passed to SubscribeTickers, live or replayed
+func (e *Exchange) FeedV5Ticker(category bybit.CategoryV5) func(bybit.V5WebsocketPublicTickerResponse) error {
+	return func(response bybit.V5WebsocketPublicTickerResponse) error {
+		var quote Quote
+		for _, field := range []struct {
+			dst *bybit.Decimal
+			src string
+		}{
+			{&quote.Last, response.Data.LastPrice},
+			{&quote.Bid, response.Data.Bid1Price},
+			{&quote.Ask, response.Data.Ask1Price},
+			{&quote.Mark, response.Data.MarkPrice},
+		} {
+			d, err := bybit.NewDecimalFromString(field.src)
+			if err != nil {
+				return err
+			}
+			*field.dst = d
+		}
+		e.UpdateQuote(category, response.Data.Symbol, quote)
+		return nil
+	}
+}
+
+// FeedV5Trade : can be passed to SubscribeTrade, live or replayed
+func (e *Exchange) FeedV5Trade(category bybit.CategoryV5) func(bybit.V5WebsocketPublicTradeResponse) error {
+	return func(response bybit.V5WebsocketPublicTradeResponse) error {
+		for _, trade := range response.Data {
+			price, err := bybit.NewDecimalFromString(trade.Price)
+			if err != nil {
+				return err
+			}
+			e.SetPrice(category, trade.Symbol, price)
+		}
+		return nil
+	}
+}
+
+func (e *Exchange) emit(fills []bybit.V5GetExecutionOrder) {
+	if e.onExecution == nil {
+		return
+	}
+	for _, fill := range fills {
+		e.onExecution(fill)
+	}
+}
+
+func (e *Exchange) wallet(coin bybit.Coin) *wallet {
+	w, ok := e.wallets[coin]
+	if !ok {
+		w = &wallet{}
+		e.wallets[coin] = w
+	}
+	return w
+}
+
+// settleCoin : from the registry when there is one
+func (e *Exchange) settleCoin(key symbolKey) bybit.Coin {
+	if e.instruments != nil {
+		if instrument, err := e.instruments.Instrument(key.category, key.symbol); err == nil && instrument.SettleCoin != "" {
+			return instrument.SettleCoin
+		}
+	}
+	if strings.HasSuffix(string(key.symbol), "USDT") {
+		return bybit.CoinUSDT
+	}
+	return bybit.CoinUSDC
+}
+
+func (e *Exchange) nextID() string {
+	e.lastID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", e.lastID)
+}
+
+func (e *Exchange) mark(key symbolKey) decimal.Decimal {
+	q, ok := e.quotes[key]
+	if !ok {
+		return decimal.Zero
+	}
+	if !q.Mark.IsZero() {
+		return q.Mark.Decimal
+	}
+	return q.Last.Decimal
+}
+
+// takerPrice : where a market order of side fills
+func (e *Exchange) takerPrice(key symbolKey, side bybit.Side) (decimal.Decimal, bool) {
+	q, ok := e.quotes[key]
+	if !ok {
+		return decimal.Zero, false
+	}
+	price := q.Last.Decimal
+	if side == bybit.SideBuy && !q.Ask.IsZero() {
+		price = q.Ask.Decimal
+	}
+	if side == bybit.SideSell && !q.Bid.IsZero() {
+		price = q.Bid.Decimal
+	}
+	return price, !price.IsZero()
+}
+
+func (e *Exchange) response() bybit.CommonV5Response {
+	return bybit.CommonV5Response{RetMsg: "OK", RetExtInfo: map[string]interface{}{}, Time: int(e.now().UnixMilli())}
+}
+
+func millis(t time.Time) bybit.MillisTime {
+	return bybit.MillisTime{Time: t}
+}
+
+// page : from and to of the page of n items, the cursor is the offset
+func page(n int, limit *int, cursor *string, defaultLimit int) (int, int, string) {
+	from := 0
+	if cursor != nil {
+		fmt.Sscanf(*cursor, "%d", &from)
+	}
+	size := defaultLimit
+	if limit != nil && *limit > 0 {
+		size = *limit
+	}
+	if from > n {
+		from = n
+	}
+	to := from + size
+	if to >= n {
+		return from, n, ""
+	}
+	return from, to, fmt.Sprint(to)
+}
@@ -0,0 +1,634 @@
+package paper
+
+import (
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/shopspring/decimal"
+)
+
+type order struct {
+	key              symbolKey
+	id               string
+	linkID           string
+	side             bybit.Side
+	orderType        bybit.OrderType
+	timeInForce      bybit.TimeInForce
+	qty              decimal.Decimal
+	price            decimal.Decimal
+	triggerPrice     decimal.Decimal
+	triggerDirection bybit.TriggerDirection
+	triggerBy        bybit.TriggerBy
+	stopOrderType    string
+	takeProfit       decimal.Decimal
+	stopLoss         decimal.Decimal
+	reduceOnly       bool
+	status           bybit.OrderStatus
+	rejectReason     string
+	lastPrice        decimal.Decimal
+	cumQty           decimal.Decimal
+	cumValue         decimal.Decimal
+	cumFee           decimal.Decimal
+	created          time.Time
+	updated          time.Time
+}
+
+func (o *order) isOpen() bool {
+	return o.status == bybit.OrderStatusNew || o.status == bybit.OrderStatusUntriggered
+}
+
+// CreateOrder : market orders fill right away, limit orders that cross fill as taker and the others rest
+func (e *Exchange) CreateOrder(param bybit.V5CreateOrderParam) (*bybit.V5CreateOrderResponse, error) {
+	if e.instruments != nil {
+		if err := e.instruments.ValidateOrder(param); err != nil {
+			return nil, err
+		}
+	}
+
+	e.mu.Lock()
+	o, err := e.newOrder(param)
+	if err != nil {
+		e.mu.Unlock()
+		return nil, err
+	}
+	var fills []bybit.V5GetExecutionOrder
+	if err := e.place(o, &fills); err != nil {
+		e.mu.Unlock()
+		return nil, err
+	}
+	e.mu.Unlock()
+
+	e.emit(fills)
+	return &bybit.V5CreateOrderResponse{
+		CommonV5Response: e.response(),
+		Result:           bybit.V5CreateOrderResult{OrderID: o.id, OrderLinkID: o.linkID},
+	}, nil
+}
+
+func (e *Exchange) newOrder(param bybit.V5CreateOrderParam) (*order, error) {
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	if param.Side != bybit.SideBuy && param.Side != bybit.SideSell {
+		return nil, newError(retCodeParams, "side must be Buy or Sell")
+	}
+	if param.PositionIdx != nil && *param.PositionIdx != bybit.PositionIdxOneWay {
+		return nil, newError(retCodeParams, "only one-way mode is simulated")
+	}
+	now := e.now()
+	o := &order{
+		key:         symbolKey{category: param.Category, symbol: param.Symbol},
+		side:        param.Side,
+		orderType:   param.OrderType,
+		timeInForce: bybit.TimeInForceGoodTillCancel,
+		reduceOnly:  param.ReduceOnly != nil && *param.ReduceOnly || param.CloseOnTrigger != nil && *param.CloseOnTrigger,
+		created:     now,
+		updated:     now,
+	}
+	if param.OrderLinkID != nil {
+		for _, existing := range e.orders {
+			if existing.linkID == *param.OrderLinkID {
+				return nil, newError(retCodeParams, "orderLinkId %s is duplicated", *param.OrderLinkID)
+			}
+		}
+		o.linkID = *param.OrderLinkID
+	}
+	if param.TimeInForce != nil {
+		o.timeInForce = *param.TimeInForce
+	}
+	if param.OrderType == bybit.OrderTypeMarket {
+		o.timeInForce = bybit.TimeInForceImmediateOrCancel
+	}
+
+	var err error
+	parse := func(name string, s *string) decimal.Decimal {
+		if err != nil || s == nil || *s == "" {
+			return decimal.Zero
+		}
+		d, e := decimal.NewFromString(*s)
+		if e != nil {
+			err = newError(retCodeParams, "%s: %s", name, e)
+		}
+		return d
+	}
+	o.qty = parse("qty", &param.Qty)
+	o.price = parse("price", param.Price)
+	o.triggerPrice = parse("triggerPrice", param.TriggerPrice)
+	o.takeProfit = parse("takeProfit", param.TakeProfit)
+	o.stopLoss = parse("stopLoss", param.StopLoss)
+	if err != nil {
+		return nil, err
+	}
+	if !o.qty.IsPositive() {
+		return nil, newError(retCodeParams, "qty must be positive")
+	}
+	switch param.OrderType {
+	case bybit.OrderTypeLimit:
+		if !o.price.IsPositive() {
+			return nil, newError(retCodeParams, "price is required for a limit order")
+		}
+	case bybit.OrderTypeMarket:
+		o.price = decimal.Zero
+	default:
+		return nil, newError(retCodeParams, "orderType %q is not supported", param.OrderType)
+	}
+	if o.triggerPrice.IsPositive() {
+		if param.TriggerDirection == nil {
+			return nil, newError(retCodeParams, "triggerDirection is required with triggerPrice")
+		}
+		o.triggerDirection = *param.TriggerDirection
+		o.triggerBy = bybit.TriggerByLastPrice
+		if param.TriggerBy != nil {
+			o.triggerBy = *param.TriggerBy
+		}
+		o.stopOrderType = "Stop"
+	}
+	if o.reduceOnly {
+		if p, ok := e.positions[o.key]; !ok || p.size.IsZero() || p.side() == o.side {
+			return nil, newError(retCodeReduceOnly, "reduce-only rule not satisfied")
+		}
+	}
+	if q, ok := e.quotes[o.key]; ok {
+		o.lastPrice = q.Last.Decimal
+	}
+
+	o.id = e.nextID()
+	return o, nil
+}
+
+// place : rejects what the margin does not cover, otherwise fills, rests or cancels the order
+func (e *Exchange) place(o *order, fills *[]bybit.V5GetExecutionOrder) error {
+	if o.triggerPrice.IsPositive() {
+		if err := e.checkMargin(o, decimal.Max(o.price, o.triggerPrice)); err != nil {
+			return err
+		}
+		o.status = bybit.OrderStatusUntriggered
+		e.orders = append(e.orders, o)
+		e.open = append(e.open, o)
+		return nil
+	}
+
+	price, marketable := e.crossPrice(o)
+	if o.orderType == bybit.OrderTypeMarket && !marketable {
+		return newError(retCodeParams, "no price for %s yet", o.key.symbol)
+	}
+	marginPrice := o.price
+	if marketable {
+		marginPrice = price
+	}
+	if err := e.checkMargin(o, marginPrice); err != nil {
+		return err
+	}
+	e.orders = append(e.orders, o)
+
+	switch {
+	case marketable && o.timeInForce == bybit.TimeInForcePostOnly:
+		o.status = bybit.OrderStatusCancelled
+		o.rejectReason = "EC_PostOnlyWillTakeLiquidity"
+	case marketable:
+		e.fill(o, price, false, bybit.ExecTypeTrade, fills)
+	case o.timeInForce == bybit.TimeInForceImmediateOrCancel || o.timeInForce == bybit.TimeInForceFillOrKill:
+		o.status = bybit.OrderStatusCancelled
+		o.rejectReason = "EC_NoImmediateQtyToFill"
+	default:
+		o.status = bybit.OrderStatusNew
+		e.open = append(e.open, o)
+	}
+	return nil
+}
+
+// crossPrice : where the order fills as taker now, false when it has to wait
+func (e *Exchange) crossPrice(o *order) (decimal.Decimal, bool) {
+	price, ok := e.takerPrice(o.key, o.side)
+	if !ok {
+		return decimal.Zero, false
+	}
+	if o.orderType == bybit.OrderTypeMarket {
+		return price, true
+	}
+	if o.side == bybit.SideBuy && price.LessThanOrEqual(o.price) || o.side == bybit.SideSell && price.GreaterThanOrEqual(o.price) {
+		return price, true
+	}
+	return decimal.Zero, false
+}
+
+// checkMargin : the qty that does not reduce the position needs its initial margin and the taker fee available
+func (e *Exchange) checkMargin(o *order, price decimal.Decimal) error {
+	if o.reduceOnly || price.IsZero() {
+		return nil
+	}
+	p := e.position(o.key)
+	opening := o.qty
+	if !p.size.IsZero() && p.side() != o.side {
+		opening = decimal.Max(o.qty.Sub(p.size.Abs()), decimal.Zero)
+	}
+	if opening.IsZero() {
+		return nil
+	}
+	need := opening.Mul(price).Div(p.leverage).Add(opening.Mul(price).Mul(e.takerFee))
+	if need.GreaterThan(e.available(e.settleCoin(o.key))) {
+		return newError(retCodeNotEnoughMargin, "ab not enough for new order")
+	}
+	return nil
+}
+
+// fill : the rest of the order at price
+func (e *Exchange) fill(o *order, price decimal.Decimal, isMaker bool, execType bybit.ExecType, fills *[]bybit.V5GetExecutionOrder) {
+	now := e.now()
+	p := e.position(o.key)
+	qty := o.qty.Sub(o.cumQty)
+	if o.reduceOnly {
+		if p.size.IsZero() || p.side() == o.side {
+			o.status = bybit.OrderStatusCancelled
+			o.rejectReason = "EC_ReduceOnlyRuleNotSatisfied"
+			o.updated = now
+			return
+		}
+		qty = decimal.Min(qty, p.size.Abs())
+	}
+
+	rate := e.takerFee
+	if isMaker {
+		rate = e.makerFee
+	}
+	value := qty.Mul(price)
+	fee := value.Mul(rate)
+	if execType == bybit.ExecTypeBustTrade {
+		fee = decimal.Zero
+	}
+	leverage := p.leverage
+	opened := p.size.IsZero() || p.side() == o.side
+	closed := p.apply(signed(o.side, qty), price, fee, now)
+
+	w := e.wallet(e.settleCoin(o.key))
+	w.balance = w.balance.Add(closed.pnl).Sub(fee)
+	w.cumRealised = w.cumRealised.Add(closed.pnl).Sub(fee)
+	if opened || p.side() == o.side {
+		if o.takeProfit.IsPositive() {
+			p.takeProfit = o.takeProfit
+		}
+		if o.stopLoss.IsPositive() {
+			p.stopLoss = o.stopLoss
+		}
+	}
+
+	o.cumQty = o.cumQty.Add(qty)
+	o.cumValue = o.cumValue.Add(value)
+	o.cumFee = o.cumFee.Add(fee)
+	o.status = bybit.OrderStatusFilled
+	o.updated = now
+
+	execution := bybit.V5GetExecutionOrder{
+		Symbol:        o.key.symbol,
+		OrderType:     o.orderType,
+		OrderLinkID:   o.linkID,
+		Side:          o.side,
+		OrderID:       o.id,
+		StopOrderType: o.stopOrderType,
+		LeavesQty:     "0",
+		ExecTime:      millis(now),
+		IsMaker:       isMaker,
+		ExecFee:       fee.String(),
+		FeeRate:       rate.String(),
+		ExecID:        e.nextID(),
+		MarkPrice:     e.mark(o.key).String(),
+		ExecPrice:     price.String(),
+		OrderQty:      o.qty.String(),
+		OrderPrice:    o.price.String(),
+		ExecValue:     value.String(),
+		ExecType:      execType,
+		ExecQty:       qty.String(),
+		ClosedSize:    closed.qty.String(),
+	}
+	e.executions = append(e.executions, execution)
+	*fills = append(*fills, execution)
+
+	if closed.qty.IsPositive() {
+		e.closedPnl = append(e.closedPnl, bybit.V5GetClosedPnl{
+			Symbol:        o.key.symbol,
+			OrderType:     o.orderType,
+			OrderID:       o.id,
+			Leverage:      leverage.String(),
+			UpdatedTime:   millis(now),
+			Side:          o.side,
+			ClosedPnl:     closed.net.String(),
+			AvgEntryPrice: closed.avgEntry.String(),
+			Qty:           o.qty.String(),
+			CumEntryValue: closed.avgEntry.Mul(closed.qty).String(),
+			CreatedTime:   millis(now),
+			OrderPrice:    price.String(),
+			ClosedSize:    closed.qty.String(),
+			AvgExitPrice:  price.String(),
+			ExecType:      string(execType),
+			FillCount:     "1",
+			CumExitValue:  price.Mul(closed.qty).String(),
+		})
+	}
+}
+
+// match : after a quote update, triggers and fills the open orders, then take profits, stop losses and liquidation
+func (e *Exchange) match(key symbolKey, fills *[]bybit.V5GetExecutionOrder) {
+	q := e.quotes[key]
+	last := q.Last.Decimal
+	if !last.IsZero() {
+		for _, o := range append([]*order(nil), e.open...) {
+			if o.key != key || !o.isOpen() {
+				continue
+			}
+			if o.status == bybit.OrderStatusUntriggered {
+				trigger := last
+				if o.triggerBy == bybit.TriggerByMarkPrice {
+					trigger = e.mark(key)
+				}
+				if o.triggerDirection == bybit.TriggerDirectionRise && trigger.LessThan(o.triggerPrice) ||
+					o.triggerDirection == bybit.TriggerDirectionFall && trigger.GreaterThan(o.triggerPrice) {
+					continue
+				}
+				o.status = bybit.OrderStatusNew
+				o.updated = e.now()
+				if price, ok := e.crossPrice(o); ok {
+					e.fill(o, price, false, bybit.ExecTypeTrade, fills)
+				}
+				continue
+			}
+			if o.orderType == bybit.OrderTypeLimit &&
+				(o.side == bybit.SideBuy && last.LessThanOrEqual(o.price) || o.side == bybit.SideSell && last.GreaterThanOrEqual(o.price)) {
+				e.fill(o, o.price, true, bybit.ExecTypeTrade, fills)
+			}
+		}
+	}
+
+	if p, ok := e.positions[key]; ok && !p.size.IsZero() && !last.IsZero() {
+		long := p.size.IsPositive()
+		takeProfit := p.takeProfit.IsPositive() && (long && last.GreaterThanOrEqual(p.takeProfit) || !long && last.LessThanOrEqual(p.takeProfit))
+		stopLoss := p.stopLoss.IsPositive() && (long && last.LessThanOrEqual(p.stopLoss) || !long && last.GreaterThanOrEqual(p.stopLoss))
+		if takeProfit || stopLoss {
+			o := e.closeOrder(p, bybit.OrderTypeMarket)
+			o.stopOrderType = "TakeProfit"
+			if stopLoss {
+				o.stopOrderType = "StopLoss"
+			}
+			if price, ok := e.takerPrice(key, o.side); ok {
+				e.fill(o, price, false, bybit.ExecTypeTrade, fills)
+			}
+		}
+	}
+
+	if p, ok := e.positions[key]; ok && !p.size.IsZero() {
+		mark := e.mark(key)
+		liq := p.liqPrice(e.maintenanceRate)
+		if p.size.IsPositive() && mark.LessThanOrEqual(liq) || p.size.IsNegative() && mark.GreaterThanOrEqual(liq) {
+			o := e.closeOrder(p, bybit.OrderTypeLimit)
+			o.price = p.bustPrice()
+			o.stopOrderType = "Liquidation"
+			e.fill(o, o.price, false, bybit.ExecTypeBustTrade, fills)
+		}
+	}
+
+	open := e.open[:0]
+	for _, o := range e.open {
+		if o.isOpen() {
+			open = append(open, o)
+		}
+	}
+	e.open = open
+}
+
+// closeOrder : the order the exchange places to close p
+func (e *Exchange) closeOrder(p *position, orderType bybit.OrderType) *order {
+	now := e.now()
+	side := bybit.SideSell
+	if p.size.IsNegative() {
+		side = bybit.SideBuy
+	}
+	o := &order{
+		key:         p.key,
+		id:          e.nextID(),
+		side:        side,
+		orderType:   orderType,
+		timeInForce: bybit.TimeInForceImmediateOrCancel,
+		qty:         p.size.Abs(),
+		reduceOnly:  true,
+		status:      bybit.OrderStatusTriggered,
+		created:     now,
+		updated:     now,
+	}
+	e.orders = append(e.orders, o)
+	return o
+}
+
+// CancelOrder :
+func (e *Exchange) CancelOrder(param bybit.V5CancelOrderParam) (*bybit.V5CancelOrderResponse, error) {
+	if param.OrderID == nil && param.OrderLinkID == nil {
+		return nil, newError(retCodeParams, "either orderId or orderLinkId needed")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, o := range e.open {
+		if o.key.category != param.Category || o.key.symbol != param.Symbol {
+			continue
+		}
+		if param.OrderID != nil && o.id != *param.OrderID || param.OrderLinkID != nil && o.linkID != *param.OrderLinkID {
+			continue
+		}
+		if o.status == bybit.OrderStatusUntriggered {
+			o.status = bybit.OrderStatusDeactivated
+		} else {
+			o.status = bybit.OrderStatusCancelled
+		}
+		o.rejectReason = "EC_PerCancelRequest"
+		o.updated = e.now()
+		e.open = append(e.open[:i:i], e.open[i+1:]...)
+		return &bybit.V5CancelOrderResponse{
+			CommonV5Response: e.response(),
+			Result:           bybit.V5CancelOrderResult{OrderID: o.id, OrderLinkID: o.linkID},
+		}, nil
+	}
+	return nil, newError(retCodeOrderNotExists, "order not exists or too late to cancel")
+}
+
+// GetOpenOrders : the newest first
+func (e *Exchange) GetOpenOrders(param bybit.V5GetOpenOrdersParam) (*bybit.V5GetOpenOrdersResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	var list []bybit.V5GetOpenOrder
+	for i := len(e.open) - 1; i >= 0; i-- {
+		o := e.open[i]
+		if !e.matches(o, param.Category, param.Symbol, param.SettleCoin, param.OrderID, param.OrderLinkID) {
+			continue
+		}
+		if param.OrderFilter != nil && (*param.OrderFilter == bybit.OrderFilterStopOrder) != (o.stopOrderType != "") {
+			continue
+		}
+		list = append(list, openOrder(o))
+	}
+	from, to, next := page(len(list), param.Limit, param.Cursor, 20)
+	return &bybit.V5GetOpenOrdersResponse{
+		CommonV5Response: e.response(),
+		Result:           bybit.V5GetOpenOrdersResult{Category: param.Category, NextPageCursor: next, List: list[from:to]},
+	}, nil
+}
+
+// GetOrderList : every order, the newest first
+func (e *Exchange) GetOrderList(param bybit.V5GetOrderListParam) (*bybit.V5GetOrderListResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	var list []bybit.V5GetOrder
+	for i := len(e.orders) - 1; i >= 0; i-- {
+		o := e.orders[i]
+		if !e.matches(o, param.Category, param.Symbol, nil, param.OrderID, param.OrderLinkID) || !within(o.created, param.StartTime, param.EndTime) {
+			continue
+		}
+		list = append(list, historyOrder(o))
+	}
+	from, to, next := page(len(list), param.Limit, param.Cursor, 20)
+	return &bybit.V5GetOrderListResponse{
+		CommonV5Response: e.response(),
+		Result:           bybit.V5GetOrderListResult{Category: param.Category, NextPageCursor: next, List: list[from:to]},
+	}, nil
+}
+
+// GetExecutionList : the newest first
+func (e *Exchange) GetExecutionList(param bybit.V5GetExecutionListParam) (*bybit.V5GetExecutionListResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	var list []bybit.V5GetExecutionOrder
+	for i := len(e.executions) - 1; i >= 0; i-- {
+		execution := e.executions[i]
+		if param.Symbol != nil && execution.Symbol != *param.Symbol ||
+			param.OrderID != nil && execution.OrderID != *param.OrderID ||
+			param.OrderLinkID != nil && execution.OrderLinkID != *param.OrderLinkID ||
+			param.ExecType != nil && execution.ExecType != *param.ExecType ||
+			!within(execution.ExecTime.Time, param.StartTime, param.EndTime) {
+			continue
+		}
+		list = append(list, execution)
+	}
+	from, to, next := page(len(list), param.Limit, param.Cursor, 50)
+	return &bybit.V5GetExecutionListResponse{
+		CommonV5Response: e.response(),
+		Result:           bybit.V5GetExecutionListResult{Category: param.Category, NextPageCursor: next, List: list[from:to]},
+	}, nil
+}
+
+// GetClosedPnl : one record per closing fill, the newest first
+func (e *Exchange) GetClosedPnl(param bybit.V5GetClosedPnlParam) (*bybit.V5GetClosedPnlResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	var list []bybit.V5GetClosedPnl
+	for i := len(e.closedPnl) - 1; i >= 0; i-- {
+		closed := e.closedPnl[i]
+		if param.Symbol != nil && closed.Symbol != *param.Symbol || !within(closed.CreatedTime.Time, param.StartTime, param.EndTime) {
+			continue
+		}
+		list = append(list, closed)
+	}
+	from, to, next := page(len(list), param.Limit, param.Cursor, 50)
+	return &bybit.V5GetClosedPnlResponse{
+		CommonV5Response: e.response(),
+		Result:           bybit.V5GetClosedPnlResult{Category: param.Category, NextPageCursor: next, List: list[from:to]},
+	}, nil
+}
+
+func (e *Exchange) matches(o *order, category bybit.CategoryV5, symbol *bybit.SymbolV5, settleCoin *bybit.Coin, orderID, orderLinkID *string) bool {
+	return o.key.category == category &&
+		(symbol == nil || o.key.symbol == *symbol) &&
+		(settleCoin == nil || e.settleCoin(o.key) == *settleCoin) &&
+		(orderID == nil || o.id == *orderID) &&
+		(orderLinkID == nil || o.linkID == *orderLinkID)
+}
+
+func within(t time.Time, start, end *time.Time) bool {
+	return (start == nil || !t.Before(*start)) && (end == nil || !t.After(*end))
+}
+
+func avgPrice(o *order) string {
+	if o.cumQty.IsZero() {
+		return "0"
+	}
+	return o.cumValue.Div(o.cumQty).String()
+}
+
+func openOrder(o *order) bybit.V5GetOpenOrder {
+	leaves := o.qty.Sub(o.cumQty)
+	return bybit.V5GetOpenOrder{
+		Symbol:             o.key.symbol,
+		OrderType:          o.orderType,
+		OrderLinkID:        o.linkID,
+		OrderID:            o.id,
+		AvgPrice:           avgPrice(o),
+		StopOrderType:      o.stopOrderType,
+		LastPriceOnCreated: o.lastPrice.String(),
+		OrderStatus:        o.status,
+		TakeProfit:         o.takeProfit.String(),
+		CumExecValue:       o.cumValue.String(),
+		TriggerDirection:   int(o.triggerDirection),
+		IsLeverage:         "",
+		RejectReason:       o.rejectReason,
+		Price:              o.price.String(),
+		CreatedTime:        millis(o.created),
+		PositionIdx:        int(bybit.PositionIdxOneWay),
+		TimeInForce:        o.timeInForce,
+		LeavesValue:        leaves.Mul(o.price).String(),
+		UpdatedTime:        millis(o.updated),
+		Side:               o.side,
+		TriggerPrice:       o.triggerPrice.String(),
+		CumExecFee:         o.cumFee.String(),
+		LeavesQty:          leaves.String(),
+		CumExecQty:         o.cumQty.String(),
+		ReduceOnly:         o.reduceOnly,
+		Qty:                o.qty.String(),
+		StopLoss:           o.stopLoss.String(),
+		TriggerBy:          o.triggerBy,
+	}
+}
+
+func historyOrder(o *order) bybit.V5GetOrder {
+	open := openOrder(o)
+	return bybit.V5GetOrder{
+		Symbol:             open.Symbol,
+		OrderType:          open.OrderType,
+		OrderLinkID:        open.OrderLinkID,
+		OrderID:            open.OrderID,
+		AvgPrice:           open.AvgPrice,
+		StopOrderType:      open.StopOrderType,
+		LastPriceOnCreated: open.LastPriceOnCreated,
+		OrderStatus:        string(open.OrderStatus),
+		TakeProfit:         open.TakeProfit,
+		CumExecValue:       open.CumExecValue,
+		TriggerDirection:   open.TriggerDirection,
+		RejectReason:       open.RejectReason,
+		Price:              open.Price,
+		CreatedTime:        open.CreatedTime,
+		PositionIdx:        open.PositionIdx,
+		TimeInForce:        string(open.TimeInForce),
+		LeavesValue:        open.LeavesValue,
+		UpdatedTime:        open.UpdatedTime,
+		Side:               open.Side,
+		TriggerPrice:       open.TriggerPrice,
+		CumExecFee:         open.CumExecFee,
+		LeavesQty:          open.LeavesQty,
+		CumExecQty:         open.CumExecQty,
+		ReduceOnly:         open.ReduceOnly,
+		Qty:                open.Qty,
+		StopLoss:           open.StopLoss,
+		TriggerBy:          string(open.TriggerBy),
+	}
+}
@@ -0,0 +1,242 @@
+package paper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var symbol = bybit.SymbolV5BTCUSDT
+
+func newTestExchange() (*Exchange, *[]bybit.V5GetExecutionOrder) {
+	now := time.Date(2023, 11, 29, 8, 0, 0, 0, time.UTC)
+	fills := []bybit.V5GetExecutionOrder{}
+	exchange := NewExchange().
+		WithBalance(bybit.CoinUSDT, bybit.RequireDecimal("10000")).
+		WithClock(func() time.Time { return now }).
+		OnExecution(func(fill bybit.V5GetExecutionOrder) { fills = append(fills, fill) })
+	return exchange, &fills
+}
+
+func quote(exchange *Exchange, price string) {
+	p := bybit.RequireDecimal(price)
+	exchange.UpdateQuote(bybit.CategoryV5Linear, symbol, Quote{Last: p, Bid: p, Ask: p})
+}
+
+func str(s string) *string {
+	return &s
+}
+
+func positionInfo(t *testing.T, exchange *Exchange) bybit.V5GetPositionInfoItem {
+	res, err := exchange.GetPositionInfo(bybit.V5GetPositionInfoParam{Category: bybit.CategoryV5Linear, Symbol: &symbol})
+	require.NoError(t, err)
+	require.Len(t, res.Result.List, 1)
+	return res.Result.List[0]
+}
+
+func balance(t *testing.T, exchange *Exchange) bybit.V5WalletBalanceCoin {
+	res, err := exchange.GetWalletBalance(bybit.AccountTypeUnified, []bybit.Coin{bybit.CoinUSDT})
+	require.NoError(t, err)
+	return res.Result.List[0].Coin[0]
+}
+
+func retCode(err error) int {
+	var res *bybit.ErrorResponse
+	if errors.As(err, &res) {
+		return res.RetCode
+	}
+	return 0
+}
+
+func TestRoundTrip(t *testing.T) {
+	exchange, fills := newTestExchange()
+	exchange.UpdateQuote(bybit.CategoryV5Linear, symbol, Quote{
+		Last: bybit.RequireDecimal("30000"), Bid: bybit.RequireDecimal("29999.5"), Ask: bybit.RequireDecimal("30000"),
+	})
+
+	_, err := exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: "0.1",
+	})
+	require.NoError(t, err)
+	require.Len(t, *fills, 1)
+	assert.Equal(t, "30000", (*fills)[0].ExecPrice)
+	assert.Equal(t, "1.65", (*fills)[0].ExecFee)
+	assert.False(t, (*fills)[0].IsMaker)
+
+	p := positionInfo(t, exchange)
+	assert.Equal(t, bybit.SideBuy, p.Side)
+	assert.Equal(t, "0.1", p.Size)
+	assert.Equal(t, "30000", p.AvgPrice)
+	assert.Equal(t, "300", p.PositionIM)
+	assert.Equal(t, "27150", p.LiqPrice)
+	assert.Equal(t, "-1.65", p.CumRealisedPnl)
+	assert.Equal(t, "9998.35", balance(t, exchange).WalletBalance)
+
+	res, err := exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideSell, OrderType: bybit.OrderTypeLimit,
+		Qty: "0.1", Price: str("31000"), OrderLinkID: str("exit"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "exit", res.Result.OrderLinkID)
+	open, err := exchange.GetOpenOrders(bybit.V5GetOpenOrdersParam{Category: bybit.CategoryV5Linear})
+	require.NoError(t, err)
+	require.Len(t, open.Result.List, 1)
+	assert.Equal(t, bybit.OrderStatusNew, open.Result.List[0].OrderStatus)
+
+	quote(exchange, "30500")
+	assert.Len(t, *fills, 1)
+	assert.Equal(t, "50", positionInfo(t, exchange).UnrealisedPnl)
+
+	quote(exchange, "31000")
+	require.Len(t, *fills, 2)
+	assert.True(t, (*fills)[1].IsMaker)
+	assert.Equal(t, "0.62", (*fills)[1].ExecFee)
+	assert.Equal(t, "0.1", (*fills)[1].ClosedSize)
+	assert.Equal(t, bybit.SideNone, positionInfo(t, exchange).Side)
+	assert.Equal(t, "10097.73", balance(t, exchange).WalletBalance)
+
+	closed, err := exchange.GetClosedPnl(bybit.V5GetClosedPnlParam{Category: bybit.CategoryV5Linear})
+	require.NoError(t, err)
+	require.Len(t, closed.Result.List, 1)
+	assert.Equal(t, "97.73", closed.Result.List[0].ClosedPnl)
+	assert.Equal(t, "30000", closed.Result.List[0].AvgEntryPrice)
+
+	history, err := exchange.GetOrderList(bybit.V5GetOrderListParam{Category: bybit.CategoryV5Linear, OrderLinkID: str("exit")})
+	require.NoError(t, err)
+	require.Len(t, history.Result.List, 1)
+	assert.Equal(t, string(bybit.OrderStatusFilled), history.Result.List[0].OrderStatus)
+	assert.Equal(t, "31000", history.Result.List[0].AvgPrice)
+
+	executions, err := exchange.GetExecutionList(bybit.V5GetExecutionListParam{Category: bybit.CategoryV5Linear, Limit: intPtr(1)})
+	require.NoError(t, err)
+	require.Len(t, executions.Result.List, 1)
+	assert.Equal(t, "31000", executions.Result.List[0].ExecPrice)
+	assert.Equal(t, "1", executions.Result.NextPageCursor)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRejections(t *testing.T) {
+	exchange, _ := newTestExchange()
+
+	_, err := exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: "0.1",
+	})
+	assert.Error(t, err, "no price yet")
+
+	quote(exchange, "30000")
+	_, err = exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: "4",
+	})
+	assert.Equal(t, retCodeNotEnoughMargin, retCode(err))
+
+	reduceOnly := true
+	_, err = exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideSell, OrderType: bybit.OrderTypeMarket, Qty: "1", ReduceOnly: &reduceOnly,
+	})
+	assert.Equal(t, retCodeReduceOnly, retCode(err))
+
+	_, err = exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Inverse, Symbol: bybit.SymbolV5BTCUSD, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: "1",
+	})
+	assert.Equal(t, retCodeParams, retCode(err))
+
+	postOnly := bybit.TimeInForcePostOnly
+	res, err := exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideBuy, OrderType: bybit.OrderTypeLimit,
+		Qty: "0.1", Price: str("30100"), TimeInForce: &postOnly,
+	})
+	require.NoError(t, err)
+	history, err := exchange.GetOrderList(bybit.V5GetOrderListParam{Category: bybit.CategoryV5Linear, OrderID: &res.Result.OrderID})
+	require.NoError(t, err)
+	assert.Equal(t, string(bybit.OrderStatusCancelled), history.Result.List[0].OrderStatus)
+	assert.Equal(t, "EC_PostOnlyWillTakeLiquidity", history.Result.List[0].RejectReason)
+
+	_, err = exchange.SetLeverage(bybit.V5SetLeverageParam{Category: bybit.CategoryV5Linear, Symbol: symbol, BuyLeverage: "10", SellLeverage: "10"})
+	assert.Equal(t, retCodeLeverageNotMod, retCode(err))
+	_, err = exchange.CancelOrder(bybit.V5CancelOrderParam{Category: bybit.CategoryV5Linear, Symbol: symbol, OrderID: &res.Result.OrderID})
+	assert.Equal(t, retCodeOrderNotExists, retCode(err))
+}
+
+func TestConditionalOrderAndStopLoss(t *testing.T) {
+	exchange, fills := newTestExchange()
+	quote(exchange, "30000")
+
+	rise := bybit.TriggerDirectionRise
+	res, err := exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket,
+		Qty: "0.1", TriggerPrice: str("30500"), TriggerDirection: &rise, StopLoss: str("30200"),
+	})
+	require.NoError(t, err)
+	open, err := exchange.GetOpenOrders(bybit.V5GetOpenOrdersParam{Category: bybit.CategoryV5Linear, OrderID: &res.Result.OrderID})
+	require.NoError(t, err)
+	assert.Equal(t, bybit.OrderStatusUntriggered, open.Result.List[0].OrderStatus)
+
+	quote(exchange, "30400")
+	assert.Empty(t, *fills)
+	quote(exchange, "30600")
+	require.Len(t, *fills, 1)
+	assert.Equal(t, "30600", (*fills)[0].ExecPrice)
+	assert.Equal(t, "30200", positionInfo(t, exchange).StopLoss)
+
+	quote(exchange, "30100")
+	require.Len(t, *fills, 2)
+	assert.Equal(t, "StopLoss", (*fills)[1].StopOrderType)
+	assert.Equal(t, bybit.SideNone, positionInfo(t, exchange).Side)
+}
+
+func TestLiquidation(t *testing.T) {
+	exchange, fills := newTestExchange()
+	quote(exchange, "30000")
+	_, err := exchange.SetLeverage(bybit.V5SetLeverageParam{Category: bybit.CategoryV5Linear, Symbol: symbol, BuyLeverage: "50", SellLeverage: "50"})
+	require.NoError(t, err)
+
+	_, err = exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideSell, OrderType: bybit.OrderTypeMarket, Qty: "1",
+	})
+	require.NoError(t, err)
+	p := positionInfo(t, exchange)
+	assert.Equal(t, "600", p.PositionIM)
+	assert.Equal(t, "30450", p.LiqPrice)
+
+	exchange.UpdateQuote(bybit.CategoryV5Linear, symbol, Quote{Mark: bybit.RequireDecimal("30460")})
+	require.Len(t, *fills, 2)
+	assert.Equal(t, bybit.ExecTypeBustTrade, (*fills)[1].ExecType)
+	assert.Equal(t, "30600", (*fills)[1].ExecPrice)
+	// the margin is gone, the opening fee was paid before
+	assert.Equal(t, "9383.5", balance(t, exchange).WalletBalance)
+}
+
+func TestV5(t *testing.T) {
+	exchange := NewExchange()
+	service := exchange.V5(bybit.NewClient().V5())
+	assert.Equal(t, exchange, service.Order())
+	assert.Equal(t, exchange, service.Position())
+	assert.Equal(t, exchange, service.Account())
+	assert.NotNil(t, service.Market())
+}
+
+func TestApplyFunding(t *testing.T) {
+	exchange, fills := newTestExchange()
+	exchange.ApplyFunding(bybit.CategoryV5Linear, symbol, bybit.RequireDecimal("0.0001"))
+	assert.Empty(t, *fills, "no position")
+
+	quote(exchange, "30000")
+	_, err := exchange.CreateOrder(bybit.V5CreateOrderParam{
+		Category: bybit.CategoryV5Linear, Symbol: symbol, Side: bybit.SideSell, OrderType: bybit.OrderTypeMarket, Qty: "0.1",
+	})
+	require.NoError(t, err)
+	exchange.UpdateQuote(bybit.CategoryV5Linear, symbol, Quote{Mark: bybit.RequireDecimal("31000")})
+	exchange.ApplyFunding(bybit.CategoryV5Linear, symbol, bybit.RequireDecimal("0.0001"))
+	require.Len(t, *fills, 2)
+	assert.Equal(t, bybit.ExecTypeFunding, (*fills)[1].ExecType)
+	assert.Equal(t, "-0.31", (*fills)[1].ExecFee, "the short receives")
+	assert.Equal(t, "9998.66", balance(t, exchange).WalletBalance)
+	assert.Equal(t, "-1.34", positionInfo(t, exchange).CumRealisedPnl)
+}
@@ -0,0 +1,288 @@
+package paper
+
+import (
+	"sort"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/shopspring/decimal"
+)
+
+type position struct {
+	key symbolKey
+	// size : negative when short
+	size        decimal.Decimal
+	avgPrice    decimal.Decimal
+	leverage    decimal.Decimal
+	cumRealised decimal.Decimal
+	// openFee : fees paid opening what is still open, charged to the closed PnL on close
+	openFee    decimal.Decimal
+	takeProfit decimal.Decimal
+	stopLoss   decimal.Decimal
+	created    time.Time
+	updated    time.Time
+}
+
+// closing : what a fill closed of the position
+type closing struct {
+	qty      decimal.Decimal
+	avgEntry decimal.Decimal
+	pnl      decimal.Decimal
+	// net : pnl less the opening and closing fees of the closed qty
+	net decimal.Decimal
+}
+
+func (e *Exchange) position(key symbolKey) *position {
+	p, ok := e.positions[key]
+	if !ok {
+		now := e.now()
+		p = &position{key: key, leverage: e.defaultLeverage, created: now, updated: now}
+		e.positions[key] = p
+	}
+	return p
+}
+
+func signed(side bybit.Side, qty decimal.Decimal) decimal.Decimal {
+	if side == bybit.SideSell {
+		return qty.Neg()
+	}
+	return qty
+}
+
+// apply : adds the fill of qty (negative for a sell) at price, with fee paid on it
+func (p *position) apply(qty, price, fee decimal.Decimal, now time.Time) closing {
+	var result closing
+	p.updated = now
+	p.cumRealised = p.cumRealised.Sub(fee)
+	if p.size.IsZero() || p.size.Sign() == qty.Sign() {
+		value := p.avgPrice.Mul(p.size.Abs()).Add(price.Mul(qty.Abs()))
+		p.size = p.size.Add(qty)
+		p.avgPrice = value.Div(p.size.Abs())
+		p.openFee = p.openFee.Add(fee)
+		return result
+	}
+
+	result.qty = decimal.Min(qty.Abs(), p.size.Abs())
+	result.avgEntry = p.avgPrice
+	result.pnl = price.Sub(p.avgPrice).Mul(result.qty)
+	if p.size.IsNegative() {
+		result.pnl = result.pnl.Neg()
+	}
+	openFee := p.openFee.Mul(result.qty).Div(p.size.Abs())
+	closeFee := fee.Mul(result.qty).Div(qty.Abs())
+	result.net = result.pnl.Sub(openFee).Sub(closeFee)
+	p.openFee = p.openFee.Sub(openFee)
+	p.cumRealised = p.cumRealised.Add(result.pnl)
+
+	remaining := qty.Abs().Sub(result.qty)
+	p.size = p.size.Add(qty.Sub(signed(sideOf(qty), remaining)))
+	if p.size.IsZero() {
+		p.avgPrice = decimal.Zero
+		p.openFee = decimal.Zero
+		p.takeProfit = decimal.Zero
+		p.stopLoss = decimal.Zero
+	}
+	if remaining.IsPositive() {
+		// flipped to the other side
+		p.size = signed(sideOf(qty), remaining)
+		p.avgPrice = price
+		p.openFee = fee.Sub(closeFee)
+	}
+	return result
+}
+
+func sideOf(qty decimal.Decimal) bybit.Side {
+	if qty.IsNegative() {
+		return bybit.SideSell
+	}
+	return bybit.SideBuy
+}
+
+func (p *position) side() bybit.Side {
+	if p.size.IsZero() {
+		return bybit.SideNone
+	}
+	return sideOf(p.size)
+}
+
+func (p *position) unrealised(mark decimal.Decimal) decimal.Decimal {
+	if p.size.IsZero() || mark.IsZero() {
+		return decimal.Zero
+	}
+	return mark.Sub(p.avgPrice).Mul(p.size)
+}
+
+func (p *position) initialMargin() decimal.Decimal {
+	return p.avgPrice.Mul(p.size.Abs()).Div(p.leverage)
+}
+
+func (p *position) maintenanceMargin(mark, rate decimal.Decimal) decimal.Decimal {
+	if mark.IsZero() {
+		mark = p.avgPrice
+	}
+	return mark.Mul(p.size.Abs()).Mul(rate)
+}
+
+// bustPrice : where the initial margin is lost
+func (p *position) bustPrice() decimal.Decimal {
+	if p.size.IsZero() {
+		return decimal.Zero
+	}
+	move := p.avgPrice.Div(p.leverage)
+	if p.size.IsNegative() {
+		return p.avgPrice.Add(move)
+	}
+	return decimal.Max(p.avgPrice.Sub(move), decimal.Zero)
+}
+
+// liqPrice : isolated margin, the bust price moved by the maintenance margin
+func (p *position) liqPrice(rate decimal.Decimal) decimal.Decimal {
+	if p.size.IsZero() {
+		return decimal.Zero
+	}
+	if p.size.IsNegative() {
+		return p.bustPrice().Sub(p.avgPrice.Mul(rate))
+	}
+	return decimal.Max(p.bustPrice().Add(p.avgPrice.Mul(rate)), decimal.Zero)
+}
+
+// GetPositionInfo : the positions of the symbol or the settle coin, those that never traded are left out
+func (e *Exchange) GetPositionInfo(param bybit.V5GetPositionInfoParam) (*bybit.V5GetPositionInfoResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	if param.Symbol == nil && param.SettleCoin == nil {
+		return nil, newError(retCodeParams, "symbol or settleCoin is required")
+	}
+
+	var list bybit.V5GetPositionInfoList
+	for key, p := range e.positions {
+		if key.category != param.Category {
+			continue
+		}
+		if param.Symbol != nil && key.symbol != *param.Symbol {
+			continue
+		}
+		if param.Symbol == nil && e.settleCoin(key) != *param.SettleCoin {
+			continue
+		}
+		list = append(list, e.positionItem(p))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Symbol < list[j].Symbol })
+	from, to, next := page(len(list), param.Limit, param.Cursor, 20)
+
+	return &bybit.V5GetPositionInfoResponse{
+		CommonV5Response: e.response(),
+		Result: bybit.V5GetPositionInfoResult{
+			Category:       param.Category,
+			NextPageCursor: next,
+			List:           list[from:to],
+		},
+	}, nil
+}
+
+func (e *Exchange) positionItem(p *position) bybit.V5GetPositionInfoItem {
+	mark := e.mark(p.key)
+	item := bybit.V5GetPositionInfoItem{
+		Symbol:         p.key.symbol,
+		Leverage:       p.leverage.String(),
+		AvgPrice:       p.avgPrice.String(),
+		LiqPrice:       "",
+		TakeProfit:     p.takeProfit.String(),
+		StopLoss:       p.stopLoss.String(),
+		PositionValue:  p.avgPrice.Mul(p.size.Abs()).String(),
+		TpSlMode:       bybit.TpSlModeFull,
+		TrailingStop:   "0",
+		UnrealisedPnl:  p.unrealised(mark).String(),
+		MarkPrice:      mark.String(),
+		CumRealisedPnl: p.cumRealised.String(),
+		PositionMM:     p.maintenanceMargin(mark, e.maintenanceRate).String(),
+		PositionIM:     p.initialMargin().String(),
+		CreatedTime:    millis(p.created),
+		UpdatedTime:    millis(p.updated),
+		PositionIdx:    int(bybit.PositionIdxOneWay),
+		Side:           p.side(),
+		BustPrice:      "",
+		Size:           p.size.Abs().String(),
+		PositionStatus: "Normal",
+		TradeMode:      1,
+	}
+	if !p.size.IsZero() {
+		item.LiqPrice = p.liqPrice(e.maintenanceRate).String()
+		item.BustPrice = p.bustPrice().String()
+	}
+	return item
+}
+
+// SetLeverage : buy and sell leverage must be equal in one-way mode, an open position keeps its margin
+func (e *Exchange) SetLeverage(param bybit.V5SetLeverageParam) (*bybit.V5SetLeverageResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkCategory(param.Category); err != nil {
+		return nil, err
+	}
+	leverage, err := decimal.NewFromString(param.BuyLeverage)
+	if err != nil || param.BuyLeverage != param.SellLeverage || !leverage.IsPositive() {
+		return nil, newError(retCodeParams, "buyLeverage must equal sellLeverage and be positive")
+	}
+	p := e.position(symbolKey{category: param.Category, symbol: param.Symbol})
+	if p.leverage.Equal(leverage) {
+		return nil, newError(retCodeLeverageNotMod, "leverage not modified")
+	}
+	p.leverage = leverage
+	p.updated = e.now()
+	return &bybit.V5SetLeverageResponse{CommonV5Response: e.response(), Result: map[string]interface{}{}}, nil
+}
+
+func (e *Exchange) checkCategory(category bybit.CategoryV5) error {
+	if category != bybit.CategoryV5Linear {
+		return newError(retCodeParams, "category %q is not simulated", category)
+	}
+	return nil
+}
+
+// ApplyFunding : settles the funding of the position at the mark price, a positive rate is paid by longs to shorts.
+// It is booked like the exchange does, as an execution of ExecTypeFunding whose ExecFee is what was paid.
+func (e *Exchange) ApplyFunding(category bybit.CategoryV5, symbol bybit.SymbolV5, rate bybit.Decimal) {
+	e.mu.Lock()
+	key := symbolKey{category: category, symbol: symbol}
+	p, ok := e.positions[key]
+	mark := e.mark(key)
+	if !ok || p.size.IsZero() || mark.IsZero() {
+		e.mu.Unlock()
+		return
+	}
+	now := e.now()
+	fee := mark.Mul(p.size).Mul(rate.Decimal)
+	p.cumRealised = p.cumRealised.Sub(fee)
+	p.updated = now
+	w := e.wallet(e.settleCoin(key))
+	w.balance = w.balance.Sub(fee)
+	w.cumRealised = w.cumRealised.Sub(fee)
+
+	execution := bybit.V5GetExecutionOrder{
+		Symbol:     symbol,
+		Side:       p.side(),
+		LeavesQty:  "0",
+		ExecTime:   millis(now),
+		ExecFee:    fee.String(),
+		FeeRate:    rate.String(),
+		ExecID:     e.nextID(),
+		MarkPrice:  mark.String(),
+		ExecPrice:  mark.String(),
+		OrderQty:   "0",
+		OrderPrice: "0",
+		ExecValue:  mark.Mul(p.size.Abs()).String(),
+		ExecType:   bybit.ExecTypeFunding,
+		ExecQty:    p.size.Abs().String(),
+		ClosedSize: "0",
+	}
+	e.executions = append(e.executions, execution)
+	e.mu.Unlock()
+
+	e.emit([]bybit.V5GetExecutionOrder{execution})
+}
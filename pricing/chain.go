@@ -0,0 +1,95 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// ContractParams : model inputs of an option chain contract at the mark IV of its ticker
+func ContractParams(c bybit.V5OptionContract, now time.Time, rate float64) (Params, error) {
+	if c.Ticker == nil {
+		return Params{}, fmt.Errorf("%s: no ticker", c.Symbol)
+	}
+	spot, _ := c.Ticker.UnderlyingPrice.Float64()
+	if spot <= 0 {
+		return Params{}, fmt.Errorf("%s: no underlying price", c.Symbol)
+	}
+	strike, _ := c.Strike.Float64()
+	vol, _ := c.Ticker.MarkIv.Float64()
+	return Params{
+		OptionsType:  c.OptionsType,
+		Spot:         spot,
+		Strike:       strike,
+		Rate:         rate,
+		Volatility:   vol,
+		TimeToExpiry: YearsToExpiry(now, c.Expiry),
+	}, nil
+}
+
+// ContractGreeks : greeks of one contract at the mark IV
+func ContractGreeks(c bybit.V5OptionContract, now time.Time, rate float64) (Greeks, error) {
+	p, err := ContractParams(c, now, rate)
+	if err != nil {
+		return Greeks{}, err
+	}
+	return Compute(p), nil
+}
+
+// MarkImpliedVolatility : the volatility implied by the mark price, to compare with the mark IV bybit sends
+func MarkImpliedVolatility(c bybit.V5OptionContract, now time.Time, rate float64) (float64, error) {
+	p, err := ContractParams(c, now, rate)
+	if err != nil {
+		return 0, err
+	}
+	mark, _ := c.Ticker.MarkPrice.Float64()
+	return ImpliedVolatility(p, mark)
+}
+
+// Position : Size is negative for a short position
+type Position struct {
+	Contract bybit.V5OptionContract
+	Size     float64
+}
+
+// PortfolioGreeks : the sum of the greeks of the positions, Price is the theoretical value of the portfolio
+func PortfolioGreeks(positions []Position, now time.Time, rate float64) (Greeks, error) {
+	var total Greeks
+	for _, position := range positions {
+		g, err := ContractGreeks(position.Contract, now, rate)
+		if err != nil {
+			return Greeks{}, err
+		}
+		total = total.Add(g.Scale(position.Size))
+	}
+	return total, nil
+}
+
+// CheckOrderIv : rejects an orderIv further than tolerance (0.05 is five volatility points) from the mark IV of the contract
+func CheckOrderIv(param bybit.V5CreateOrderParam, c bybit.V5OptionContract, tolerance float64) error {
+	if param.OrderIv == nil {
+		return nil
+	}
+	if param.Symbol != c.Symbol {
+		return fmt.Errorf("order is for %s, not %s", param.Symbol, c.Symbol)
+	}
+	if c.Ticker == nil {
+		return fmt.Errorf("%s: no ticker", c.Symbol)
+	}
+	orderIv, err := strconv.ParseFloat(*param.OrderIv, 64)
+	if err != nil {
+		return fmt.Errorf("orderIv: %w", err)
+	}
+	if orderIv <= 0 {
+		return errors.New("orderIv must be positive")
+	}
+	markIv, _ := c.Ticker.MarkIv.Float64()
+	if math.Abs(orderIv-markIv) > tolerance {
+		return fmt.Errorf("orderIv %s is more than %g away from the mark IV %s", *param.OrderIv, tolerance, c.Ticker.MarkIv)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Package pricing prices European options with Black-Scholes and backs out implied volatility.
+package pricing
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// ErrNoConvergence :
+var ErrNoConvergence = errors.New("implied volatility did not converge")
+
+// daysPerYear : bybit quotes theta per calendar day
+const daysPerYear = 365
+
+// Params : inputs of the model, Rate and Volatility are annual and continuous, TimeToExpiry is in years
+type Params struct {
+	OptionsType  bybit.OptionsType
+	Spot         float64
+	Strike       float64
+	Rate         float64
+	Volatility   float64
+	TimeToExpiry float64
+}
+
+// Greeks : Vega is per volatility point (0.01), Theta per calendar day, as bybit shows them
+type Greeks struct {
+	Price float64
+	Delta float64
+	Gamma float64
+	Vega  float64
+	Theta float64
+}
+
+// Add : for aggregating positions
+func (g Greeks) Add(o Greeks) Greeks {
+	return Greeks{
+		Price: g.Price + o.Price,
+		Delta: g.Delta + o.Delta,
+		Gamma: g.Gamma + o.Gamma,
+		Vega:  g.Vega + o.Vega,
+		Theta: g.Theta + o.Theta,
+	}
+}
+
+// Scale : greeks of size contracts, negative for a short position
+func (g Greeks) Scale(size float64) Greeks {
+	return Greeks{
+		Price: g.Price * size,
+		Delta: g.Delta * size,
+		Gamma: g.Gamma * size,
+		Vega:  g.Vega * size,
+		Theta: g.Theta * size,
+	}
+}
+
+// YearsToExpiry : 0 once expired
+func YearsToExpiry(now time.Time, expiry time.Time) float64 {
+	d := expiry.Sub(now)
+	if d <= 0 {
+		return 0
+	}
+	return d.Hours() / 24 / daysPerYear
+}
+
+// Price : theoretical price, the intrinsic value at or after expiry
+func Price(p Params) float64 {
+	return Compute(p).Price
+}
+
+// Compute : price and greeks
+func Compute(p Params) Greeks {
+	isCall := p.OptionsType != bybit.OptionsTypePut
+	if p.TimeToExpiry <= 0 || p.Volatility <= 0 {
+		return intrinsic(p, isCall)
+	}
+
+	sqrtT := math.Sqrt(p.TimeToExpiry)
+	d1 := (math.Log(p.Spot/p.Strike) + (p.Rate+p.Volatility*p.Volatility/2)*p.TimeToExpiry) / (p.Volatility * sqrtT)
+	d2 := d1 - p.Volatility*sqrtT
+	discount := math.Exp(-p.Rate * p.TimeToExpiry)
+
+	g := Greeks{
+		Gamma: normPDF(d1) / (p.Spot * p.Volatility * sqrtT),
+		Vega:  p.Spot * normPDF(d1) * sqrtT / 100,
+	}
+	decay := -p.Spot * normPDF(d1) * p.Volatility / (2 * sqrtT)
+	if isCall {
+		g.Price = p.Spot*normCDF(d1) - p.Strike*discount*normCDF(d2)
+		g.Delta = normCDF(d1)
+		g.Theta = (decay - p.Rate*p.Strike*discount*normCDF(d2)) / daysPerYear
+	} else {
+		g.Price = p.Strike*discount*normCDF(-d2) - p.Spot*normCDF(-d1)
+		g.Delta = normCDF(d1) - 1
+		g.Theta = (decay + p.Rate*p.Strike*discount*normCDF(-d2)) / daysPerYear
+	}
+	return g
+}
+
+// ImpliedVolatility : the volatility at which the model gives price, p.Volatility is ignored
+func ImpliedVolatility(p Params, price float64) (float64, error) {
+	if p.TimeToExpiry <= 0 {
+		return 0, errors.New("option has expired")
+	}
+	isCall := p.OptionsType != bybit.OptionsTypePut
+	lower := intrinsic(p, isCall).Price
+	upper := p.Spot
+	if !isCall {
+		upper = p.Strike * math.Exp(-p.Rate*p.TimeToExpiry)
+	}
+	if price <= lower || price >= upper {
+		return 0, errors.New("price is outside the no-arbitrage bounds")
+	}
+
+	// Newton from a reasonable start, bisection whenever a step leaves the bracket
+	low, high := 1e-6, 10.0
+	vol := 0.5
+	for i := 0; i < 100; i++ {
+		p.Volatility = vol
+		g := Compute(p)
+		diff := g.Price - price
+		if math.Abs(diff) < 1e-8 {
+			return vol, nil
+		}
+		if diff > 0 {
+			high = vol
+		} else {
+			low = vol
+		}
+		next := vol - diff/(g.Vega*100)
+		if g.Vega <= 0 || next <= low || next >= high {
+			next = (low + high) / 2
+		}
+		vol = next
+	}
+	return 0, ErrNoConvergence
+}
+
+func intrinsic(p Params, isCall bool) Greeks {
+	discounted := p.Strike * math.Exp(-p.Rate*math.Max(p.TimeToExpiry, 0))
+	if isCall {
+		if p.Spot > discounted {
+			return Greeks{Price: p.Spot - discounted, Delta: 1}
+		}
+		return Greeks{}
+	}
+	if p.Spot < discounted {
+		return Greeks{Price: discounted - p.Spot, Delta: -1}
+	}
+	return Greeks{}
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,90 @@
+package pricing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	p := Params{
+		OptionsType:  bybit.OptionsTypeCall,
+		Spot:         100,
+		Strike:       100,
+		Rate:         0.05,
+		Volatility:   0.2,
+		TimeToExpiry: 1,
+	}
+	call := Compute(p)
+	assert.InDelta(t, 10.4506, call.Price, 1e-4)
+	assert.InDelta(t, 0.6368, call.Delta, 1e-4)
+	assert.InDelta(t, 0.01876, call.Gamma, 1e-5)
+	assert.InDelta(t, 0.3752, call.Vega, 1e-4)
+	assert.InDelta(t, -6.4140/365, call.Theta, 1e-5)
+
+	p.OptionsType = bybit.OptionsTypePut
+	put := Compute(p)
+	assert.InDelta(t, 5.5735, put.Price, 1e-4)
+	assert.InDelta(t, -0.3632, put.Delta, 1e-4)
+	assert.InDelta(t, call.Gamma, put.Gamma, 1e-12)
+
+	p.TimeToExpiry = 0
+	p.Spot = 90
+	assert.InDelta(t, 10, Price(p), 1e-12)
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	for _, vol := range []float64{0.05, 0.2, 0.8, 2.5} {
+		for _, optionsType := range []bybit.OptionsType{bybit.OptionsTypeCall, bybit.OptionsTypePut} {
+			p := Params{OptionsType: optionsType, Spot: 37500, Strike: 40000, Volatility: vol, TimeToExpiry: 30.0 / 365}
+			got, err := ImpliedVolatility(p, Price(p))
+			require.NoError(t, err)
+			assert.InDelta(t, vol, got, 1e-6)
+		}
+	}
+
+	_, err := ImpliedVolatility(Params{OptionsType: bybit.OptionsTypeCall, Spot: 100, Strike: 90, TimeToExpiry: 1}, 5)
+	assert.Error(t, err, "below intrinsic value")
+}
+
+func TestChain(t *testing.T) {
+	now := time.Date(2023, 11, 29, 8, 0, 0, 0, time.UTC)
+	expiry := now.AddDate(0, 0, 30)
+	strike := bybit.RequireDecimal("40000")
+	symbol := bybit.NewOptionSymbol("BTC", expiry, strike, bybit.OptionsTypeCall, "")
+	option, err := bybit.ParseOptionSymbol(symbol)
+	require.NoError(t, err)
+
+	spot := 37500.0
+	mark := Price(Params{OptionsType: bybit.OptionsTypeCall, Spot: spot, Strike: 40000, Volatility: 0.46, TimeToExpiry: YearsToExpiry(now, expiry)})
+	contract := bybit.V5OptionContract{
+		V5OptionSymbol: option,
+		Ticker: &bybit.V5GetTickersOptionDecimal{
+			Symbol:          symbol,
+			UnderlyingPrice: bybit.RequireDecimal("37500"),
+			MarkIv:          bybit.RequireDecimal("0.46"),
+			MarkPrice:       bybit.NewDecimal(decimal.NewFromFloat(mark)),
+		},
+	}
+
+	iv, err := MarkImpliedVolatility(contract, now, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.46, iv, 1e-6)
+
+	single, err := ContractGreeks(contract, now, 0)
+	require.NoError(t, err)
+	portfolio, err := PortfolioGreeks([]Position{{Contract: contract, Size: 2}, {Contract: contract, Size: -0.5}}, now, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, single.Delta*1.5, portfolio.Delta, 1e-12)
+	assert.InDelta(t, single.Vega*1.5, portfolio.Vega, 1e-12)
+
+	orderIv := "0.48"
+	param := bybit.V5CreateOrderParam{Category: bybit.CategoryV5Option, Symbol: symbol, OrderIv: &orderIv}
+	assert.NoError(t, CheckOrderIv(param, contract, 0.05))
+	orderIv = "0.9"
+	assert.Error(t, CheckOrderIv(param, contract, 0.05))
+}
@@ -0,0 +1,257 @@
+// Package prometheus : a bybit.MetricsSink exposing its metrics in the Prometheus text format,
+// without depending on the Prometheus client.
+// The Exporter is an endpoint of its own to scrape, it is not a prometheus.Collector to register in a Registry, e.g.
+//
+//	exporter := prometheus.NewExporter()
+//	client := bybit.NewClient().WithMetrics(exporter)
+//	wsClient := bybit.NewWebsocketClient().WithMetrics(exporter)
+//	http.Handle("/metrics", exporter)
+package prometheus
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oneart-dev/bybit"
+)
+
+// DefaultBuckets : of the latency histograms, in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Exporter : counts in memory what the clients report and writes it on scrape, as an http.Handler.
+// Its families are not part of the default registry of the Prometheus client, serve it on a path of its own.
+//
+//	bybit_requests_total{path,status}                      counter, status is the HTTP status or "error"
+//	bybit_request_errors_total{path}                       counter
+//	bybit_request_duration_seconds{path}                   histogram
+//	bybit_ret_codes_total{path,ret_code}                   counter
+//	bybit_rate_limit{path}                                 gauge
+//	bybit_rate_limit_remaining{path}                       gauge
+//	bybit_websocket_messages_total{path,topic}             counter
+//	bybit_websocket_handler_duration_seconds{path,topic}   histogram
+//	bybit_websocket_reconnects_total{path}                 counter, of V5WebsocketPublicPool only
+//
+// Only a V5WebsocketPublicPool replaces a lost connection, the other websocket services stop on it,
+// so bybit_websocket_reconnects_total stays absent for them.
+type Exporter struct {
+	namespace string
+	buckets   []float64
+
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+var _ bybit.MetricsSink = (*Exporter)(nil)
+
+type kind string
+
+const (
+	counter   kind = "counter"
+	gauge     kind = "gauge"
+	histogram kind = "histogram"
+)
+
+// family : the series of a metric by their label values
+type family struct {
+	name   string
+	help   string
+	kind   kind
+	labels []string
+	series map[string]*series
+}
+
+type series struct {
+	values []string
+	value  float64
+	// counts : per bucket, not cumulative, for histograms
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewExporter : with the bybit namespace and DefaultBuckets
+func NewExporter() *Exporter {
+	return &Exporter{
+		namespace: "bybit",
+		buckets:   DefaultBuckets,
+		families:  map[string]*family{},
+	}
+}
+
+// WithNamespace : the prefix of the metric names
+func (e *Exporter) WithNamespace(namespace string) *Exporter {
+	e.namespace = namespace
+	return e
+}
+
+// WithBuckets : the upper bounds of the latency histograms in seconds, in increasing order
+func (e *Exporter) WithBuckets(buckets []float64) *Exporter {
+	e.buckets = buckets
+	return e
+}
+
+// ObserveRequest :
+func (e *Exporter) ObserveRequest(path string, statusCode, retCode int, latency time.Duration, err error) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.series("requests_total", "REST requests by endpoint and HTTP status.", counter, []string{"path", "status"}, path, status).value++
+	if err != nil {
+		e.series("request_errors_total", "REST requests returning an error by endpoint.", counter, []string{"path"}, path).value++
+	}
+	e.observe(e.series("request_duration_seconds", "REST request latency by endpoint.", histogram, []string{"path"}, path), latency)
+	if statusCode != 0 {
+		e.series("ret_codes_total", "REST responses by endpoint and retCode.", counter, []string{"path", "ret_code"}, path, strconv.Itoa(retCode)).value++
+	}
+}
+
+// SetRateLimit :
+func (e *Exporter) SetRateLimit(path string, limit, remaining int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.series("rate_limit", "Requests allowed per window by endpoint.", gauge, []string{"path"}, path).value = float64(limit)
+	e.series("rate_limit_remaining", "Requests left in the current window by endpoint.", gauge, []string{"path"}, path).value = float64(remaining)
+}
+
+// IncWebsocketMessage :
+func (e *Exporter) IncWebsocketMessage(path, topic string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.series("websocket_messages_total", "Websocket data frames by connection path and topic.", counter, []string{"path", "topic"}, path, topic).value++
+}
+
+// ObserveWebsocketHandler :
+func (e *Exporter) ObserveWebsocketHandler(path, topic string, latency time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observe(e.series("websocket_handler_duration_seconds", "Websocket handler latency by connection path and topic.", histogram, []string{"path", "topic"}, path, topic), latency)
+}
+
+// IncWebsocketReconnect :
+func (e *Exporter) IncWebsocketReconnect(path string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.series("websocket_reconnects_total", "Websocket connections lost and replaced by connection path.", counter, []string{"path"}, path).value++
+}
+
+// series : e.mu must be held
+func (e *Exporter) series(name, help string, k kind, labels []string, values ...string) *series {
+	f, ok := e.families[name]
+	if !ok {
+		f = &family{name: e.namespace + "_" + name, help: help, kind: k, labels: labels, series: map[string]*series{}}
+		e.families[name] = f
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: values}
+		if k == histogram {
+			s.counts = make([]uint64, len(e.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// observe : e.mu must be held
+func (e *Exporter) observe(s *series, latency time.Duration) {
+	seconds := latency.Seconds()
+	for i, bound := range e.buckets {
+		if seconds <= bound {
+			s.counts[i]++
+			break
+		}
+	}
+	s.sum += seconds
+	s.count++
+}
+
+// ServeHTTP : the metrics in the text format
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = e.WriteTo(w)
+}
+
+// WriteTo : the metrics in the text format, families and series in lexical order
+func (e *Exporter) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	e.mu.Lock()
+	names := make([]string, 0, len(e.families))
+	for name := range e.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		e.writeFamily(&b, e.families[name])
+	}
+	e.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (e *Exporter) writeFamily(b *strings.Builder, f *family) {
+	fmt.Fprintf(b, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", f.name, f.kind)
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		if f.kind != histogram {
+			fmt.Fprintf(b, "%s%s %s\n", f.name, labelString(f.labels, s.values, "", ""), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range e.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, labelString(f.labels, s.values, "le", formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", f.name, labelString(f.labels, s.values, "le", "+Inf"), s.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", f.name, labelString(f.labels, s.values, "", ""), formatFloat(s.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", f.name, labelString(f.labels, s.values, "", ""), s.count)
+	}
+}
+
+// labelString : {name="value",...} with the extra label last when given
+func labelString(names, values []string, extraName, extraValue string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, name+`="`+escapeLabel(values[i])+`"`)
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+extraValue+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,65 @@
+package prometheus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter(t *testing.T) {
+	e := NewExporter().WithBuckets([]float64{0.1, 1})
+	e.ObserveRequest("/v5/order/create", 200, 0, 50*time.Millisecond, nil)
+	e.ObserveRequest("/v5/order/create", 200, 10006, 500*time.Millisecond, errors.New("rate limit"))
+	e.ObserveRequest("/v5/order/create", 0, 0, 2*time.Second, errors.New("timeout"))
+	e.SetRateLimit("/v5/order/create", 10, 3)
+	e.IncWebsocketMessage("/v5/public/linear", `tickers."BTCUSDT"`)
+	e.ObserveWebsocketHandler("/v5/public/linear", "tickers.BTCUSDT", time.Millisecond)
+	e.IncWebsocketReconnect("/v5/public/linear")
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4"))
+	body := recorder.Body.String()
+
+	for _, line := range []string{
+		"# TYPE bybit_requests_total counter",
+		`bybit_requests_total{path="/v5/order/create",status="200"} 2`,
+		`bybit_requests_total{path="/v5/order/create",status="error"} 1`,
+		`bybit_request_errors_total{path="/v5/order/create"} 2`,
+		"# TYPE bybit_request_duration_seconds histogram",
+		`bybit_request_duration_seconds_bucket{path="/v5/order/create",le="0.1"} 1`,
+		`bybit_request_duration_seconds_bucket{path="/v5/order/create",le="1"} 2`,
+		`bybit_request_duration_seconds_bucket{path="/v5/order/create",le="+Inf"} 3`,
+		`bybit_request_duration_seconds_sum{path="/v5/order/create"} 2.55`,
+		`bybit_request_duration_seconds_count{path="/v5/order/create"} 3`,
+		`bybit_ret_codes_total{path="/v5/order/create",ret_code="0"} 1`,
+		`bybit_ret_codes_total{path="/v5/order/create",ret_code="10006"} 1`,
+		`bybit_rate_limit{path="/v5/order/create"} 10`,
+		`bybit_rate_limit_remaining{path="/v5/order/create"} 3`,
+		`bybit_websocket_messages_total{path="/v5/public/linear",topic="tickers.\"BTCUSDT\""} 1`,
+		`bybit_websocket_handler_duration_seconds_count{path="/v5/public/linear",topic="tickers.BTCUSDT"} 1`,
+		`bybit_websocket_reconnects_total{path="/v5/public/linear"} 1`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.Less(t, strings.Index(body, "bybit_rate_limit "), strings.Index(body, "bybit_requests_total "), "families in lexical order")
+}
+
+func TestExporterNamespace(t *testing.T) {
+	e := NewExporter().WithNamespace("exchange")
+	e.IncWebsocketReconnect("/v5/public/spot")
+
+	var b strings.Builder
+	_, err := e.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Equal(t, `# HELP exchange_websocket_reconnects_total Websocket connections lost and replaced by connection path.
+# TYPE exchange_websocket_reconnects_total counter
+exchange_websocket_reconnects_total{path="/v5/public/spot"} 1
+`, b.String())
+}
@@ -84,7 +84,7 @@ type SpotQuoteDepthResponse struct {
 
 // SpotQuoteDepthResult :
 type SpotQuoteDepthResult struct {
-	Time int                    `json:"time"`
+	Time MillisTime             `json:"time"`
 	Bids SpotQuoteDepthBidsAsks `json:"bids"`
 	Asks SpotQuoteDepthBidsAsks `json:"asks"`
 }
@@ -151,7 +151,7 @@ type SpotQuoteDepthMergedResponse struct {
 
 // SpotQuoteDepthMergedResult :
 type SpotQuoteDepthMergedResult struct {
-	Time int                    `json:"time"`
+	Time MillisTime             `json:"time"`
 	Bids SpotQuoteDepthBidsAsks `json:"bids"`
 	Asks SpotQuoteDepthBidsAsks `json:"asks"`
 }
@@ -187,10 +187,10 @@ type SpotQuoteTradesResponse struct {
 
 // SpotQuoteTradesResult :
 type SpotQuoteTradesResult struct {
-	Price        string `json:"price"`
-	Time         int    `json:"time"`
-	Qty          string `json:"qty"`
-	IsBuyerMaker bool   `json:"isBuyerMaker"`
+	Price        string     `json:"price"`
+	Time         MillisTime `json:"time"`
+	Qty          string     `json:"qty"`
+	IsBuyerMaker bool       `json:"isBuyerMaker"`
 }
 
 // SpotQuoteTrades :
@@ -240,13 +240,13 @@ func (r *SpotQuoteKlineResult) UnmarshalJSON(data []byte) error {
 		return errors.New("so far len(items) must be 11, please check it on documents")
 	}
 	r.SpotQuoteKline = SpotQuoteKline{
-		StartTime:        int(parsedData[0].(float64)),
+		StartTime:        NewMillisTime(int64(parsedData[0].(float64))),
 		Open:             parsedData[1].(string),
 		High:             parsedData[2].(string),
 		Low:              parsedData[3].(string),
 		Close:            parsedData[4].(string),
 		Volume:           parsedData[5].(string),
-		EndTime:          int(parsedData[6].(float64)),
+		EndTime:          NewMillisTime(int64(parsedData[6].(float64))),
 		QuoteAssetVolume: parsedData[7].(string),
 		Trades:           int(parsedData[8].(float64)),
 		TakerBaseVolume:  parsedData[9].(string),
@@ -257,13 +257,13 @@ func (r *SpotQuoteKlineResult) UnmarshalJSON(data []byte) error {
 
 // SpotQuoteKline :
 type SpotQuoteKline struct {
-	StartTime        int
+	StartTime        MillisTime
 	Open             string
 	High             string
 	Low              string
 	Close            string
 	Volume           string
-	EndTime          int
+	EndTime          MillisTime
 	QuoteAssetVolume string
 	Trades           int
 	TakerBaseVolume  string
@@ -299,16 +299,16 @@ type SpotQuoteTicker24hrResponse struct {
 
 // SpotQuoteTicker24hrResult :
 type SpotQuoteTicker24hrResult struct {
-	Time         int    `json:"time"`
-	Symbol       string `json:"symbol"`
-	BestBidPrice string `json:"bestBidPrice"`
-	BestAskPrice string `json:"bestAskPrice"`
-	LastPrice    string `json:"lastPrice"`
-	OpenPrice    string `json:"openPrice"`
-	HighPrice    string `json:"highPrice"`
-	LowPrice     string `json:"lowPrice"`
-	Volume       string `json:"volume"`
-	QuoteVolume  string `json:"quoteVolume"`
+	Time         MillisTime `json:"time"`
+	Symbol       string     `json:"symbol"`
+	BestBidPrice string     `json:"bestBidPrice"`
+	BestAskPrice string     `json:"bestAskPrice"`
+	LastPrice    string     `json:"lastPrice"`
+	OpenPrice    string     `json:"openPrice"`
+	HighPrice    string     `json:"highPrice"`
+	LowPrice     string     `json:"lowPrice"`
+	Volume       string     `json:"volume"`
+	QuoteVolume  string     `json:"quoteVolume"`
 }
 
 // SpotQuoteTicker24hr :
@@ -373,12 +373,12 @@ type SpotQuoteTickerBookTickerResponse struct {
 
 // SpotQuoteTickerBookTickerResult :
 type SpotQuoteTickerBookTickerResult struct {
-	Symbol   string `json:"symbol"`
-	BidPrice string `json:"bidPrice"`
-	BidQty   string `json:"bidQty"`
-	AskPrice string `json:"askPrice"`
-	AskQty   string `json:"askQty"`
-	Time     int    `json:"time"`
+	Symbol   string     `json:"symbol"`
+	BidPrice string     `json:"bidPrice"`
+	BidQty   string     `json:"bidQty"`
+	AskPrice string     `json:"askPrice"`
+	AskQty   string     `json:"askQty"`
+	Time     MillisTime `json:"time"`
 }
 
 // SpotQuoteTickerBookTicker :
@@ -420,7 +420,7 @@ type SpotPostOrderResult struct {
 	OrderID      string          `json:"orderId"`
 	OrderLinkID  string          `json:"orderLinkId"`
 	Symbol       string          `json:"symbol"`
-	TransactTime string          `json:"transactTime"`
+	TransactTime MillisTime      `json:"transactTime"`
 	Price        string          `json:"price"`
 	OrigQty      string          `json:"origQty"`
 	Type         OrderTypeSpot   `json:"type"`
@@ -448,6 +448,23 @@ func (s *SpotV1Service) SpotPostOrder(param SpotPostOrderParam) (*SpotPostOrderR
 	return &res, nil
 }
 
+// SpotPostOrderDecimal : SpotPostOrder without rounding qty and price through float64.
+// It is not part of SpotV1ServiceI, reach it with client.Spot().V1().(*bybit.SpotV1Service).
+func (s *SpotV1Service) SpotPostOrderDecimal(param SpotPostOrderDecimalParam) (*SpotPostOrderResponse, error) {
+	var res SpotPostOrderResponse
+
+	queryString, err := query.Values(param)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.client.postForm("/spot/v1/order", queryString, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 // SpotGetOrderParam :
 type SpotGetOrderParam struct {
 	OrderID     *string `url:"orderId,omitempty"`
@@ -462,26 +479,26 @@ type SpotGetOrderResponse struct {
 
 // SpotGetOrderResult :
 type SpotGetOrderResult struct {
-	AccountId           string `json:"accountId"`
-	ExchangeId          string `json:"exchangeId"`
-	Symbol              string `json:"symbol"`
-	SymbolName          string `json:"symbolName"`
-	OrderLinkId         string `json:"orderLinkId"`
-	OrderId             string `json:"orderId"`
-	Price               string `json:"price"`
-	OrigQty             string `json:"origQty"`
-	ExecutedQty         string `json:"executedQty"`
-	CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
-	AvgPrice            string `json:"avgPrice"`
-	Status              string `json:"status"`
-	TimeInForce         string `json:"timeInForce"`
-	Type                string `json:"type"`
-	Side                string `json:"side"`
-	StopPrice           string `json:"stopPrice"`
-	IcebergQty          string `json:"icebergQty"`
-	Time                string `json:"time"`
-	UpdateTime          string `json:"updateTime"`
-	IsWorking           bool   `json:"isWorking"`
+	AccountId           string     `json:"accountId"`
+	ExchangeId          string     `json:"exchangeId"`
+	Symbol              string     `json:"symbol"`
+	SymbolName          string     `json:"symbolName"`
+	OrderLinkId         string     `json:"orderLinkId"`
+	OrderId             string     `json:"orderId"`
+	Price               string     `json:"price"`
+	OrigQty             string     `json:"origQty"`
+	ExecutedQty         string     `json:"executedQty"`
+	CummulativeQuoteQty string     `json:"cummulativeQuoteQty"`
+	AvgPrice            string     `json:"avgPrice"`
+	Status              string     `json:"status"`
+	TimeInForce         string     `json:"timeInForce"`
+	Type                string     `json:"type"`
+	Side                string     `json:"side"`
+	StopPrice           string     `json:"stopPrice"`
+	IcebergQty          string     `json:"icebergQty"`
+	Time                MillisTime `json:"time"`
+	UpdateTime          MillisTime `json:"updateTime"`
+	IsWorking           bool       `json:"isWorking"`
 }
 
 // SpotGetOrder :
@@ -514,18 +531,18 @@ type SpotDeleteOrderResponse struct {
 
 // SpotDeleteOrderResult :
 type SpotDeleteOrderResult struct {
-	OrderId      string `json:"orderId"`
-	OrderLinkId  string `json:"orderLinkId"`
-	Symbol       string `json:"symbol"`
-	Status       string `json:"status"`
-	AccountId    string `json:"accountId"`
-	TransactTime string `json:"transactTime"`
-	Price        string `json:"price"`
-	OrigQty      string `json:"origQty"`
-	ExecutedQty  string `json:"executedQty"`
-	TimeInForce  string `json:"timeInForce"`
-	Type         string `json:"type"`
-	Side         string `json:"side"`
+	OrderId      string     `json:"orderId"`
+	OrderLinkId  string     `json:"orderLinkId"`
+	Symbol       string     `json:"symbol"`
+	Status       string     `json:"status"`
+	AccountId    string     `json:"accountId"`
+	TransactTime MillisTime `json:"transactTime"`
+	Price        string     `json:"price"`
+	OrigQty      string     `json:"origQty"`
+	ExecutedQty  string     `json:"executedQty"`
+	TimeInForce  string     `json:"timeInForce"`
+	Type         string     `json:"type"`
+	Side         string     `json:"side"`
 }
 
 // SpotDeleteOrder :
@@ -31,7 +31,7 @@ func TestSpotPostOrder(t *testing.T) {
 				OrderID:      "1037799004578056704",
 				OrderLinkID:  "1638451282020267",
 				Symbol:       string(param.Symbol),
-				TransactTime: "1638451282090",
+				TransactTime: NewMillisTime(1638451282090),
 				Price:        "28383.5",
 				OrigQty:      fmt.Sprintf("%f", param.Qty),
 				Type:         OrderTypeSpotMarket,
@@ -77,7 +77,7 @@ func TestSpotPostOrder(t *testing.T) {
 				OrderID:      "1037799004578056704",
 				OrderLinkID:  "1638451282020267",
 				Symbol:       string(param.Symbol),
-				TransactTime: "1638451282090",
+				TransactTime: NewMillisTime(1638451282090),
 				Price:        "28383.5",
 				OrigQty:      fmt.Sprintf("%f", param.Qty),
 				Type:         OrderTypeSpotMarket,
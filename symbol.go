@@ -0,0 +1,240 @@
+package bybit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSymbol :
+var ErrInvalidSymbol = errors.New("invalid symbol")
+
+// V5SymbolFilter : empty fields match everything
+type V5SymbolFilter struct {
+	Category   CategoryV5
+	BaseCoin   Coin
+	QuoteCoin  Coin
+	SettleCoin Coin
+	// TradableOnly : leave out symbols that are not open for trading
+	TradableOnly bool
+}
+
+// Symbols : live symbols of the registry that match the filter, sorted
+func (r *InstrumentRegistry) Symbols(filter V5SymbolFilter) []SymbolV5 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []SymbolV5{}
+	for category, instruments := range r.instruments {
+		if filter.Category != "" && filter.Category != category {
+			continue
+		}
+		for symbol, instrument := range instruments {
+			if filter.BaseCoin != "" && filter.BaseCoin != instrument.BaseCoin {
+				continue
+			}
+			if filter.QuoteCoin != "" && filter.QuoteCoin != instrument.QuoteCoin {
+				continue
+			}
+			if filter.SettleCoin != "" && filter.SettleCoin != instrument.SettleCoin {
+				continue
+			}
+			if filter.TradableOnly && !instrument.Tradable() {
+				continue
+			}
+			result = append(result, symbol)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return uniqueSymbols(result)
+}
+
+// uniqueSymbols : a sorted slice without repeats, spot and linear share names such as BTCUSDT
+func uniqueSymbols(symbols []SymbolV5) []SymbolV5 {
+	result := symbols[:0]
+	for i, symbol := range symbols {
+		if i == 0 || symbols[i-1] != symbol {
+			result = append(result, symbol)
+		}
+	}
+	return result
+}
+
+// futuresMonthCodes : month codes of dated futures, bybit lists the quarterly H, M, U and Z
+var futuresMonthCodes = map[byte]time.Month{
+	'F': time.January, 'G': time.February, 'H': time.March, 'J': time.April,
+	'K': time.May, 'M': time.June, 'N': time.July, 'Q': time.August,
+	'U': time.September, 'V': time.October, 'X': time.November, 'Z': time.December,
+}
+
+var (
+	futuresCodeSymbolPattern = regexp.MustCompile(`^([A-Z0-9]+?)(USDT|USDC|USD)([FGHJKMNQUVXZ])(\d{2})$`)
+	futuresDateSymbolPattern = regexp.MustCompile(`^([A-Z0-9]+?)(USDT|USDC)?-(\d{1,2}[A-Z]{3}\d{2})$`)
+	optionSymbolPattern      = regexp.MustCompile(`^([A-Z0-9]+)-(\d{1,2}[A-Z]{3}\d{2})-(\d+(?:\.\d+)?)-([CP])(?:-([A-Z]+))?$`)
+)
+
+// deliveryHour : bybit settles dated futures and options at 08:00 UTC
+const deliveryHour = 8
+
+// V5FuturesSymbol : a dated futures symbol, BTCUSDH23, BTC-29DEC23 or BTCUSDT-29DEC23
+type V5FuturesSymbol struct {
+	Symbol    SymbolV5
+	BaseCoin  Coin
+	QuoteCoin Coin
+	// Delivery : exact for the dated form, the last Friday of the month at 08:00 UTC for the month code form.
+	// V5Instrument.DeliveryTime is authoritative.
+	Delivery time.Time
+}
+
+// ParseFuturesSymbol :
+func ParseFuturesSymbol(symbol SymbolV5) (V5FuturesSymbol, error) {
+	s := string(symbol)
+	if m := futuresCodeSymbolPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[4])
+		return V5FuturesSymbol{
+			Symbol:    symbol,
+			BaseCoin:  Coin(m[1]),
+			QuoteCoin: Coin(m[2]),
+			Delivery:  lastFriday(2000+year, futuresMonthCodes[m[3][0]]),
+		}, nil
+	}
+	if m := futuresDateSymbolPattern.FindStringSubmatch(s); m != nil {
+		delivery, err := parseSymbolDate(m[3])
+		if err != nil {
+			return V5FuturesSymbol{}, fmt.Errorf("%s: %w", symbol, err)
+		}
+		// without a quote coin in the symbol, it is one of the USDC futures
+		quoteCoin := Coin(CoinUSDC)
+		if m[2] != "" {
+			quoteCoin = Coin(m[2])
+		}
+		return V5FuturesSymbol{
+			Symbol:    symbol,
+			BaseCoin:  Coin(m[1]),
+			QuoteCoin: quoteCoin,
+			Delivery:  delivery,
+		}, nil
+	}
+	return V5FuturesSymbol{}, fmt.Errorf("%s is not a dated futures symbol: %w", symbol, ErrInvalidSymbol)
+}
+
+// V5OptionSymbol : BTC-29DEC23-40000-C, optionally followed by the settle coin
+type V5OptionSymbol struct {
+	Symbol      SymbolV5
+	BaseCoin    Coin
+	Expiry      time.Time
+	Strike      Decimal
+	OptionsType OptionsType
+	// SettleCoin : USDC unless the symbol names another one
+	SettleCoin Coin
+}
+
+// ParseOptionSymbol :
+func ParseOptionSymbol(symbol SymbolV5) (V5OptionSymbol, error) {
+	m := optionSymbolPattern.FindStringSubmatch(string(symbol))
+	if m == nil {
+		return V5OptionSymbol{}, fmt.Errorf("%s is not an option symbol: %w", symbol, ErrInvalidSymbol)
+	}
+	expiry, err := parseSymbolDate(m[2])
+	if err != nil {
+		return V5OptionSymbol{}, fmt.Errorf("%s: %w", symbol, err)
+	}
+	strike, err := NewDecimalFromString(m[3])
+	if err != nil {
+		return V5OptionSymbol{}, fmt.Errorf("%s: %w", symbol, err)
+	}
+	optionsType := OptionsTypeCall
+	if m[4] == "P" {
+		optionsType = OptionsTypePut
+	}
+	settleCoin := Coin(CoinUSDC)
+	if m[5] != "" {
+		settleCoin = Coin(m[5])
+	}
+	return V5OptionSymbol{
+		Symbol:      symbol,
+		BaseCoin:    Coin(m[1]),
+		Expiry:      expiry,
+		Strike:      strike,
+		OptionsType: optionsType,
+		SettleCoin:  settleCoin,
+	}, nil
+}
+
+// NewOptionSymbol : the symbol of the option, the settle coin is left out when it is USDC
+func NewOptionSymbol(baseCoin Coin, expiry time.Time, strike Decimal, optionsType OptionsType, settleCoin Coin) SymbolV5 {
+	side := "C"
+	if optionsType == OptionsTypePut {
+		side = "P"
+	}
+	s := fmt.Sprintf("%s-%s-%s-%s", baseCoin, formatSymbolDate(expiry), strike.Decimal.String(), side)
+	if settleCoin != "" && settleCoin != CoinUSDC {
+		s += "-" + string(settleCoin)
+	}
+	return SymbolV5(s)
+}
+
+// parseSymbolDate : 29DEC23 at 08:00 UTC
+func parseSymbolDate(s string) (time.Time, error) {
+	date, err := time.Parse("2Jan06", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.Add(deliveryHour * time.Hour), nil
+}
+
+// formatSymbolDate : 29DEC23
+func formatSymbolDate(t time.Time) string {
+	return strings.ToUpper(t.UTC().Format("2Jan06"))
+}
+
+// lastFriday : of the month at 08:00 UTC
+func lastFriday(year int, month time.Month) time.Time {
+	t := time.Date(year, month+1, 0, deliveryHour, 0, 0, 0, time.UTC)
+	for t.Weekday() != time.Friday {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
+
+// V5 :
+func (s SymbolInverse) V5() SymbolV5 {
+	return SymbolV5(s)
+}
+
+// V5 :
+func (s SymbolUSDT) V5() SymbolV5 {
+	return SymbolV5(s)
+}
+
+// V5 :
+func (s SymbolSpot) V5() SymbolV5 {
+	return SymbolV5(s)
+}
+
+// Inverse : the legacy inverse perpetual or futures symbol, BTCUSD or BTCUSDH23
+func (s SymbolV5) Inverse() (SymbolInverse, error) {
+	if strings.HasSuffix(string(s), "USD") {
+		return SymbolInverse(s), nil
+	}
+	if f, err := ParseFuturesSymbol(s); err == nil && f.QuoteCoin == "USD" {
+		return SymbolInverse(s), nil
+	}
+	return "", fmt.Errorf("%s is not an inverse symbol: %w", s, ErrInvalidSymbol)
+}
+
+// USDT : the legacy USDT perpetual symbol
+func (s SymbolV5) USDT() (SymbolUSDT, error) {
+	if len(s) > len("USDT") && strings.HasSuffix(string(s), "USDT") {
+		return SymbolUSDT(s), nil
+	}
+	return "", fmt.Errorf("%s is not a USDT perpetual symbol: %w", s, ErrInvalidSymbol)
+}
+
+// Spot : the legacy spot symbol, any v5 spot symbol is one
+func (s SymbolV5) Spot() SymbolSpot {
+	return SymbolSpot(s)
+}
@@ -0,0 +1,104 @@
+package bybit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFuturesSymbol(t *testing.T) {
+	t.Run("month code", func(t *testing.T) {
+		got, err := ParseFuturesSymbol(SymbolV5BTCUSDZ23)
+		require.NoError(t, err)
+		assert.Equal(t, Coin("BTC"), got.BaseCoin)
+		assert.Equal(t, Coin("USD"), got.QuoteCoin)
+		assert.Equal(t, time.Date(2023, 12, 29, 8, 0, 0, 0, time.UTC), got.Delivery)
+
+		got, err = ParseFuturesSymbol(SymbolV5("ETHUSDTH24"))
+		require.NoError(t, err)
+		assert.Equal(t, Coin("USDT"), got.QuoteCoin)
+		assert.Equal(t, time.Date(2024, 3, 29, 8, 0, 0, 0, time.UTC), got.Delivery)
+	})
+	t.Run("date", func(t *testing.T) {
+		got, err := ParseFuturesSymbol(SymbolV5("BTC-29DEC23"))
+		require.NoError(t, err)
+		assert.Equal(t, Coin("BTC"), got.BaseCoin)
+		assert.Equal(t, Coin("USDC"), got.QuoteCoin)
+		assert.Equal(t, time.Date(2023, 12, 29, 8, 0, 0, 0, time.UTC), got.Delivery)
+
+		got, err = ParseFuturesSymbol(SymbolV5("BTCUSDT-27DEC24"))
+		require.NoError(t, err)
+		assert.Equal(t, Coin("BTC"), got.BaseCoin)
+		assert.Equal(t, Coin("USDT"), got.QuoteCoin)
+		assert.Equal(t, time.Date(2024, 12, 27, 8, 0, 0, 0, time.UTC), got.Delivery)
+
+		got, err = ParseFuturesSymbol(SymbolV5("ETHUSDC-28MAR25"))
+		require.NoError(t, err)
+		assert.Equal(t, Coin("ETH"), got.BaseCoin)
+		assert.Equal(t, Coin("USDC"), got.QuoteCoin)
+
+		got, err = ParseFuturesSymbol(SymbolV5("1000PEPEUSDT-27JUN25"))
+		require.NoError(t, err)
+		assert.Equal(t, Coin("1000PEPE"), got.BaseCoin)
+		assert.Equal(t, Coin("USDT"), got.QuoteCoin)
+	})
+	t.Run("perpetual", func(t *testing.T) {
+		_, err := ParseFuturesSymbol(SymbolV5BTCUSDT)
+		assert.True(t, errors.Is(err, ErrInvalidSymbol))
+	})
+}
+
+func TestParseOptionSymbol(t *testing.T) {
+	got, err := ParseOptionSymbol(SymbolV5("BTC-29DEC23-40000-C"))
+	require.NoError(t, err)
+	assert.Equal(t, Coin("BTC"), got.BaseCoin)
+	assert.Equal(t, time.Date(2023, 12, 29, 8, 0, 0, 0, time.UTC), got.Expiry)
+	assert.Equal(t, "40000", got.Strike.String())
+	assert.Equal(t, OptionsTypeCall, got.OptionsType)
+	assert.Equal(t, Coin("USDC"), got.SettleCoin)
+	assert.Equal(t, got.Symbol, NewOptionSymbol(got.BaseCoin, got.Expiry, got.Strike, got.OptionsType, got.SettleCoin))
+
+	got, err = ParseOptionSymbol(SymbolV5("ETH-5JAN24-2250-P-USDT"))
+	require.NoError(t, err)
+	assert.Equal(t, OptionsTypePut, got.OptionsType)
+	assert.Equal(t, Coin("USDT"), got.SettleCoin)
+	assert.Equal(t, got.Symbol, NewOptionSymbol(got.BaseCoin, got.Expiry, got.Strike, got.OptionsType, got.SettleCoin))
+
+	_, err = ParseOptionSymbol(SymbolV5("BTC-29DEC23-40000"))
+	assert.True(t, errors.Is(err, ErrInvalidSymbol))
+}
+
+func TestSymbolConversion(t *testing.T) {
+	assert.Equal(t, SymbolV5BTCUSD, SymbolInverseBTCUSD.V5())
+	assert.Equal(t, SymbolV5BTCUSDT, SymbolUSDTBTC.V5())
+	assert.Equal(t, SymbolV5BTCUSDT, SymbolSpotBTCUSDT.V5())
+
+	inverse, err := SymbolV5BTCUSDH23.Inverse()
+	require.NoError(t, err)
+	assert.Equal(t, SymbolInverse("BTCUSDH23"), inverse)
+	_, err = SymbolV5BTCUSDT.Inverse()
+	assert.Error(t, err)
+
+	usdt, err := SymbolV5ETHUSDT.USDT()
+	require.NoError(t, err)
+	assert.Equal(t, SymbolUSDTETH, usdt)
+	_, err = SymbolV5BTCUSD.USDT()
+	assert.Error(t, err)
+}
+
+func TestInstrumentRegistry_Symbols(t *testing.T) {
+	registry := NewInstrumentRegistry(NewClient()).Set(
+		V5Instrument{Category: CategoryV5Linear, Symbol: SymbolV5BTCUSDT, Status: InstrumentStatusTrading, BaseCoin: "BTC", QuoteCoin: "USDT", SettleCoin: "USDT"},
+		V5Instrument{Category: CategoryV5Linear, Symbol: SymbolV5ETHUSDT, Status: InstrumentStatusSettling, BaseCoin: "ETH", QuoteCoin: "USDT", SettleCoin: "USDT"},
+		V5Instrument{Category: CategoryV5Linear, Symbol: SymbolV5BTCPERP, Status: InstrumentStatusTrading, BaseCoin: "BTC", QuoteCoin: "USD", SettleCoin: "USDC"},
+		V5Instrument{Category: CategoryV5Spot, Symbol: SymbolV5BTCUSDT, Status: InstrumentStatusTrading, BaseCoin: "BTC", QuoteCoin: "USDT"},
+	)
+
+	assert.Equal(t, []SymbolV5{SymbolV5BTCPERP, SymbolV5BTCUSDT, SymbolV5ETHUSDT}, registry.Symbols(V5SymbolFilter{}))
+	assert.Equal(t, []SymbolV5{SymbolV5BTCUSDT}, registry.Symbols(V5SymbolFilter{Category: CategoryV5Spot}))
+	assert.Equal(t, []SymbolV5{SymbolV5BTCPERP, SymbolV5BTCUSDT}, registry.Symbols(V5SymbolFilter{Category: CategoryV5Linear, BaseCoin: "BTC"}))
+	assert.Equal(t, []SymbolV5{SymbolV5BTCUSDT}, registry.Symbols(V5SymbolFilter{SettleCoin: "USDT", TradableOnly: true}))
+}
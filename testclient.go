@@ -1,6 +1,8 @@
 package bybit
 
-import "os"
+import (
+	"os"
+)
 
 const (
 	// TestNetBaseURL :
@@ -12,16 +14,14 @@ type TestClient struct {
 	*Client
 }
 
-// NewTestClient :
-func NewTestClient() *TestClient {
+// NewTestClient : for the test net, configured by opts
+func NewTestClient(opts ...Option) *TestClient {
 	return &TestClient{
-		Client: &Client{
-			baseURL: TestNetBaseURL,
-		},
+		Client: NewClient(append([]Option{WithBaseURLOption(TestNetBaseURL)}, opts...)...),
 	}
 }
 
-// WithAuthFromEnv :
+// WithAuthFromEnv : a copy of the client authenticated with BYBIT_TEST_KEY and BYBIT_TEST_SECRET
 func (c *TestClient) WithAuthFromEnv() *TestClient {
 	key, ok := os.LookupEnv("BYBIT_TEST_KEY")
 	if !ok {
@@ -31,15 +31,10 @@ func (c *TestClient) WithAuthFromEnv() *TestClient {
 	if !ok {
 		panic("need BYBIT_TEST_SECRET as environment variable")
 	}
-	c.key = key
-	c.secret = secret
-
-	return c
+	return &TestClient{c.Clone(WithAuthOption(key, secret))}
 }
 
-// WithBaseURL :
+// WithBaseURL : a copy of the client sending to url
 func (c *TestClient) WithBaseURL(url string) *TestClient {
-	c.baseURL = url
-
-	return c
+	return &TestClient{c.Clone(WithBaseURLOption(url))}
 }
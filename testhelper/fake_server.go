@@ -0,0 +1,294 @@
+package testhelper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// FakeKey : the api key FakeServer accepts by default
+	FakeKey = "test-key"
+	// FakeSecret : the api secret FakeServer verifies X-BAPI-SIGN with by default
+	FakeSecret = "test-secret"
+)
+
+// FakeServer : a scriptable bybit, routes answer in the order their responses were scripted
+// and every request is recorded, e.g.
+//
+//	server, teardown := testhelper.NewFakeServer()
+//	defer teardown()
+//	server.Handle(http.MethodGet, "/v5/market/tickers").
+//		WithQuery("symbol", "BTCUSDT").
+//		RespondFile("v5/market/tickers.json").
+//		RespondRateLimit().
+//		RespondRetCode(10001, "params error")
+//	client := bybit.NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret)
+type FakeServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	key      string
+	secret   string
+	routes   []*Route
+	requests []RecordedRequest
+}
+
+// RecordedRequest : as received, before the route is matched
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Route : requests of Method to Path whose query has the wanted values and that match every matcher
+type Route struct {
+	Method string
+	Path   string
+
+	query     url.Values
+	matchers  []func(RecordedRequest) bool
+	private   bool
+	responses []FakeResponse
+	served    int
+}
+
+// FakeResponse : one scripted answer
+type FakeResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// NewFakeServer : started, verifying signatures with FakeKey and FakeSecret
+func NewFakeServer() (*FakeServer, func()) {
+	s := &FakeServer{key: FakeKey, secret: FakeSecret}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s, s.Close
+}
+
+// WithAuth : the api key and secret requests are verified with
+func (s *FakeServer) WithAuth(key, secret string) *FakeServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+	s.secret = secret
+	return s
+}
+
+// Handle : adds a route, the last added route that matches a request answers it
+func (s *FakeServer) Handle(method, path string) *Route {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	route := &Route{Method: method, Path: path, query: url.Values{}}
+	s.routes = append(s.routes, route)
+	return route
+}
+
+// Requests : all received so far, oldest first
+func (s *FakeServer) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// RequestsTo : those of method to path
+func (s *FakeServer) RequestsTo(method, path string) []RecordedRequest {
+	var list []RecordedRequest
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			list = append(list, req)
+		}
+	}
+	return list
+}
+
+// WithQuery : the query must have value for key, among others
+func (r *Route) WithQuery(key, value string) *Route {
+	r.query.Add(key, value)
+	return r
+}
+
+// Match : the request must satisfy f too, e.g. to look at the JSON body
+func (r *Route) Match(f func(RecordedRequest) bool) *Route {
+	r.matchers = append(r.matchers, f)
+	return r
+}
+
+// Private : requests must be signed, even without X-BAPI-API-KEY
+func (r *Route) Private() *Route {
+	r.private = true
+	return r
+}
+
+// Respond : scripts the next answer, the last one is repeated once the script is played
+func (r *Route) Respond(status int, body []byte) *Route {
+	r.responses = append(r.responses, FakeResponse{Status: status, Header: http.Header{}, Body: body})
+	return r
+}
+
+// RespondJSON : v marshalled
+func (r *Route) RespondJSON(status int, v interface{}) *Route {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return r.Respond(status, body)
+}
+
+// RespondFile : a golden response, e.g. "v5/market/tickers.json"
+func (r *Route) RespondFile(name string) *Route {
+	return r.Respond(http.StatusOK, Golden(name))
+}
+
+// RespondRetCode : a v5 error with HTTP 200 like bybit does
+func (r *Route) RespondRetCode(retCode int, retMsg string) *Route {
+	return r.RespondJSON(http.StatusOK, v5Error(retCode, retMsg))
+}
+
+// RespondRateLimit : retCode 10006 with the limit headers saying the limit resets in a second
+func (r *Route) RespondRateLimit() *Route {
+	r.RespondRetCode(10006, "Too many visits!")
+	header := r.responses[len(r.responses)-1].Header
+	header.Set("X-Bapi-Limit", "10")
+	header.Set("X-Bapi-Limit-Status", "0")
+	header.Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(time.Now().Add(time.Second).UnixNano()/int64(time.Millisecond), 10))
+	return r
+}
+
+func v5Error(retCode int, retMsg string) map[string]interface{} {
+	return map[string]interface{}{
+		"retCode":    retCode,
+		"retMsg":     retMsg,
+		"result":     map[string]interface{}{},
+		"retExtInfo": map[string]interface{}{},
+		"time":       time.Now().UnixNano() / int64(time.Millisecond),
+	}
+}
+
+func (r *Route) matches(req RecordedRequest) bool {
+	if r.Method != req.Method || r.Path != req.Path {
+		return false
+	}
+	for key, values := range r.query {
+		for _, value := range values {
+			if !contains(req.Query[key], value) {
+				return false
+			}
+		}
+	}
+	for _, match := range r.matchers {
+		if !match(req) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// next : the response to serve, nil when nothing is scripted
+func (r *Route) next() *FakeResponse {
+	if len(r.responses) == 0 {
+		return nil
+	}
+	i := r.served
+	if i >= len(r.responses) {
+		i = len(r.responses) - 1
+	}
+	r.served++
+	return &r.responses[i]
+}
+
+func (s *FakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	req := RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	var route *Route
+	for i := len(s.routes) - 1; i >= 0; i-- {
+		if s.routes[i].matches(req) {
+			route = s.routes[i]
+			break
+		}
+	}
+	var (
+		response *FakeResponse
+		retCode  int
+		retMsg   string
+	)
+	if route != nil && (route.private || req.Header.Get("X-BAPI-API-KEY") != "") {
+		retCode, retMsg = verify(req, r.URL.RawQuery, s.key, s.secret)
+	}
+	if route != nil && retCode == 0 {
+		response = route.next()
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if retCode != 0 {
+		res, _ := json.Marshal(v5Error(retCode, retMsg))
+		_, _ = w.Write(res)
+		return
+	}
+	if response == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	for name, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.WriteHeader(response.Status)
+	_, _ = w.Write(response.Body)
+}
+
+// verify : the v5 signature, the HMAC-SHA256 of timestamp, api key, recv window and the query or the body
+func verify(req RecordedRequest, rawQuery, key, secret string) (int, string) {
+	if req.Header.Get("X-BAPI-API-KEY") != key {
+		return 10003, "API key is invalid."
+	}
+	timestamp := req.Header.Get("X-BAPI-TIMESTAMP")
+	if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return 10002, "invalid request, please check your server timestamp or recv_window param"
+	}
+	payload := rawQuery
+	if req.Method != http.MethodGet {
+		payload = string(req.Body)
+	}
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + key + req.Header.Get("X-BAPI-RECV-WINDOW") + payload))
+	if !hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(req.Header.Get("X-BAPI-SIGN"))) {
+		return 10004, "error sign!"
+	}
+	return 0, ""
+}
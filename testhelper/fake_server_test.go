@@ -0,0 +1,173 @@
+package testhelper_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/oneart-dev/bybit"
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeServerGolden(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+
+	v5 := bybit.NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).V5()
+	symbol := bybit.SymbolV5BTCUSDT
+	linear := bybit.CategoryV5Linear
+	orderID := "1321003749386327552"
+
+	calls := map[string]func() error{
+		"kline": func() error {
+			_, err := v5.Market().GetKline(bybit.V5GetKlineParam{Category: linear, Symbol: symbol, Interval: bybit.Interval60})
+			return err
+		},
+		"mark price kline": func() error {
+			_, err := v5.Market().GetMarkPriceKline(bybit.V5GetMarkPriceKlineParam{Category: linear, Symbol: symbol, Interval: bybit.Interval60})
+			return err
+		},
+		"index price kline": func() error {
+			_, err := v5.Market().GetIndexPriceKline(bybit.V5GetIndexPriceKlineParam{Category: linear, Symbol: symbol, Interval: bybit.Interval60})
+			return err
+		},
+		"premium index price kline": func() error {
+			_, err := v5.Market().GetPremiumIndexPriceKline(bybit.V5GetPremiumIndexPriceKlineParam{Category: linear, Symbol: symbol, Interval: bybit.Interval60})
+			return err
+		},
+		"instruments info": func() error {
+			res, err := v5.Market().GetInstrumentsInfo(bybit.V5GetInstrumentsInfoParam{Category: linear})
+			if err == nil && res.Result.LinearInverse.List[0].Symbol != symbol {
+				err = errors.New("unexpected symbol")
+			}
+			return err
+		},
+		"orderbook": func() error {
+			_, err := v5.Market().GetOrderbook(bybit.V5GetOrderbookParam{Category: linear, Symbol: symbol})
+			return err
+		},
+		"tickers": func() error {
+			_, err := v5.Market().GetTickers(bybit.V5GetTickersParam{Category: linear})
+			return err
+		},
+		"create order": func() error {
+			_, err := v5.Order().CreateOrder(bybit.V5CreateOrderParam{Category: linear, Symbol: symbol, Side: bybit.SideBuy, OrderType: bybit.OrderTypeMarket, Qty: "0.1"})
+			return err
+		},
+		"cancel order": func() error {
+			_, err := v5.Order().CancelOrder(bybit.V5CancelOrderParam{Category: linear, Symbol: symbol, OrderID: &orderID})
+			return err
+		},
+		"open orders": func() error {
+			_, err := v5.Order().GetOpenOrders(bybit.V5GetOpenOrdersParam{Category: linear})
+			return err
+		},
+		"order history": func() error {
+			_, err := v5.Order().GetOrderList(bybit.V5GetOrderListParam{Category: linear})
+			return err
+		},
+		"executions": func() error {
+			_, err := v5.Order().GetExecutionList(bybit.V5GetExecutionListParam{Category: linear})
+			return err
+		},
+		"closed pnl": func() error {
+			_, err := v5.Order().GetClosedPnl(bybit.V5GetClosedPnlParam{Category: linear})
+			return err
+		},
+		"positions": func() error {
+			_, err := v5.Position().GetPositionInfo(bybit.V5GetPositionInfoParam{Category: linear, Symbol: &symbol})
+			return err
+		},
+		"set leverage": func() error {
+			_, err := v5.Position().SetLeverage(bybit.V5SetLeverageParam{Category: linear, Symbol: symbol, BuyLeverage: "10", SellLeverage: "10"})
+			return err
+		},
+		"wallet balance": func() error {
+			_, err := v5.Account().GetWalletBalance(bybit.AccountTypeUnified, nil)
+			return err
+		},
+		"api key": func() error {
+			_, err := v5.User().GetAPIKey()
+			return err
+		},
+	}
+	for name, call := range calls {
+		assert.NoError(t, call(), name)
+	}
+	assert.Len(t, server.Requests(), len(calls))
+}
+
+func TestFakeServerScript(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.Handle(http.MethodGet, "/v5/market/tickers").
+		WithQuery("symbol", "BTCUSDT").
+		RespondFile("v5/market/tickers.json").
+		RespondRateLimit().
+		RespondRetCode(10001, "params error")
+
+	market := bybit.NewTestClient().WithBaseURL(server.URL).V5().Market()
+	symbol := bybit.SymbolV5BTCUSDT
+	param := bybit.V5GetTickersParam{Category: bybit.CategoryV5Linear, Symbol: &symbol}
+
+	res, err := market.GetTickers(param)
+	require.NoError(t, err)
+	assert.Equal(t, "38089.9", res.Result.LinearInverse.List[0].LastPrice)
+
+	_, err = market.GetTickers(param)
+	var rateLimit *bybit.RateLimitError
+	assert.True(t, errors.As(err, &rateLimit), "got %v", err)
+
+	for i := 0; i < 2; i++ {
+		_, err = market.GetTickers(param)
+		var errorResponse *bybit.ErrorResponse
+		require.True(t, errors.As(err, &errorResponse), "got %v", err)
+		assert.Equal(t, 10001, errorResponse.RetCode, "the last response is repeated")
+	}
+
+	_, err = market.GetTickers(bybit.V5GetTickersParam{Category: bybit.CategoryV5Linear})
+	assert.ErrorIs(t, err, bybit.ErrPathNotFound, "the query does not match")
+
+	requests := server.RequestsTo(http.MethodGet, "/v5/market/tickers")
+	require.Len(t, requests, 5)
+	assert.Equal(t, "linear", requests[0].Query.Get("category"))
+}
+
+func TestFakeServerSignature(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+	server.Handle(http.MethodPost, "/v5/order/create").
+		Match(func(req testhelper.RecordedRequest) bool {
+			var body map[string]interface{}
+			return json.Unmarshal(req.Body, &body) == nil && body["side"] == "Sell"
+		}).
+		RespondRetCode(110007, "ab not enough for new order")
+
+	order := func(key, secret string, side bybit.Side) error {
+		_, err := bybit.NewTestClient().WithBaseURL(server.URL).WithAuth(key, secret).V5().Order().CreateOrder(bybit.V5CreateOrderParam{
+			Category: bybit.CategoryV5Linear, Symbol: bybit.SymbolV5BTCUSDT, Side: side, OrderType: bybit.OrderTypeMarket, Qty: "0.1",
+		})
+		return err
+	}
+	retCode := func(err error) int {
+		var errorResponse *bybit.ErrorResponse
+		if errors.As(err, &errorResponse) {
+			return errorResponse.RetCode
+		}
+		return 0
+	}
+
+	assert.NoError(t, order(testhelper.FakeKey, testhelper.FakeSecret, bybit.SideBuy))
+	assert.Equal(t, 110007, retCode(order(testhelper.FakeKey, testhelper.FakeSecret, bybit.SideSell)))
+	assert.Equal(t, 10004, retCode(order(testhelper.FakeKey, "wrong", bybit.SideBuy)))
+	assert.Equal(t, 10003, retCode(order("wrong", testhelper.FakeSecret, bybit.SideBuy)))
+
+	server.WithAuth("other-key", "other-secret")
+	assert.NoError(t, order("other-key", "other-secret", bybit.SideBuy))
+	assert.Len(t, server.Requests(), 5)
+}
@@ -0,0 +1,385 @@
+package testhelper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// FakeWebsocketServer : speaks the v5 protocol under /v5/ and the spot v1 one under /spot/.
+// It acknowledges subscribe, unsubscribe, auth and ping operations like bybit does and pushes messages
+// to the connections subscribed to a topic. Topics are the v5 args, e.g. "tickers.BTCUSDT",
+// and for spot v1 the topic and the symbol joined by a dot, e.g. "trade.BTCUSDT".
+type FakeWebsocketServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	key       string
+	secret    string
+	pongDelay time.Duration
+	conns     []*fakeWebsocketConn
+	received  []WebsocketFrame
+	lastID    int
+}
+
+// WebsocketFrame : a text frame a client sent
+type WebsocketFrame struct {
+	Path    string
+	Message []byte
+}
+
+type fakeWebsocketConn struct {
+	id     string
+	path   string
+	spot   bool
+	conn   *websocket.Conn
+	topics map[string]bool
+
+	writeMu sync.Mutex
+}
+
+// NewFakeWebsocketServer : started, URL is ws://, auth is verified with FakeKey and FakeSecret
+func NewFakeWebsocketServer() (*FakeWebsocketServer, func()) {
+	s := &FakeWebsocketServer{key: FakeKey, secret: FakeSecret}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = makeWsProtocol(s.URL)
+	return s, func() {
+		s.Disconnect()
+		s.Close()
+	}
+}
+
+// WithAuth : the api key and secret auth operations are verified with
+func (s *FakeWebsocketServer) WithAuth(key, secret string) *FakeWebsocketServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+	s.secret = secret
+	return s
+}
+
+// WithPongDelay : pongs, to ping frames and to ping operations, are sent after delay
+func (s *FakeWebsocketServer) WithPongDelay(delay time.Duration) *FakeWebsocketServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pongDelay = delay
+	return s
+}
+
+// Publish : sends message to every connection subscribed to topic, returns how many there were
+func (s *FakeWebsocketServer) Publish(topic string, message []byte) int {
+	n := 0
+	for _, c := range s.connections() {
+		if c.subscribed(s, topic) {
+			if c.write(websocket.TextMessage, message) == nil {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// PublishJSON : v marshalled
+func (s *FakeWebsocketServer) PublishJSON(topic string, v interface{}) int {
+	message, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return s.Publish(topic, message)
+}
+
+// Broadcast : sends message to every connection whatever it subscribed to, e.g. a malformed frame
+func (s *FakeWebsocketServer) Broadcast(message []byte) {
+	for _, c := range s.connections() {
+		_ = c.write(websocket.TextMessage, message)
+	}
+}
+
+// Disconnect : drops every connection without a close frame, like a network failure
+func (s *FakeWebsocketServer) Disconnect() {
+	s.mu.Lock()
+	conns := s.conns
+	s.conns = nil
+	s.mu.Unlock()
+	for _, c := range conns {
+		_ = c.conn.UnderlyingConn().Close()
+	}
+}
+
+// CloseConnections : closes every connection with a close frame of code
+func (s *FakeWebsocketServer) CloseConnections(code int) {
+	for _, c := range s.connections() {
+		_ = c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""), time.Now().Add(time.Second))
+	}
+}
+
+// Connections : currently open
+func (s *FakeWebsocketServer) Connections() int {
+	return len(s.connections())
+}
+
+// Topics : subscribed by any connection, in lexical order
+func (s *FakeWebsocketServer) Topics() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	set := map[string]bool{}
+	for _, c := range s.conns {
+		for topic := range c.topics {
+			set[topic] = true
+		}
+	}
+	topics := make([]string, 0, len(set))
+	for topic := range set {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// WaitSubscribed : until a connection subscribes to topic, subscribing being asynchronous
+func (s *FakeWebsocketServer) WaitSubscribed(topic string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		for _, t := range s.Topics() {
+			if t == topic {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s not subscribed within %s", topic, timeout)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Received : the text frames of the clients, oldest first
+func (s *FakeWebsocketServer) Received() []WebsocketFrame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]WebsocketFrame(nil), s.received...)
+}
+
+func (s *FakeWebsocketServer) connections() []*fakeWebsocketConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*fakeWebsocketConn(nil), s.conns...)
+}
+
+func (s *FakeWebsocketServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/v5/") && !strings.HasPrefix(r.URL.Path, "/spot/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.lastID++
+	c := &fakeWebsocketConn{
+		id:     fmt.Sprintf("fake-%d", s.lastID),
+		path:   r.URL.Path,
+		spot:   strings.HasPrefix(r.URL.Path, "/spot/"),
+		conn:   conn,
+		topics: map[string]bool{},
+	}
+	s.conns = append(s.conns, c)
+	s.mu.Unlock()
+
+	conn.SetPingHandler(func(data string) error {
+		s.pong(func() {
+			_ = conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		return nil
+	})
+
+	defer s.remove(c)
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.received = append(s.received, WebsocketFrame{Path: c.path, Message: message})
+		s.mu.Unlock()
+		if c.spot {
+			s.handleSpotV1(c, message)
+		} else {
+			s.handleV5(c, message)
+		}
+	}
+}
+
+func (s *FakeWebsocketServer) remove(c *fakeWebsocketConn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, conn := range s.conns {
+		if conn == c {
+			s.conns = append(s.conns[:i], s.conns[i+1:]...)
+			break
+		}
+	}
+	_ = c.conn.Close()
+}
+
+// pong : now or after the pong delay, without holding up the reads
+func (s *FakeWebsocketServer) pong(send func()) {
+	s.mu.Lock()
+	delay := s.pongDelay
+	s.mu.Unlock()
+	if delay == 0 {
+		send()
+		return
+	}
+	time.AfterFunc(delay, send)
+}
+
+func (c *fakeWebsocketConn) write(messageType int, message []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(messageType, message)
+}
+
+func (c *fakeWebsocketConn) writeJSON(v interface{}) {
+	message, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_ = c.write(websocket.TextMessage, message)
+}
+
+func (c *fakeWebsocketConn) subscribed(s *FakeWebsocketServer, topic string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return c.topics[topic]
+}
+
+// setTopic : false when it was already in that state
+func (s *FakeWebsocketServer) setTopic(c *fakeWebsocketConn, topic string, subscribed bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.topics[topic] == subscribed {
+		return false
+	}
+	if subscribed {
+		c.topics[topic] = true
+	} else {
+		delete(c.topics, topic)
+	}
+	return true
+}
+
+// verifyAuth : the signature of "GET/realtime" and expires
+func (s *FakeWebsocketServer) verifyAuth(key string, expires int64, signature string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := hmac.New(sha256.New, []byte(s.secret))
+	h.Write([]byte(fmt.Sprintf("GET/realtime%d", expires)))
+	return key == s.key && hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(signature))
+}
+
+// authArgs : key, expires and signature
+func authArgs(args []interface{}) (string, int64, string, bool) {
+	if len(args) != 3 {
+		return "", 0, "", false
+	}
+	key, ok1 := args[0].(string)
+	expires, ok2 := args[1].(float64)
+	signature, ok3 := args[2].(string)
+	return key, int64(expires), signature, ok1 && ok2 && ok3
+}
+
+func (s *FakeWebsocketServer) handleV5(c *fakeWebsocketConn, message []byte) {
+	var req struct {
+		ReqID string        `json:"req_id"`
+		Op    string        `json:"op"`
+		Args  []interface{} `json:"args"`
+	}
+	if err := json.Unmarshal(message, &req); err != nil {
+		c.writeJSON(map[string]interface{}{"success": false, "ret_msg": "error:invalid json", "conn_id": c.id, "op": ""})
+		return
+	}
+	ack := func(success bool, retMsg string) map[string]interface{} {
+		return map[string]interface{}{"success": success, "ret_msg": retMsg, "conn_id": c.id, "req_id": req.ReqID, "op": req.Op}
+	}
+
+	switch req.Op {
+	case "ping":
+		response := ack(true, "pong")
+		s.pong(func() { c.writeJSON(response) })
+	case "auth":
+		key, expires, signature, ok := authArgs(req.Args)
+		if !ok || !s.verifyAuth(key, expires, signature) {
+			c.writeJSON(ack(false, "Params Error"))
+			return
+		}
+		c.writeJSON(ack(true, ""))
+	case "subscribe", "unsubscribe":
+		subscribe := req.Op == "subscribe"
+		for _, arg := range req.Args {
+			topic, _ := arg.(string)
+			if !s.setTopic(c, topic, subscribe) {
+				state := "already subscribed"
+				if !subscribe {
+					state = "not subscribed"
+				}
+				c.writeJSON(ack(false, fmt.Sprintf("error:%s,topic:%s", state, topic)))
+				return
+			}
+		}
+		c.writeJSON(ack(true, ""))
+	default:
+		c.writeJSON(ack(false, "error:handler not found"))
+	}
+}
+
+func (s *FakeWebsocketServer) handleSpotV1(c *fakeWebsocketConn, message []byte) {
+	req := map[string]interface{}{}
+	if err := json.Unmarshal(message, &req); err != nil {
+		c.writeJSON(map[string]interface{}{"code": "-10001", "desc": "invalid json"})
+		return
+	}
+
+	if ping, ok := req["ping"]; ok {
+		s.pong(func() { c.writeJSON(map[string]interface{}{"pong": ping}) })
+		return
+	}
+	if req["op"] == "auth" {
+		args, _ := req["args"].([]interface{})
+		key, expires, signature, ok := authArgs(args)
+		if !ok || !s.verifyAuth(key, expires, signature) {
+			c.writeJSON(map[string]interface{}{"auth": "fail", "userId": 0})
+			return
+		}
+		c.writeJSON(map[string]interface{}{"auth": "success", "userId": 24600000})
+		return
+	}
+
+	event, _ := req["event"].(string)
+	topic, _ := req["topic"].(string)
+	symbol, _ := req["symbol"].(string)
+	if params, ok := req["params"].(map[string]interface{}); ok && symbol == "" {
+		symbol, _ = params["symbol"].(string)
+	}
+	switch event {
+	case "sub", "cancel":
+		s.setTopic(c, topic+"."+symbol, event == "sub")
+		req["code"] = "0"
+		req["msg"] = "Success"
+	default:
+		req["code"] = "-10002"
+		req["msg"] = "invalid event"
+	}
+	c.writeJSON(req)
+}
@@ -0,0 +1,140 @@
+package testhelper_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/oneart-dev/bybit"
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeWebsocketServerV5(t *testing.T) {
+	server, teardown := testhelper.NewFakeWebsocketServer()
+	defer teardown()
+
+	svc, err := bybit.NewTestWebsocketClient().WithBaseURL(server.URL).V5().Public(bybit.CategoryV5Linear)
+	require.NoError(t, err)
+
+	received := make(chan bybit.V5WebsocketPublicTickerResponse, 1)
+	unsubscribe, err := svc.SubscribeTicker(bybit.V5WebsocketPublicTickerParamKey{Symbol: bybit.SymbolV5BTCUSDT}, func(response bybit.V5WebsocketPublicTickerResponse) error {
+		received <- response
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.Run(), "subscribe acknowledged")
+	require.NoError(t, server.WaitSubscribed("tickers.BTCUSDT", time.Second))
+
+	assert.Equal(t, 0, server.Publish("tickers.ETHUSDT", []byte(`{}`)))
+	assert.Equal(t, 1, server.PublishJSON("tickers.BTCUSDT", map[string]interface{}{
+		"topic": "tickers.BTCUSDT",
+		"type":  "snapshot",
+		"ts":    1701219600000,
+		"data":  map[string]interface{}{"symbol": "BTCUSDT", "lastPrice": "38089.9"},
+	}))
+	require.NoError(t, svc.Run())
+	assert.Equal(t, "38089.9", (<-received).Data.LastPrice)
+
+	require.NoError(t, svc.Ping())
+	require.NoError(t, svc.Run(), "pong acknowledged")
+
+	require.NoError(t, unsubscribe())
+	require.NoError(t, svc.Run(), "unsubscribe acknowledged")
+	assert.Empty(t, server.Topics())
+	assert.Len(t, server.Received(), 3)
+
+	server.Broadcast([]byte(`{"topic":`))
+	assert.Error(t, svc.Run(), "malformed frame")
+
+	server.Disconnect()
+	assert.Error(t, svc.Run())
+	assert.Equal(t, 0, server.Connections())
+}
+
+func TestFakeWebsocketServerSpotV1(t *testing.T) {
+	server, teardown := testhelper.NewFakeWebsocketServer()
+	defer teardown()
+	client := bybit.NewTestWebsocketClient().WithBaseURL(server.URL)
+
+	svc, err := client.Spot().V1().PublicV1()
+	require.NoError(t, err)
+	trades := make(chan bybit.SpotWebsocketV1PublicV1TradeResponse, 1)
+	_, err = svc.SubscribeTrade(bybit.SymbolSpotBTCUSDT, func(response bybit.SpotWebsocketV1PublicV1TradeResponse) error {
+		trades <- response
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.Run(), "subscribe acknowledged")
+	require.NoError(t, server.WaitSubscribed("trade.BTCUSDT", time.Second))
+
+	server.PublishJSON("trade.BTCUSDT", bybit.SpotWebsocketV1PublicV1TradeResponse{
+		Symbol: bybit.SymbolSpotBTCUSDT,
+		Topic:  bybit.SpotWebsocketV1PublicV1TopicTrade,
+		Data:   []bybit.SpotWebsocketV1PublicV1TradeContent{{TradeID: "1", Price: "38089.9", Quantity: "0.01"}},
+	})
+	require.NoError(t, svc.Run())
+	assert.Equal(t, "38089.9", (<-trades).Data[0].Price)
+
+	private, err := bybit.NewTestWebsocketClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).Spot().V1().Private()
+	require.NoError(t, err)
+	require.NoError(t, private.Subscribe())
+	assert.NoError(t, private.Run(), "auth succeeded")
+
+	private, err = bybit.NewTestWebsocketClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, "wrong").Spot().V1().Private()
+	require.NoError(t, err)
+	require.NoError(t, private.Subscribe())
+	assert.EqualError(t, private.Run(), "auth failed")
+}
+
+func TestFakeWebsocketServerPongDelay(t *testing.T) {
+	delay := 50 * time.Millisecond
+	server, teardown := testhelper.NewFakeWebsocketServer()
+	defer teardown()
+	server.WithPongDelay(delay)
+
+	c, _, err := websocket.DefaultDialer.Dial(server.URL+bybit.V5WebsocketPublicPath+"/linear", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	pong := make(chan time.Time, 1)
+	c.SetPongHandler(func(string) error {
+		pong <- time.Now()
+		return nil
+	})
+	go func() {
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	sent := time.Now()
+	require.NoError(t, c.WriteControl(websocket.PingMessage, nil, sent.Add(time.Second)))
+	select {
+	case at := <-pong:
+		assert.GreaterOrEqual(t, int64(at.Sub(sent)), int64(delay))
+	case <-time.After(time.Second):
+		t.Fatal("no pong")
+	}
+}
+
+func TestFakeWebsocketServerV5Auth(t *testing.T) {
+	server, teardown := testhelper.NewFakeWebsocketServer()
+	defer teardown()
+
+	c, _, err := websocket.DefaultDialer.Dial(server.URL+"/v5/private", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.WriteJSON(map[string]interface{}{"op": "auth", "args": []interface{}{testhelper.FakeKey, 1701219600000, "bad"}}))
+	var ack bybit.V5WebsocketOperationResponse
+	_, message, err := c.ReadMessage()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(message, &ack))
+	assert.False(t, ack.Success)
+	assert.Equal(t, "auth", string(ack.Op))
+}
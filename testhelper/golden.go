@@ -0,0 +1,57 @@
+package testhelper
+
+import (
+	"embed"
+	"net/http"
+	"strings"
+)
+
+//go:embed golden
+var golden embed.FS
+
+// goldenRoutes : every v5 endpoint the client implements and its golden response
+var goldenRoutes = []struct {
+	method string
+	path   string
+	file   string
+}{
+	{http.MethodGet, "/v5/market/kline", "v5/market/kline.json"},
+	{http.MethodGet, "/v5/market/mark-price-kline", "v5/market/mark-price-kline.json"},
+	{http.MethodGet, "/v5/market/index-price-kline", "v5/market/index-price-kline.json"},
+	{http.MethodGet, "/v5/market/premium-index-price-kline", "v5/market/premium-index-price-kline.json"},
+	{http.MethodGet, "/v5/market/instruments-info", "v5/market/instruments-info.json"},
+	{http.MethodGet, "/v5/market/orderbook", "v5/market/orderbook.json"},
+	{http.MethodGet, "/v5/market/tickers", "v5/market/tickers.json"},
+	{http.MethodPost, "/v5/order/create", "v5/order/create.json"},
+	{http.MethodPost, "/v5/order/cancel", "v5/order/cancel.json"},
+	{http.MethodGet, "/v5/order/realtime", "v5/order/realtime.json"},
+	{http.MethodGet, "/v5/order/history", "v5/order/history.json"},
+	{http.MethodGet, "/v5/execution/list", "v5/execution/list.json"},
+	{http.MethodGet, "/v5/pre-upgrade/execution/list", "v5/execution/list.json"},
+	{http.MethodGet, "/v5/position/list", "v5/position/list.json"},
+	{http.MethodPost, "/v5/position/set-leverage", "v5/position/set-leverage.json"},
+	{http.MethodGet, "/v5/position/closed-pnl", "v5/position/closed-pnl.json"},
+	{http.MethodGet, "/v5/account/wallet-balance", "v5/account/wallet-balance.json"},
+	{http.MethodGet, "/v5/user/query-api", "v5/user/query-api.json"},
+}
+
+// Golden : a canned response of the golden directory, e.g. "v5/market/tickers.json"
+func Golden(name string) []byte {
+	body, err := golden.ReadFile("golden/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return body
+}
+
+// WithGoldenResponses : answers every v5 endpoint the client implements with its golden response,
+// routes handled afterwards take precedence. All but the market endpoints are private.
+func (s *FakeServer) WithGoldenResponses() *FakeServer {
+	for _, r := range goldenRoutes {
+		route := s.Handle(r.method, r.path).RespondFile(r.file)
+		if !strings.HasPrefix(r.path, "/v5/market/") {
+			route.Private()
+		}
+	}
+	return s
+}
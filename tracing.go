@@ -0,0 +1,178 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// Tracer : starts the spans of API calls and websocket messages, e.g. an adapter of an OpenTelemetry tracer:
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, bybit.Span) {
+//		ctx, span := t.tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+// where otelSpan calls SetAttributes, RecordError and SetStatus, and End.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span : the part of a span the clients use
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// The attributes set on the spans, a value the request or the response does not have is not set
+const (
+	TraceAttributeEndpoint           = "bybit.endpoint"
+	TraceAttributeMethod             = "http.method"
+	TraceAttributeStatusCode         = "http.status_code"
+	TraceAttributeCategory           = "bybit.category"
+	TraceAttributeSymbol             = "bybit.symbol"
+	TraceAttributeOrderID            = "bybit.order_id"
+	TraceAttributeOrderLinkID        = "bybit.order_link_id"
+	TraceAttributeRetCode            = "bybit.ret_code"
+	TraceAttributeRetMsg             = "bybit.ret_msg"
+	TraceAttributeRateLimit          = "bybit.rate_limit"
+	TraceAttributeRateLimitRemaining = "bybit.rate_limit_remaining"
+	TraceAttributeWebsocketPath      = "bybit.websocket.path"
+	TraceAttributeWebsocketTopic     = "bybit.websocket.topic"
+)
+
+// WithTracer : every request is traced in a span named after its endpoint, e.g. "bybit /v5/order/create",
+// a child of the context of the call, e.g. client.V5().Order().CreateOrderContext(ctx, param)
+func (c *Client) WithTracer(tracer Tracer) *Client {
+	return c.Clone(WithTracerOption(tracer))
+}
+
+// traceFields : the identifiers a request or a response may carry
+type traceFields struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+}
+
+func (f traceFields) setOn(span Span) {
+	for _, attribute := range [][2]string{
+		{TraceAttributeCategory, f.Category},
+		{TraceAttributeSymbol, f.Symbol},
+		{TraceAttributeOrderID, f.OrderID},
+		{TraceAttributeOrderLinkID, f.OrderLinkID},
+	} {
+		if attribute[1] != "" {
+			span.SetAttribute(attribute[0], attribute[1])
+		}
+	}
+}
+
+// missingFrom : the fields set that are not in known
+func (f traceFields) missingFrom(known traceFields) traceFields {
+	if known.Category != "" {
+		f.Category = ""
+	}
+	if known.Symbol != "" {
+		f.Symbol = ""
+	}
+	if known.OrderID != "" {
+		f.OrderID = ""
+	}
+	if known.OrderLinkID != "" {
+		f.OrderLinkID = ""
+	}
+	return f
+}
+
+// requestTraceFields : from the query or the JSON body
+func requestTraceFields(call *Call) traceFields {
+	query := call.Request.URL.Query()
+	if call.Request.GetBody != nil {
+		if body, err := call.Request.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			_ = body.Close()
+			var fields traceFields
+			if json.Unmarshal(b, &fields) == nil {
+				return fields
+			}
+			if values, err := url.ParseQuery(string(b)); err == nil {
+				query = values
+			}
+		}
+	}
+	return traceFields{
+		Category:    query.Get("category"),
+		Symbol:      query.Get("symbol"),
+		OrderID:     query.Get("orderId"),
+		OrderLinkID: query.Get("orderLinkId"),
+	}
+}
+
+// responseTraceFields : from the result of the response body
+func responseTraceFields(body []byte) traceFields {
+	var res struct {
+		Result traceFields `json:"result"`
+	}
+	_ = json.Unmarshal(body, &res)
+	return res.Result
+}
+
+// trace : the span of the call, which send makes
+func (c *Client) trace(call *Call, send RoundTrip) error {
+	ctx, span := c.tracer.Start(call.Request.Context(), "bybit "+call.Path)
+	defer span.End()
+	call.Request = call.Request.WithContext(ctx)
+
+	span.SetAttribute(TraceAttributeEndpoint, call.Path)
+	span.SetAttribute(TraceAttributeMethod, call.Request.Method)
+	fields := requestTraceFields(call)
+	fields.setOn(span)
+
+	err := send(call)
+
+	if call.StatusCode != 0 {
+		span.SetAttribute(TraceAttributeStatusCode, call.StatusCode)
+		span.SetAttribute(TraceAttributeRetCode, call.RetCode)
+		if call.RetMsg != "" {
+			span.SetAttribute(TraceAttributeRetMsg, call.RetMsg)
+		}
+		responseTraceFields(call.body).missingFrom(fields).setOn(span)
+	}
+	if v, err := strconv.Atoi(call.Header.Get("X-Bapi-Limit")); err == nil {
+		span.SetAttribute(TraceAttributeRateLimit, v)
+	}
+	if v, err := strconv.Atoi(call.Header.Get("X-Bapi-Limit-Status")); err == nil {
+		span.SetAttribute(TraceAttributeRateLimitRemaining, v)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// WithTracer : the data frames read by connections dialed afterwards are handled in a span per message
+// named after its topic, e.g. "bybit tickers.BTCUSDT", a child of the context the service runs with,
+// see RunContext and Start, and ending when the handler returns.
+// The handlers get the context of the span from MessageContext of their service.
+func (c *WebSocketClient) WithTracer(tracer Tracer) *WebSocketClient {
+	c.tracer = tracer
+
+	return c
+}
+
+// tracingHook : a span per message
+func (c *WebSocketClient) tracingHook(ctx context.Context, path, topic string) (context.Context, func(error)) {
+	ctx, span := c.tracer.Start(ctx, "bybit "+topic)
+	span.SetAttribute(TraceAttributeWebsocketPath, path)
+	span.SetAttribute(TraceAttributeWebsocketTopic, topic)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,138 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSpan struct {
+	name       string
+	parent     interface{}
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.err = err }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+type testTraceKey struct{}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &testSpan{name: name, parent: ctx.Value(testTraceKey{}), attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, testTraceKey{}, name), span
+}
+
+func TestClientWithTracer(t *testing.T) {
+	server, teardown := testhelper.NewFakeServer()
+	defer teardown()
+	server.WithGoldenResponses()
+	server.Handle(http.MethodGet, "/v5/order/realtime").RespondRateLimit()
+
+	tracer := &testTracer{}
+	var requestContexts []interface{}
+	client := NewTestClient().WithBaseURL(server.URL).WithAuth(testhelper.FakeKey, testhelper.FakeSecret).
+		WithTracer(tracer).
+		Use(func(next RoundTrip) RoundTrip {
+			return func(call *Call) error {
+				requestContexts = append(requestContexts, call.Request.Context().Value(testTraceKey{}))
+				return next(call)
+			}
+		})
+	ctx := context.WithValue(context.Background(), testTraceKey{}, "order flow")
+	linkID := "link-1"
+
+	_, err := client.V5().Order().CreateOrderContext(ctx, V5CreateOrderParam{
+		Category: CategoryV5Linear, Symbol: SymbolV5BTCUSDT, Side: SideBuy, OrderType: OrderTypeMarket, Qty: "0.1", OrderLinkID: &linkID,
+	})
+	require.NoError(t, err)
+	_, err = client.V5().Order().GetOpenOrders(V5GetOpenOrdersParam{Category: CategoryV5Linear})
+	var rateLimit *RateLimitError
+	require.True(t, errors.As(err, &rateLimit), "got %v", err)
+
+	require.Len(t, tracer.spans, 2)
+	create := tracer.spans[0]
+	assert.Equal(t, "bybit /v5/order/create", create.name)
+	assert.Equal(t, "order flow", create.parent)
+	assert.Equal(t, create.name, requestContexts[0], "the request carries the span")
+	assert.True(t, create.ended)
+	assert.NoError(t, create.err)
+	assert.Equal(t, map[string]interface{}{
+		TraceAttributeEndpoint:    "/v5/order/create",
+		TraceAttributeMethod:      http.MethodPost,
+		TraceAttributeStatusCode:  http.StatusOK,
+		TraceAttributeCategory:    "linear",
+		TraceAttributeSymbol:      "BTCUSDT",
+		TraceAttributeOrderID:     "1321003749386327552",
+		TraceAttributeOrderLinkID: "link-1",
+		TraceAttributeRetCode:     0,
+		TraceAttributeRetMsg:      "OK",
+	}, create.attributes)
+
+	open := tracer.spans[1]
+	assert.Nil(t, open.parent)
+	assert.Equal(t, "linear", open.attributes[TraceAttributeCategory])
+	assert.Equal(t, 10006, open.attributes[TraceAttributeRetCode])
+	assert.Equal(t, 0, open.attributes[TraceAttributeRateLimitRemaining])
+	assert.Equal(t, 10, open.attributes[TraceAttributeRateLimit])
+	assert.Equal(t, err, open.err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.V5().Order().GetOpenOrdersContext(cancelled, V5GetOpenOrdersParam{Category: CategoryV5Linear})
+	assert.True(t, errors.Is(err, context.Canceled), "got %v", err)
+	require.Len(t, tracer.spans, 3)
+	assert.Equal(t, "order flow", tracer.spans[2].parent)
+	assert.Equal(t, err, tracer.spans[2].err)
+}
+
+func TestWebsocketClientWithTracer(t *testing.T) {
+	server, teardown := testhelper.NewFakeWebsocketServer()
+	defer teardown()
+
+	tracer := &testTracer{}
+	ctx := context.WithValue(context.Background(), testTraceKey{}, "feed")
+	svc, err := NewTestWebsocketClient().WithBaseURL(server.URL).WithTracer(tracer).V5().Public(CategoryV5Linear)
+	require.NoError(t, err)
+	var handlerSpan interface{}
+	var handlerEnded bool
+	handlerErr := errors.New("failed")
+	_, err = svc.SubscribeTicker(V5WebsocketPublicTickerParamKey{Symbol: SymbolV5BTCUSDT}, func(V5WebsocketPublicTickerResponse) error {
+		handlerSpan = svc.MessageContext().Value(testTraceKey{})
+		handlerEnded = tracer.spans[len(tracer.spans)-1].ended
+		return handlerErr
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.RunContext(ctx), "subscribe acknowledged")
+	require.NoError(t, server.WaitSubscribed("tickers.BTCUSDT", time.Second))
+
+	server.PublishJSON("tickers.BTCUSDT", map[string]interface{}{"topic": "tickers.BTCUSDT", "type": "snapshot", "data": map[string]interface{}{"symbol": "BTCUSDT"}})
+	assert.Equal(t, handlerErr, svc.RunContext(ctx))
+	require.Len(t, tracer.spans, 1, "acknowledgements are not traced")
+	span := tracer.spans[0]
+	assert.Equal(t, "bybit tickers.BTCUSDT", span.name)
+	assert.Equal(t, "feed", span.parent, "a child of the context of the run")
+	assert.Equal(t, "/v5/public/linear", span.attributes[TraceAttributeWebsocketPath])
+	assert.Equal(t, "bybit tickers.BTCUSDT", handlerSpan, "the handler gets the span")
+	assert.False(t, handlerEnded, "open while the handler runs")
+	assert.True(t, span.ended, "ends when the handler returns")
+	assert.Equal(t, handlerErr, span.err)
+	assert.Nil(t, svc.MessageContext().Value(testTraceKey{}), "no message outside the handlers")
+}
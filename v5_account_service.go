@@ -1,6 +1,7 @@
 package bybit
 
 import (
+	"context"
 	"net/url"
 	"strings"
 )
@@ -8,6 +9,7 @@ import (
 // V5AccountServiceI :
 type V5AccountServiceI interface {
 	GetWalletBalance(AccountType, []Coin) (*V5WalletBalanceResponse, error)
+	GetWalletBalanceContext(context.Context, AccountType, []Coin) (*V5WalletBalanceResponse, error)
 }
 
 // V5AccountService :
@@ -66,6 +68,11 @@ type V5WalletBalanceList struct {
 // If not passed, it returns non-zero asset info
 // You can pass multiple coins to query, separated by comma. "USDT,USDC".
 func (s *V5AccountService) GetWalletBalance(at AccountType, coins []Coin) (*V5WalletBalanceResponse, error) {
+	return s.GetWalletBalanceContext(context.Background(), at, coins)
+}
+
+// GetWalletBalanceContext :
+func (s *V5AccountService) GetWalletBalanceContext(ctx context.Context, at AccountType, coins []Coin) (*V5WalletBalanceResponse, error) {
 	var (
 		res   V5WalletBalanceResponse
 		query = make(url.Values)
@@ -80,7 +87,7 @@ func (s *V5AccountService) GetWalletBalance(at AccountType, coins []Coin) (*V5Wa
 		query.Add("coin", strings.Join(coinsStr, ","))
 	}
 
-	if err := s.client.getV5Privately("/v5/account/wallet-balance", query, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, "/v5/account/wallet-balance", query, &res); err != nil {
 		return nil, err
 	}
 
@@ -40,6 +40,7 @@ const (
 	SymbolV5ETHUSD = SymbolV5("ETHUSD")
 
 	// Inverse Futures
+	// These contracts have expired, InstrumentRegistry.Symbols lists the live ones.
 	SymbolV5BTCUSDH23 = SymbolV5("BTCUSDH23")
 	SymbolV5BTCUSDM23 = SymbolV5("BTCUSDM23")
 	SymbolV5BTCUSDU23 = SymbolV5("BTCUSDU23")
@@ -1,10 +1,11 @@
 package bybit
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -12,12 +13,19 @@ import (
 // V5MarketServiceI :
 type V5MarketServiceI interface {
 	GetKline(V5GetKlineParam) (*V5GetKlineResponse, error)
+	GetKlineContext(context.Context, V5GetKlineParam) (*V5GetKlineResponse, error)
 	GetMarkPriceKline(V5GetMarkPriceKlineParam) (*V5GetMarkPriceKlineResponse, error)
+	GetMarkPriceKlineContext(context.Context, V5GetMarkPriceKlineParam) (*V5GetMarkPriceKlineResponse, error)
 	GetIndexPriceKline(V5GetIndexPriceKlineParam) (*V5GetIndexPriceKlineResponse, error)
+	GetIndexPriceKlineContext(context.Context, V5GetIndexPriceKlineParam) (*V5GetIndexPriceKlineResponse, error)
 	GetPremiumIndexPriceKline(V5GetPremiumIndexPriceKlineParam) (*V5GetPremiumIndexPriceKlineResponse, error)
+	GetPremiumIndexPriceKlineContext(context.Context, V5GetPremiumIndexPriceKlineParam) (*V5GetPremiumIndexPriceKlineResponse, error)
 	GetInstrumentsInfo(V5GetInstrumentsInfoParam) (*V5GetInstrumentsInfoResponse, error)
+	GetInstrumentsInfoContext(context.Context, V5GetInstrumentsInfoParam) (*V5GetInstrumentsInfoResponse, error)
 	GetOrderbook(V5GetOrderbookParam) (*V5GetOrderbookResponse, error)
+	GetOrderbookContext(context.Context, V5GetOrderbookParam) (*V5GetOrderbookResponse, error)
 	GetTickers(V5GetTickersParam) (*V5GetTickersResponse, error)
+	GetTickersContext(context.Context, V5GetTickersParam) (*V5GetTickersResponse, error)
 }
 
 // V5MarketService :
@@ -30,8 +38,8 @@ type V5GetKlineParam struct {
 	Category CategoryV5 `url:"category"`
 	Symbol   SymbolV5   `url:"symbol"`
 	Interval Interval   `url:"interval"`
-	Start    *int       `url:"start,omitempty"` // timestamp point for result, in milliseconds
-	End      *int       `url:"end,omitempty"`   // timestamp point for result, in milliseconds
+	Start    *time.Time `url:"start,omitempty,unixmilli"` // timestamp point for result, sent in milliseconds
+	End      *time.Time `url:"end,omitempty,unixmilli"`   // timestamp point for result, sent in milliseconds
 
 	Limit *int `url:"limit,omitempty"` // Limit for data size per page. [1, 200]. Default: 200
 }
@@ -54,7 +62,7 @@ type V5GetKlineList []V5GetKlineItem
 
 // V5GetKlineItem :
 type V5GetKlineItem struct {
-	StartTime string
+	StartTime MillisTime
 	Open      string
 	High      string
 	Low       string
@@ -73,14 +81,18 @@ func (l *V5GetKlineList) UnmarshalJSON(data []byte) error {
 		if len(d) != 7 {
 			return errors.New("so far len(items) must be 7, please check it on documents")
 		}
+		startTime, err := ParseMillisTime(d[0].(string))
+		if err != nil {
+			return err
+		}
 		*l = append(*l, V5GetKlineItem{
-			StartTime: d[0].(string),
+			StartTime: startTime,
 			Open:      d[1].(string),
 			High:      d[2].(string),
 			Low:       d[3].(string),
 			Close:     d[4].(string),
 			Volume:    d[5].(string),
-			Turnover:  d[5].(string),
+			Turnover:  d[6].(string),
 		})
 	}
 	return nil
@@ -88,6 +100,11 @@ func (l *V5GetKlineList) UnmarshalJSON(data []byte) error {
 
 // GetKline :
 func (s *V5MarketService) GetKline(param V5GetKlineParam) (*V5GetKlineResponse, error) {
+	return s.GetKlineContext(context.Background(), param)
+}
+
+// GetKlineContext :
+func (s *V5MarketService) GetKlineContext(ctx context.Context, param V5GetKlineParam) (*V5GetKlineResponse, error) {
 	var res V5GetKlineResponse
 
 	queryString, err := query.Values(param)
@@ -95,7 +112,7 @@ func (s *V5MarketService) GetKline(param V5GetKlineParam) (*V5GetKlineResponse,
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/kline", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/kline", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -107,9 +124,9 @@ type V5GetMarkPriceKlineParam struct {
 	Category CategoryV5 `url:"category"`
 	Symbol   SymbolV5   `url:"symbol"`
 	Interval Interval   `url:"interval"`
-	Start    *int       `url:"start,omitempty"` // timestamp point for result, in milliseconds
-	End      *int       `url:"end,omitempty"`   // timestamp point for result, in milliseconds
-	Limit    *int       `url:"limit,omitempty"` // Limit for data size per page. [1, 200]. Default: 200
+	Start    *time.Time `url:"start,omitempty,unixmilli"` // timestamp point for result, sent in milliseconds
+	End      *time.Time `url:"end,omitempty,unixmilli"`   // timestamp point for result, sent in milliseconds
+	Limit    *int       `url:"limit,omitempty"`           // Limit for data size per page. [1, 200]. Default: 200
 }
 
 // V5GetMarkPriceKlineResponse :
@@ -130,7 +147,7 @@ type V5GetMarkPriceKlineList []V5GetMarkPriceKlineItem
 
 // V5GetMarkPriceKlineItem :
 type V5GetMarkPriceKlineItem struct {
-	StartTime string
+	StartTime MillisTime
 	Open      string
 	High      string
 	Low       string
@@ -147,8 +164,12 @@ func (l *V5GetMarkPriceKlineList) UnmarshalJSON(data []byte) error {
 		if len(d) != 5 {
 			return errors.New("so far len(items) must be 5, please check it on documents")
 		}
+		startTime, err := ParseMillisTime(d[0].(string))
+		if err != nil {
+			return err
+		}
 		*l = append(*l, V5GetMarkPriceKlineItem{
-			StartTime: d[0].(string),
+			StartTime: startTime,
 			Open:      d[1].(string),
 			High:      d[2].(string),
 			Low:       d[3].(string),
@@ -160,6 +181,11 @@ func (l *V5GetMarkPriceKlineList) UnmarshalJSON(data []byte) error {
 
 // GetMarkPriceKline :
 func (s *V5MarketService) GetMarkPriceKline(param V5GetMarkPriceKlineParam) (*V5GetMarkPriceKlineResponse, error) {
+	return s.GetMarkPriceKlineContext(context.Background(), param)
+}
+
+// GetMarkPriceKlineContext :
+func (s *V5MarketService) GetMarkPriceKlineContext(ctx context.Context, param V5GetMarkPriceKlineParam) (*V5GetMarkPriceKlineResponse, error) {
 	var res V5GetMarkPriceKlineResponse
 
 	if param.Category != CategoryV5Linear && param.Category != CategoryV5Inverse {
@@ -171,7 +197,7 @@ func (s *V5MarketService) GetMarkPriceKline(param V5GetMarkPriceKlineParam) (*V5
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/mark-price-kline", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/mark-price-kline", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -183,9 +209,9 @@ type V5GetIndexPriceKlineParam struct {
 	Category CategoryV5 `url:"category"`
 	Symbol   SymbolV5   `url:"symbol"`
 	Interval Interval   `url:"interval"`
-	Start    *int       `url:"start,omitempty"` // timestamp point for result, in milliseconds
-	End      *int       `url:"end,omitempty"`   // timestamp point for result, in milliseconds
-	Limit    *int       `url:"limit,omitempty"` // Limit for data size per page. [1, 200]. Default: 200
+	Start    *time.Time `url:"start,omitempty,unixmilli"` // timestamp point for result, sent in milliseconds
+	End      *time.Time `url:"end,omitempty,unixmilli"`   // timestamp point for result, sent in milliseconds
+	Limit    *int       `url:"limit,omitempty"`           // Limit for data size per page. [1, 200]. Default: 200
 }
 
 // V5GetIndexPriceKlineResponse :
@@ -206,7 +232,7 @@ type V5GetIndexPriceKlineList []V5GetIndexPriceKlineItem
 
 // V5GetIndexPriceKlineItem :
 type V5GetIndexPriceKlineItem struct {
-	StartTime string
+	StartTime MillisTime
 	Open      string
 	High      string
 	Low       string
@@ -223,8 +249,12 @@ func (l *V5GetIndexPriceKlineList) UnmarshalJSON(data []byte) error {
 		if len(d) != 5 {
 			return errors.New("so far len(items) must be 5, please check it on documents")
 		}
+		startTime, err := ParseMillisTime(d[0].(string))
+		if err != nil {
+			return err
+		}
 		*l = append(*l, V5GetIndexPriceKlineItem{
-			StartTime: d[0].(string),
+			StartTime: startTime,
 			Open:      d[1].(string),
 			High:      d[2].(string),
 			Low:       d[3].(string),
@@ -236,6 +266,11 @@ func (l *V5GetIndexPriceKlineList) UnmarshalJSON(data []byte) error {
 
 // GetIndexPriceKline :
 func (s *V5MarketService) GetIndexPriceKline(param V5GetIndexPriceKlineParam) (*V5GetIndexPriceKlineResponse, error) {
+	return s.GetIndexPriceKlineContext(context.Background(), param)
+}
+
+// GetIndexPriceKlineContext :
+func (s *V5MarketService) GetIndexPriceKlineContext(ctx context.Context, param V5GetIndexPriceKlineParam) (*V5GetIndexPriceKlineResponse, error) {
 	var res V5GetIndexPriceKlineResponse
 
 	if param.Category != CategoryV5Linear && param.Category != CategoryV5Inverse {
@@ -247,7 +282,7 @@ func (s *V5MarketService) GetIndexPriceKline(param V5GetIndexPriceKlineParam) (*
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/index-price-kline", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/index-price-kline", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -259,9 +294,9 @@ type V5GetPremiumIndexPriceKlineParam struct {
 	Category CategoryV5 `url:"category"`
 	Symbol   SymbolV5   `url:"symbol"`
 	Interval Interval   `url:"interval"`
-	Start    *int       `url:"start,omitempty"` // timestamp point for result, in milliseconds
-	End      *int       `url:"end,omitempty"`   // timestamp point for result, in milliseconds
-	Limit    *int       `url:"limit,omitempty"` // Limit for data size per page. [1, 200]. Default: 200
+	Start    *time.Time `url:"start,omitempty,unixmilli"` // timestamp point for result, sent in milliseconds
+	End      *time.Time `url:"end,omitempty,unixmilli"`   // timestamp point for result, sent in milliseconds
+	Limit    *int       `url:"limit,omitempty"`           // Limit for data size per page. [1, 200]. Default: 200
 }
 
 // V5GetPremiumIndexPriceKlineResponse :
@@ -282,7 +317,7 @@ type V5GetPremiumIndexPriceKlineList []V5GetPremiumIndexPriceKlineItem
 
 // V5GetPremiumIndexPriceKlineItem :
 type V5GetPremiumIndexPriceKlineItem struct {
-	StartTime string
+	StartTime MillisTime
 	Open      string
 	High      string
 	Low       string
@@ -299,9 +334,12 @@ func (l *V5GetPremiumIndexPriceKlineList) UnmarshalJSON(data []byte) error {
 		if len(d) != 5 {
 			return errors.New("so far len(items) must be 5, please check it on documents")
 		}
-		log.Println(d)
+		startTime, err := ParseMillisTime(d[0].(string))
+		if err != nil {
+			return err
+		}
 		*l = append(*l, V5GetPremiumIndexPriceKlineItem{
-			StartTime: d[0].(string),
+			StartTime: startTime,
 			Open:      d[1].(string),
 			High:      d[2].(string),
 			Low:       d[3].(string),
@@ -313,6 +351,11 @@ func (l *V5GetPremiumIndexPriceKlineList) UnmarshalJSON(data []byte) error {
 
 // GetPremiumIndexPriceKline :
 func (s *V5MarketService) GetPremiumIndexPriceKline(param V5GetPremiumIndexPriceKlineParam) (*V5GetPremiumIndexPriceKlineResponse, error) {
+	return s.GetPremiumIndexPriceKlineContext(context.Background(), param)
+}
+
+// GetPremiumIndexPriceKlineContext :
+func (s *V5MarketService) GetPremiumIndexPriceKlineContext(ctx context.Context, param V5GetPremiumIndexPriceKlineParam) (*V5GetPremiumIndexPriceKlineResponse, error) {
 	var res V5GetPremiumIndexPriceKlineResponse
 
 	if param.Category != CategoryV5Linear {
@@ -324,7 +367,7 @@ func (s *V5MarketService) GetPremiumIndexPriceKline(param V5GetPremiumIndexPrice
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/premium-index-price-kline", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/premium-index-price-kline", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -395,8 +438,8 @@ type V5GetInstrumentsInfoLinearInverseResult struct {
 		BaseCoin        Coin             `json:"baseCoin"`
 		QuoteCoin       Coin             `json:"quoteCoin"`
 		SettleCoin      Coin             `json:"settleCoin"`
-		LaunchTime      string           `json:"launchTime"`
-		DeliveryTime    string           `json:"deliveryTime"`
+		LaunchTime      MillisTime       `json:"launchTime"`
+		DeliveryTime    MillisTime       `json:"deliveryTime"`
 		DeliveryFeeRate string           `json:"deliveryFeeRate"`
 		PriceScale      string           `json:"priceScale"`
 		LeverageFilter  struct {
@@ -431,8 +474,8 @@ type V5GetInstrumentsInfoOptionResult struct {
 		BaseCoin        Coin             `json:"baseCoin"`
 		QuoteCoin       Coin             `json:"quoteCoin"`
 		SettleCoin      Coin             `json:"settleCoin"`
-		LaunchTime      string           `json:"launchTime"`
-		DeliveryTime    string           `json:"deliveryTime"`
+		LaunchTime      MillisTime       `json:"launchTime"`
+		DeliveryTime    MillisTime       `json:"deliveryTime"`
 		DeliveryFeeRate string           `json:"deliveryFeeRate"`
 		PriceFilter     struct {
 			MinPrice string `json:"minPrice"`
@@ -472,6 +515,11 @@ type V5GetInstrumentsInfoSpotResult struct {
 
 // GetInstrumentsInfo :
 func (s *V5MarketService) GetInstrumentsInfo(param V5GetInstrumentsInfoParam) (*V5GetInstrumentsInfoResponse, error) {
+	return s.GetInstrumentsInfoContext(context.Background(), param)
+}
+
+// GetInstrumentsInfoContext :
+func (s *V5MarketService) GetInstrumentsInfoContext(ctx context.Context, param V5GetInstrumentsInfoParam) (*V5GetInstrumentsInfoResponse, error) {
 	var res V5GetInstrumentsInfoResponse
 
 	queryString, err := query.Values(param)
@@ -479,7 +527,7 @@ func (s *V5MarketService) GetInstrumentsInfo(param V5GetInstrumentsInfoParam) (*
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/instruments-info", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/instruments-info", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -544,6 +592,11 @@ type V5GetOrderbookBidAsk struct {
 
 // GetOrderbook :
 func (s *V5MarketService) GetOrderbook(param V5GetOrderbookParam) (*V5GetOrderbookResponse, error) {
+	return s.GetOrderbookContext(context.Background(), param)
+}
+
+// GetOrderbookContext :
+func (s *V5MarketService) GetOrderbookContext(ctx context.Context, param V5GetOrderbookParam) (*V5GetOrderbookResponse, error) {
 	var res V5GetOrderbookResponse
 
 	queryString, err := query.Values(param)
@@ -551,7 +604,7 @@ func (s *V5MarketService) GetOrderbook(param V5GetOrderbookParam) (*V5GetOrderbo
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/orderbook", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/orderbook", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -625,29 +678,29 @@ func (r *V5GetTickersResult) UnmarshalJSON(data []byte) error {
 type V5GetTickersLinearInverseResult struct {
 	Category CategoryV5 `json:"category"`
 	List     []struct {
-		Symbol                 SymbolV5 `json:"symbol"`
-		LastPrice              string   `json:"lastPrice"`
-		IndexPrice             string   `json:"indexPrice"`
-		MarkPrice              string   `json:"markPrice"`
-		PrevPrice24H           string   `json:"prevPrice24h"`
-		Price24HPcnt           string   `json:"price24hPcnt"`
-		HighPrice24H           string   `json:"highPrice24h"`
-		LowPrice24H            string   `json:"lowPrice24h"`
-		PrevPrice1H            string   `json:"prevPrice1h"`
-		OpenInterest           string   `json:"openInterest"`
-		OpenInterestValue      string   `json:"openInterestValue"`
-		Turnover24H            string   `json:"turnover24h"`
-		Volume24H              string   `json:"volume24h"`
-		FundingRate            string   `json:"fundingRate"`
-		NextFundingTime        string   `json:"nextFundingTime"`
-		PredictedDeliveryPrice string   `json:"predictedDeliveryPrice"`
-		BasisRate              string   `json:"basisRate"`
-		DeliveryFeeRate        string   `json:"deliveryFeeRate"`
-		DeliveryTime           string   `json:"deliveryTime"`
-		Ask1Size               string   `json:"ask1Size"`
-		Bid1Price              string   `json:"bid1Price"`
-		Ask1Price              string   `json:"ask1Price"`
-		Bid1Size               string   `json:"bid1Size"`
+		Symbol                 SymbolV5   `json:"symbol"`
+		LastPrice              string     `json:"lastPrice"`
+		IndexPrice             string     `json:"indexPrice"`
+		MarkPrice              string     `json:"markPrice"`
+		PrevPrice24H           string     `json:"prevPrice24h"`
+		Price24HPcnt           string     `json:"price24hPcnt"`
+		HighPrice24H           string     `json:"highPrice24h"`
+		LowPrice24H            string     `json:"lowPrice24h"`
+		PrevPrice1H            string     `json:"prevPrice1h"`
+		OpenInterest           string     `json:"openInterest"`
+		OpenInterestValue      string     `json:"openInterestValue"`
+		Turnover24H            string     `json:"turnover24h"`
+		Volume24H              string     `json:"volume24h"`
+		FundingRate            string     `json:"fundingRate"`
+		NextFundingTime        MillisTime `json:"nextFundingTime"`
+		PredictedDeliveryPrice string     `json:"predictedDeliveryPrice"`
+		BasisRate              string     `json:"basisRate"`
+		DeliveryFeeRate        string     `json:"deliveryFeeRate"`
+		DeliveryTime           MillisTime `json:"deliveryTime"`
+		Ask1Size               string     `json:"ask1Size"`
+		Bid1Price              string     `json:"bid1Price"`
+		Ask1Price              string     `json:"ask1Price"`
+		Bid1Size               string     `json:"bid1Size"`
 	} `json:"list"`
 }
 
@@ -705,6 +758,11 @@ type V5GetTickersSpotResult struct {
 
 // GetTickers :
 func (s *V5MarketService) GetTickers(param V5GetTickersParam) (*V5GetTickersResponse, error) {
+	return s.GetTickersContext(context.Background(), param)
+}
+
+// GetTickersContext :
+func (s *V5MarketService) GetTickersContext(ctx context.Context, param V5GetTickersParam) (*V5GetTickersResponse, error) {
 	var res V5GetTickersResponse
 
 	if err := param.validate(); err != nil {
@@ -716,7 +774,7 @@ func (s *V5MarketService) GetTickers(param V5GetTickersParam) (*V5GetTickersResp
 		return nil, err
 	}
 
-	if err := s.client.getPublicly("/v5/market/tickers", queryString, &res); err != nil {
+	if err := s.client.getPubliclyContext(ctx, "/v5/market/tickers", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -3,6 +3,7 @@ package bybit
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/oneart-dev/bybit/testhelper"
@@ -53,7 +54,7 @@ func TestV5MarketGetKline(t *testing.T) {
 
 	require.NotNil(t, resp)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["symbol"], string(resp.Result.Symbol))
-	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], resp.Result.List[0].StartTime)
+	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], strconv.FormatInt(resp.Result.List[0].StartTime.Millis(), 10))
 }
 
 func TestV5Market_GetMarkPriceKline(t *testing.T) {
@@ -97,7 +98,7 @@ func TestV5Market_GetMarkPriceKline(t *testing.T) {
 
 	require.NotNil(t, resp)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["symbol"], string(resp.Result.Symbol))
-	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], resp.Result.List[0].StartTime)
+	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], strconv.FormatInt(resp.Result.List[0].StartTime.Millis(), 10))
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][1], resp.Result.List[0].Open)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][2], resp.Result.List[0].High)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][3], resp.Result.List[0].Low)
@@ -145,7 +146,7 @@ func TestV5Market_GetIndexPriceKline(t *testing.T) {
 
 	require.NotNil(t, resp)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["symbol"], string(resp.Result.Symbol))
-	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], resp.Result.List[0].StartTime)
+	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], strconv.FormatInt(resp.Result.List[0].StartTime.Millis(), 10))
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][1], resp.Result.List[0].Open)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][2], resp.Result.List[0].High)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][3], resp.Result.List[0].Low)
@@ -193,7 +194,7 @@ func TestV5Market_GetPremiumIndexPriceKline(t *testing.T) {
 
 	require.NotNil(t, resp)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["symbol"], string(resp.Result.Symbol))
-	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], resp.Result.List[0].StartTime)
+	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][0], strconv.FormatInt(resp.Result.List[0].StartTime.Millis(), 10))
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][1], resp.Result.List[0].Open)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][2], resp.Result.List[0].High)
 	assert.Equal(t, respBody["result"].(map[string]interface{})["list"].([][]string)[0][3], resp.Result.List[0].Low)
@@ -1,8 +1,10 @@
 package bybit
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -10,11 +12,17 @@ import (
 // V5OrderServiceI :
 type V5OrderServiceI interface {
 	CreateOrder(V5CreateOrderParam) (*V5CreateOrderResponse, error)
+	CreateOrderContext(context.Context, V5CreateOrderParam) (*V5CreateOrderResponse, error)
 	CancelOrder(V5CancelOrderParam) (*V5CancelOrderResponse, error)
+	CancelOrderContext(context.Context, V5CancelOrderParam) (*V5CancelOrderResponse, error)
 	GetOpenOrders(V5GetOpenOrdersParam) (*V5GetOpenOrdersResponse, error)
+	GetOpenOrdersContext(context.Context, V5GetOpenOrdersParam) (*V5GetOpenOrdersResponse, error)
 	GetExecutionList(V5GetExecutionListParam) (*V5GetExecutionListResponse, error)
+	GetExecutionListContext(context.Context, V5GetExecutionListParam) (*V5GetExecutionListResponse, error)
 	GetOrderList(param V5GetOrderListParam) (*V5GetOrderListResponse, error)
+	GetOrderListContext(context.Context, V5GetOrderListParam) (*V5GetOrderListResponse, error)
 	GetClosedPnl(param V5GetClosedPnlParam) (*V5GetClosedPnlResponse, error)
+	GetClosedPnlContext(context.Context, V5GetClosedPnlParam) (*V5GetClosedPnlResponse, error)
 }
 
 // V5OrderService :
@@ -63,14 +71,25 @@ type V5CreateOrderResult struct {
 
 // CreateOrder :
 func (s *V5OrderService) CreateOrder(param V5CreateOrderParam) (*V5CreateOrderResponse, error) {
+	return s.CreateOrderContext(context.Background(), param)
+}
+
+// CreateOrderContext :
+func (s *V5OrderService) CreateOrderContext(ctx context.Context, param V5CreateOrderParam) (*V5CreateOrderResponse, error) {
 	var res V5CreateOrderResponse
 
+	if s.client.instruments != nil {
+		if err := s.client.instruments.ValidateOrder(param); err != nil {
+			return nil, fmt.Errorf("validate order: %w", err)
+		}
+	}
+
 	body, err := json.Marshal(param)
 	if err != nil {
 		return &res, fmt.Errorf("json marshal: %w", err)
 	}
 
-	if err := s.client.postV5JSON("/v5/order/create", body, &res); err != nil {
+	if err := s.client.postV5JSON(ctx, "/v5/order/create", body, &res); err != nil {
 		return &res, err
 	}
 
@@ -101,6 +120,11 @@ type V5CancelOrderResult struct {
 
 // CancelOrder :
 func (s *V5OrderService) CancelOrder(param V5CancelOrderParam) (*V5CancelOrderResponse, error) {
+	return s.CancelOrderContext(context.Background(), param)
+}
+
+// CancelOrderContext :
+func (s *V5OrderService) CancelOrderContext(ctx context.Context, param V5CancelOrderParam) (*V5CancelOrderResponse, error) {
 	var res V5CancelOrderResponse
 
 	if param.OrderID == nil && param.OrderLinkID == nil {
@@ -112,7 +136,7 @@ func (s *V5OrderService) CancelOrder(param V5CancelOrderParam) (*V5CancelOrderRe
 		return &res, fmt.Errorf("json marshal: %w", err)
 	}
 
-	if err := s.client.postV5JSON("/v5/order/cancel", body, &res); err != nil {
+	if err := s.client.postV5JSON(ctx, "/v5/order/cancel", body, &res); err != nil {
 		return &res, err
 	}
 
@@ -164,12 +188,12 @@ type V5GetOpenOrder struct {
 	RejectReason       string      `json:"rejectReason"`
 	Price              string      `json:"price"`
 	OrderIv            string      `json:"orderIv"`
-	CreatedTime        string      `json:"createdTime"`
+	CreatedTime        MillisTime  `json:"createdTime"`
 	TpTriggerBy        string      `json:"tpTriggerBy"`
 	PositionIdx        int         `json:"positionIdx"`
 	TimeInForce        TimeInForce `json:"timeInForce"`
 	LeavesValue        string      `json:"leavesValue"`
-	UpdatedTime        string      `json:"updatedTime"`
+	UpdatedTime        MillisTime  `json:"updatedTime"`
 	Side               Side        `json:"side"`
 	TriggerPrice       string      `json:"triggerPrice"`
 	CumExecFee         string      `json:"cumExecFee"`
@@ -185,6 +209,11 @@ type V5GetOpenOrder struct {
 
 // GetOpenOrders :
 func (s *V5OrderService) GetOpenOrders(param V5GetOpenOrdersParam) (*V5GetOpenOrdersResponse, error) {
+	return s.GetOpenOrdersContext(context.Background(), param)
+}
+
+// GetOpenOrdersContext :
+func (s *V5OrderService) GetOpenOrdersContext(ctx context.Context, param V5GetOpenOrdersParam) (*V5GetOpenOrdersResponse, error) {
 	var res V5GetOpenOrdersResponse
 
 	if param.Category == "" {
@@ -196,7 +225,7 @@ func (s *V5OrderService) GetOpenOrders(param V5GetOpenOrdersParam) (*V5GetOpenOr
 		return nil, err
 	}
 
-	if err := s.client.getV5Privately("/v5/order/realtime", queryString, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, "/v5/order/realtime", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -206,16 +235,16 @@ func (s *V5OrderService) GetOpenOrders(param V5GetOpenOrdersParam) (*V5GetOpenOr
 type V5GetExecutionListParam struct {
 	Category CategoryV5 `url:"category"`
 
-	PreUpgrade  *bool     `url:"-"`
-	StartTime   *int      `url:"startTime,omitempty"`
-	EndTime     *int      `url:"endTime,omitempty"`
-	ExecType    *ExecType `url:"execType,omitempty"`
-	Symbol      *SymbolV5 `url:"symbol,omitempty"`
-	BaseCoin    *Coin     `url:"baseCoin,omitempty"`
-	OrderID     *string   `url:"orderId,omitempty"`
-	OrderLinkID *string   `url:"orderLinkId,omitempty"`
-	Limit       *int      `url:"limit,omitempty"`
-	Cursor      *string   `url:"cursor,omitempty"`
+	PreUpgrade  *bool      `url:"-"`
+	StartTime   *time.Time `url:"startTime,omitempty,unixmilli"`
+	EndTime     *time.Time `url:"endTime,omitempty,unixmilli"`
+	ExecType    *ExecType  `url:"execType,omitempty"`
+	Symbol      *SymbolV5  `url:"symbol,omitempty"`
+	BaseCoin    *Coin      `url:"baseCoin,omitempty"`
+	OrderID     *string    `url:"orderId,omitempty"`
+	OrderLinkID *string    `url:"orderLinkId,omitempty"`
+	Limit       *int       `url:"limit,omitempty"`
+	Cursor      *string    `url:"cursor,omitempty"`
 }
 
 // V5GetOpenOrdersResponse :
@@ -232,30 +261,35 @@ type V5GetExecutionListResult struct {
 }
 
 type V5GetExecutionOrder struct {
-	Symbol        SymbolV5  `json:"symbol"`
-	OrderType     OrderType `json:"orderType"`
-	OrderLinkID   string    `json:"orderLinkId"`
-	Side          Side      `json:"side"`
-	OrderID       string    `json:"orderId"`
-	StopOrderType string    `json:"stopOrderType"`
-	LeavesQty     string    `json:"leavesQty"`
-	ExecTime      string    `json:"execTime"`
-	IsMaker       bool      `json:"isMaker"`
-	ExecFee       string    `json:"execFee"`
-	FeeRate       string    `json:"feeRate"`
-	ExecID        string    `json:"execId"`
-	MarkPrice     string    `json:"markPrice"`
-	ExecPrice     string    `json:"execPrice"`
-	OrderQty      string    `json:"orderQty"`
-	OrderPrice    string    `json:"orderPrice"`
-	ExecValue     string    `json:"execValue"`
-	ExecType      ExecType  `json:"execType"`
-	ExecQty       string    `json:"execQty"`
-	ClosedSize    string    `json:"closedSize"`
+	Symbol        SymbolV5   `json:"symbol"`
+	OrderType     OrderType  `json:"orderType"`
+	OrderLinkID   string     `json:"orderLinkId"`
+	Side          Side       `json:"side"`
+	OrderID       string     `json:"orderId"`
+	StopOrderType string     `json:"stopOrderType"`
+	LeavesQty     string     `json:"leavesQty"`
+	ExecTime      MillisTime `json:"execTime"`
+	IsMaker       bool       `json:"isMaker"`
+	ExecFee       string     `json:"execFee"`
+	FeeRate       string     `json:"feeRate"`
+	ExecID        string     `json:"execId"`
+	MarkPrice     string     `json:"markPrice"`
+	ExecPrice     string     `json:"execPrice"`
+	OrderQty      string     `json:"orderQty"`
+	OrderPrice    string     `json:"orderPrice"`
+	ExecValue     string     `json:"execValue"`
+	ExecType      ExecType   `json:"execType"`
+	ExecQty       string     `json:"execQty"`
+	ClosedSize    string     `json:"closedSize"`
 }
 
 // GetExecutionList :
 func (s *V5OrderService) GetExecutionList(param V5GetExecutionListParam) (*V5GetExecutionListResponse, error) {
+	return s.GetExecutionListContext(context.Background(), param)
+}
+
+// GetExecutionListContext :
+func (s *V5OrderService) GetExecutionListContext(ctx context.Context, param V5GetExecutionListParam) (*V5GetExecutionListResponse, error) {
 	var res V5GetExecutionListResponse
 
 	url := "/v5/execution/list"
@@ -272,7 +306,7 @@ func (s *V5OrderService) GetExecutionList(param V5GetExecutionListParam) (*V5Get
 		return nil, err
 	}
 
-	if err := s.client.getV5Privately(url, queryString, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, url, queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -282,15 +316,15 @@ func (s *V5OrderService) GetExecutionList(param V5GetExecutionListParam) (*V5Get
 type V5GetOrderListParam struct {
 	Category CategoryV5 `url:"category"`
 
-	StartTime   *int      `url:"startTime,omitempty"`
-	EndTime     *int      `url:"endTime,omitempty"`
-	ExecType    *ExecType `url:"execType,omitempty"`
-	Symbol      *SymbolV5 `url:"symbol,omitempty"`
-	BaseCoin    *Coin     `url:"baseCoin,omitempty"`
-	OrderID     *string   `url:"orderId,omitempty"`
-	OrderLinkID *string   `url:"orderLinkId,omitempty"`
-	Limit       *int      `url:"limit,omitempty"`
-	Cursor      *string   `url:"cursor,omitempty"`
+	StartTime   *time.Time `url:"startTime,omitempty,unixmilli"`
+	EndTime     *time.Time `url:"endTime,omitempty,unixmilli"`
+	ExecType    *ExecType  `url:"execType,omitempty"`
+	Symbol      *SymbolV5  `url:"symbol,omitempty"`
+	BaseCoin    *Coin      `url:"baseCoin,omitempty"`
+	OrderID     *string    `url:"orderId,omitempty"`
+	OrderLinkID *string    `url:"orderLinkId,omitempty"`
+	Limit       *int       `url:"limit,omitempty"`
+	Cursor      *string    `url:"cursor,omitempty"`
 }
 
 // V5GetOpenOrdersResponse :
@@ -312,39 +346,44 @@ type V5GetOrder struct {
 	OrderLinkID string    `json:"orderLinkId"`
 	OrderID     string    `json:"orderId"`
 
-	AvgPrice           string `json:"avgPrice"`
-	StopOrderType      string `json:"stopOrderType"`
-	LastPriceOnCreated string `json:"lastPriceOnCreated"`
-	OrderStatus        string `json:"orderStatus"`
-	TakeProfit         string `json:"takeProfit"`
-	CumExecValue       string `json:"cumExecValue"`
-	TriggerDirection   int    `json:"triggerDirection"`
-	BlockTradeID       string `json:"blockTradeId"`
-	RejectReason       string `json:"rejectReason"`
-	IsLeverage         string `json:"isLeverage"`
-	Price              string `json:"price"`
-	OrderIV            string `json:"orderIv"`
-	CreatedTime        string `json:"createdTime"`
-	TPTriggerBy        string `json:"tpTriggerBy"`
-	PositionIdx        int    `json:"positionIdx"`
-	TimeInForce        string `json:"timeInForce"`
-	LeavesValue        string `json:"leavesValue"`
-	UpdatedTime        string `json:"updatedTime"`
-	Side               Side   `json:"side"`
-	TriggerPrice       string `json:"triggerPrice"`
-	CumExecFee         string `json:"cumExecFee"`
-	SLTriggerBy        string `json:"slTriggerBy"`
-	LeavesQty          string `json:"leavesQty"`
-	CloseOnTrigger     bool   `json:"closeOnTrigger"`
-	CumExecQty         string `json:"cumExecQty"`
-	ReduceOnly         bool   `json:"reduceOnly"`
-	Qty                string `json:"qty"`
-	StopLoss           string `json:"stopLoss"`
-	TriggerBy          string `json:"triggerBy"`
+	AvgPrice           string     `json:"avgPrice"`
+	StopOrderType      string     `json:"stopOrderType"`
+	LastPriceOnCreated string     `json:"lastPriceOnCreated"`
+	OrderStatus        string     `json:"orderStatus"`
+	TakeProfit         string     `json:"takeProfit"`
+	CumExecValue       string     `json:"cumExecValue"`
+	TriggerDirection   int        `json:"triggerDirection"`
+	BlockTradeID       string     `json:"blockTradeId"`
+	RejectReason       string     `json:"rejectReason"`
+	IsLeverage         string     `json:"isLeverage"`
+	Price              string     `json:"price"`
+	OrderIV            string     `json:"orderIv"`
+	CreatedTime        MillisTime `json:"createdTime"`
+	TPTriggerBy        string     `json:"tpTriggerBy"`
+	PositionIdx        int        `json:"positionIdx"`
+	TimeInForce        string     `json:"timeInForce"`
+	LeavesValue        string     `json:"leavesValue"`
+	UpdatedTime        MillisTime `json:"updatedTime"`
+	Side               Side       `json:"side"`
+	TriggerPrice       string     `json:"triggerPrice"`
+	CumExecFee         string     `json:"cumExecFee"`
+	SLTriggerBy        string     `json:"slTriggerBy"`
+	LeavesQty          string     `json:"leavesQty"`
+	CloseOnTrigger     bool       `json:"closeOnTrigger"`
+	CumExecQty         string     `json:"cumExecQty"`
+	ReduceOnly         bool       `json:"reduceOnly"`
+	Qty                string     `json:"qty"`
+	StopLoss           string     `json:"stopLoss"`
+	TriggerBy          string     `json:"triggerBy"`
 }
 
 // GetOrderList :
 func (s *V5OrderService) GetOrderList(param V5GetOrderListParam) (*V5GetOrderListResponse, error) {
+	return s.GetOrderListContext(context.Background(), param)
+}
+
+// GetOrderListContext :
+func (s *V5OrderService) GetOrderListContext(ctx context.Context, param V5GetOrderListParam) (*V5GetOrderListResponse, error) {
 	var res V5GetOrderListResponse
 
 	if param.Category == "" {
@@ -356,7 +395,7 @@ func (s *V5OrderService) GetOrderList(param V5GetOrderListParam) (*V5GetOrderLis
 		return nil, err
 	}
 
-	if err := s.client.getV5Privately("/v5/order/history", queryString, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, "/v5/order/history", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -366,11 +405,11 @@ func (s *V5OrderService) GetOrderList(param V5GetOrderListParam) (*V5GetOrderLis
 type V5GetClosedPnlParam struct {
 	Category CategoryV5 `url:"category"`
 
-	StartTime *int      `url:"startTime,omitempty"`
-	EndTime   *int      `url:"endTime,omitempty"`
-	Symbol    *SymbolV5 `url:"symbol,omitempty"`
-	Limit     *int      `url:"limit,omitempty"`
-	Cursor    *string   `url:"cursor,omitempty"`
+	StartTime *time.Time `url:"startTime,omitempty,unixmilli"`
+	EndTime   *time.Time `url:"endTime,omitempty,unixmilli"`
+	Symbol    *SymbolV5  `url:"symbol,omitempty"`
+	Limit     *int       `url:"limit,omitempty"`
+	Cursor    *string    `url:"cursor,omitempty"`
 }
 
 // V5GetOpenOrdersResponse :
@@ -391,24 +430,29 @@ type V5GetClosedPnl struct {
 	OrderType OrderType `json:"orderType"`
 	OrderID   string    `json:"orderId"`
 
-	Leverage      string `json:"leverage"`
-	UpdatedTime   string `json:"updatedTime"`
-	Side          Side   `json:"side"`
-	ClosedPnl     string `json:"closedPnl"`
-	AvgEntryPrice string `json:"avgEntryPrice"`
-	Qty           string `json:"qty"`
-	CumEntryValue string `json:"cumEntryValue"`
-	CreatedTime   string `json:"createdTime"`
-	OrderPrice    string `json:"orderPrice"`
-	ClosedSize    string `json:"closedSize"`
-	AvgExitPrice  string `json:"avgExitPrice"`
-	ExecType      string `json:"execType"`
-	FillCount     string `json:"fillCount"`
-	CumExitValue  string `json:"cumExitValue"`
+	Leverage      string     `json:"leverage"`
+	UpdatedTime   MillisTime `json:"updatedTime"`
+	Side          Side       `json:"side"`
+	ClosedPnl     string     `json:"closedPnl"`
+	AvgEntryPrice string     `json:"avgEntryPrice"`
+	Qty           string     `json:"qty"`
+	CumEntryValue string     `json:"cumEntryValue"`
+	CreatedTime   MillisTime `json:"createdTime"`
+	OrderPrice    string     `json:"orderPrice"`
+	ClosedSize    string     `json:"closedSize"`
+	AvgExitPrice  string     `json:"avgExitPrice"`
+	ExecType      string     `json:"execType"`
+	FillCount     string     `json:"fillCount"`
+	CumExitValue  string     `json:"cumExitValue"`
 }
 
 // GetOrderList :
 func (s *V5OrderService) GetClosedPnl(param V5GetClosedPnlParam) (*V5GetClosedPnlResponse, error) {
+	return s.GetClosedPnlContext(context.Background(), param)
+}
+
+// GetClosedPnlContext :
+func (s *V5OrderService) GetClosedPnlContext(ctx context.Context, param V5GetClosedPnlParam) (*V5GetClosedPnlResponse, error) {
 	var res V5GetClosedPnlResponse
 
 	if param.Category == "" {
@@ -420,7 +464,7 @@ func (s *V5OrderService) GetClosedPnl(param V5GetClosedPnlParam) (*V5GetClosedPn
 		return nil, err
 	}
 
-	if err := s.client.getV5Privately("/v5/position/closed-pnl", queryString, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, "/v5/position/closed-pnl", queryString, &res); err != nil {
 		return nil, err
 	}
 
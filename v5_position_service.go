@@ -1,6 +1,7 @@
 package bybit
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -10,7 +11,9 @@ import (
 // V5PositionServiceI :
 type V5PositionServiceI interface {
 	GetPositionInfo(V5GetPositionInfoParam) (*V5GetPositionInfoResponse, error)
+	GetPositionInfoContext(context.Context, V5GetPositionInfoParam) (*V5GetPositionInfoResponse, error)
 	SetLeverage(V5SetLeverageParam) (*V5SetLeverageResponse, error)
+	SetLeverageContext(context.Context, V5SetLeverageParam) (*V5SetLeverageResponse, error)
 }
 
 // V5PositionService :
@@ -47,34 +50,39 @@ type V5GetPositionInfoList []V5GetPositionInfoItem
 
 // V5GetPositionInfoItem :
 type V5GetPositionInfoItem struct {
-	Symbol         SymbolV5 `json:"symbol"`
-	Leverage       string   `json:"leverage"`
-	AvgPrice       string   `json:"avgPrice"`
-	LiqPrice       string   `json:"liqPrice"`
-	RiskLimitValue string   `json:"riskLimitValue"`
-	TakeProfit     string   `json:"takeProfit"`
-	PositionValue  string   `json:"positionValue"`
-	TpSlMode       TpSlMode `json:"tpslMode"`
-	RiskID         int      `json:"riskId"`
-	TrailingStop   string   `json:"trailingStop"`
-	UnrealisedPnl  string   `json:"unrealisedPnl"`
-	MarkPrice      string   `json:"markPrice"`
-	CumRealisedPnl string   `json:"cumRealisedPnl"`
-	PositionMM     string   `json:"positionMM"`
-	CreatedTime    string   `json:"createdTime"`
-	PositionIdx    int      `json:"positionIdx"`
-	PositionIM     string   `json:"positionIM"`
-	UpdatedTime    string   `json:"updatedTime"`
-	Side           Side     `json:"side"`
-	BustPrice      string   `json:"bustPrice"`
-	Size           string   `json:"size"`
-	PositionStatus string   `json:"positionStatus"`
-	StopLoss       string   `json:"stopLoss"`
-	TradeMode      int      `json:"tradeMode"`
+	Symbol         SymbolV5   `json:"symbol"`
+	Leverage       string     `json:"leverage"`
+	AvgPrice       string     `json:"avgPrice"`
+	LiqPrice       string     `json:"liqPrice"`
+	RiskLimitValue string     `json:"riskLimitValue"`
+	TakeProfit     string     `json:"takeProfit"`
+	PositionValue  string     `json:"positionValue"`
+	TpSlMode       TpSlMode   `json:"tpslMode"`
+	RiskID         int        `json:"riskId"`
+	TrailingStop   string     `json:"trailingStop"`
+	UnrealisedPnl  string     `json:"unrealisedPnl"`
+	MarkPrice      string     `json:"markPrice"`
+	CumRealisedPnl string     `json:"cumRealisedPnl"`
+	PositionMM     string     `json:"positionMM"`
+	CreatedTime    MillisTime `json:"createdTime"`
+	PositionIdx    int        `json:"positionIdx"`
+	PositionIM     string     `json:"positionIM"`
+	UpdatedTime    MillisTime `json:"updatedTime"`
+	Side           Side       `json:"side"`
+	BustPrice      string     `json:"bustPrice"`
+	Size           string     `json:"size"`
+	PositionStatus string     `json:"positionStatus"`
+	StopLoss       string     `json:"stopLoss"`
+	TradeMode      int        `json:"tradeMode"`
 }
 
 // GetPositionInfo :
 func (s *V5PositionService) GetPositionInfo(param V5GetPositionInfoParam) (*V5GetPositionInfoResponse, error) {
+	return s.GetPositionInfoContext(context.Background(), param)
+}
+
+// GetPositionInfoContext :
+func (s *V5PositionService) GetPositionInfoContext(ctx context.Context, param V5GetPositionInfoParam) (*V5GetPositionInfoResponse, error) {
 	var res V5GetPositionInfoResponse
 
 	queryString, err := query.Values(param)
@@ -82,7 +90,7 @@ func (s *V5PositionService) GetPositionInfo(param V5GetPositionInfoParam) (*V5Ge
 		return nil, err
 	}
 
-	if err := s.client.getV5Privately("/v5/position/list", queryString, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, "/v5/position/list", queryString, &res); err != nil {
 		return nil, err
 	}
 
@@ -105,6 +113,11 @@ type V5SetLeverageResponse struct {
 
 // SetLeverage :
 func (s *V5PositionService) SetLeverage(param V5SetLeverageParam) (*V5SetLeverageResponse, error) {
+	return s.SetLeverageContext(context.Background(), param)
+}
+
+// SetLeverageContext :
+func (s *V5PositionService) SetLeverageContext(ctx context.Context, param V5SetLeverageParam) (*V5SetLeverageResponse, error) {
 	var res V5SetLeverageResponse
 
 	if param.Category == "" || param.Symbol == "" || param.BuyLeverage == "" || param.SellLeverage == "" {
@@ -116,7 +129,7 @@ func (s *V5PositionService) SetLeverage(param V5SetLeverageParam) (*V5SetLeverag
 		return &res, fmt.Errorf("json marshal: %w", err)
 	}
 
-	if err := s.client.postV5JSON("/v5/position/set-leverage", body, &res); err != nil {
+	if err := s.client.postV5JSON(ctx, "/v5/position/set-leverage", body, &res); err != nil {
 		return &res, err
 	}
 
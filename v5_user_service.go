@@ -1,6 +1,7 @@
 package bybit
 
 import (
+	"context"
 	"net/url"
 	"time"
 )
@@ -8,6 +9,7 @@ import (
 // V5UserServiceI :
 type V5UserServiceI interface {
 	GetAPIKey() (*V5APIKeyResponse, error)
+	GetAPIKeyContext(context.Context) (*V5APIKeyResponse, error)
 }
 
 // V5UserService :
@@ -55,11 +57,16 @@ type V5ApiKeyResult struct {
 
 // GetAPIKey :
 func (s *V5UserService) GetAPIKey() (*V5APIKeyResponse, error) {
+	return s.GetAPIKeyContext(context.Background())
+}
+
+// GetAPIKeyContext :
+func (s *V5UserService) GetAPIKeyContext(ctx context.Context) (*V5APIKeyResponse, error) {
 	var (
 		res V5APIKeyResponse
 	)
 
-	if err := s.client.getV5Privately("/v5/user/query-api", url.Values{}, &res); err != nil {
+	if err := s.client.getV5Privately(ctx, "/v5/user/query-api", url.Values{}, &res); err != nil {
 		return nil, err
 	}
 
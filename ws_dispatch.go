@@ -0,0 +1,66 @@
+package bybit
+
+import (
+	"context"
+	"sync"
+)
+
+// websocketDispatchHook : called before a data frame of topic is handled with the context of the run,
+// returns the context to handle it in and the func called with the result of the handler once it returns
+type websocketDispatchHook func(ctx context.Context, path, topic string) (context.Context, func(err error))
+
+// websocketDispatchConn : a connection whose data frames are handled between its hooks, see dispatchWebsocketMessage
+type websocketDispatchConn struct {
+	websocketConn
+	path  string
+	hooks []websocketDispatchHook
+
+	mu  sync.Mutex
+	ctx context.Context
+}
+
+// dispatchWebsocketMessage : calls handle for a message read from conn, between the hooks of conn when it is a data frame.
+// The context the message is handled in is the message context of conn until handle returns.
+func dispatchWebsocketMessage(ctx context.Context, conn websocketConn, message []byte, handle func() error) error {
+	c, ok := conn.(*websocketDispatchConn)
+	if !ok {
+		return handle()
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var handled []func(error)
+	if topic, _ := judgeWebsocketHealthFrame(message); topic != "" {
+		for _, hook := range c.hooks {
+			var f func(error)
+			ctx, f = hook(ctx, c.path, topic)
+			handled = append(handled, f)
+		}
+	}
+
+	c.setContext(ctx)
+	err := handle()
+	c.setContext(nil)
+	for i := len(handled) - 1; i >= 0; i-- {
+		handled[i](err)
+	}
+	return err
+}
+
+func (c *websocketDispatchConn) setContext(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ctx = ctx
+}
+
+// websocketMessageContext : of the message being handled on conn, context.Background() when none is
+func websocketMessageContext(conn websocketConn) context.Context {
+	if c, ok := conn.(*websocketDispatchConn); ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.ctx != nil {
+			return c.ctx
+		}
+	}
+	return context.Background()
+}
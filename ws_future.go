@@ -0,0 +1,155 @@
+package bybit
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FutureWebsocketService :
+type FutureWebsocketService struct {
+	client *WebSocketClient
+}
+
+// Future :
+func (c *WebSocketClient) Future() *FutureWebsocketService {
+	return &FutureWebsocketService{c}
+}
+
+// InversePerpetual :
+func (s *FutureWebsocketService) InversePerpetual() (*FutureWebsocketInversePerpetualService, error) {
+	c, err := s.client.dial(FutureWebsocketInversePerpetualPath)
+	if err != nil {
+		return nil, err
+	}
+	return &FutureWebsocketInversePerpetualService{
+		client:       s.client,
+		connection:   c,
+		paramFuncMap: map[string]func([]byte) error{},
+	}, nil
+}
+
+// USDTPerpetual :
+func (s *FutureWebsocketService) USDTPerpetual() *FutureWebsocketUSDTPerpetualService {
+	return &FutureWebsocketUSDTPerpetualService{s.client}
+}
+
+// FutureWebsocketUSDTPerpetualService :
+type FutureWebsocketUSDTPerpetualService struct {
+	client *WebSocketClient
+}
+
+// Public :
+func (s *FutureWebsocketUSDTPerpetualService) Public() (*FutureWebsocketUSDTPerpetualPublicService, error) {
+	c, err := s.client.dial(FutureWebsocketUSDTPerpetualPublicPath)
+	if err != nil {
+		return nil, err
+	}
+	return &FutureWebsocketUSDTPerpetualPublicService{
+		connection:   c,
+		paramFuncMap: map[string]func([]byte) error{},
+	}, nil
+}
+
+// Private :
+func (s *FutureWebsocketUSDTPerpetualService) Private() (*FutureWebsocketUSDTPerpetualPrivateService, error) {
+	c, err := s.client.dial(FutureWebsocketUSDTPerpetualPrivatePath)
+	if err != nil {
+		return nil, err
+	}
+	return &FutureWebsocketUSDTPerpetualPrivateService{
+		client:       s.client,
+		connection:   c,
+		paramFuncMap: map[string]func([]byte) error{},
+	}, nil
+}
+
+// FutureWebsocketEvent :
+type FutureWebsocketEvent string
+
+const (
+	// FutureWebsocketEventSubscribe :
+	FutureWebsocketEventSubscribe = FutureWebsocketEvent("subscribe")
+	// FutureWebsocketEventUnsubscribe :
+	FutureWebsocketEventUnsubscribe = FutureWebsocketEvent("unsubscribe")
+	// FutureWebsocketEventPing :
+	FutureWebsocketEventPing = FutureWebsocketEvent("ping")
+	// FutureWebsocketEventAuth :
+	FutureWebsocketEventAuth = FutureWebsocketEvent("auth")
+)
+
+// FutureWebsocketTopic :
+type FutureWebsocketTopic string
+
+const (
+	// FutureWebsocketTopicOrderBookL2 : 25 levels on each side
+	FutureWebsocketTopicOrderBookL2 = FutureWebsocketTopic("orderBookL2_25")
+	// FutureWebsocketTopicTrade :
+	FutureWebsocketTopicTrade = FutureWebsocketTopic("trade")
+	// FutureWebsocketTopicInstrumentInfo : pushed every 100ms
+	FutureWebsocketTopicInstrumentInfo = FutureWebsocketTopic("instrument_info.100ms")
+	// FutureWebsocketTopicKlineV2 : kline of inverse perpetual
+	FutureWebsocketTopicKlineV2 = FutureWebsocketTopic("klineV2")
+	// FutureWebsocketTopicCandle : kline of USDT perpetual
+	FutureWebsocketTopicCandle = FutureWebsocketTopic("candle")
+	// FutureWebsocketTopicPosition :
+	FutureWebsocketTopicPosition = FutureWebsocketTopic("position")
+	// FutureWebsocketTopicExecution :
+	FutureWebsocketTopicExecution = FutureWebsocketTopic("execution")
+	// FutureWebsocketTopicOrder :
+	FutureWebsocketTopicOrder = FutureWebsocketTopic("order")
+	// FutureWebsocketTopicStopOrder :
+	FutureWebsocketTopicStopOrder = FutureWebsocketTopic("stop_order")
+	// FutureWebsocketTopicWallet :
+	FutureWebsocketTopicWallet = FutureWebsocketTopic("wallet")
+)
+
+// FutureWebsocketParam :
+type FutureWebsocketParam struct {
+	Op   FutureWebsocketEvent `json:"op"`
+	Args []string             `json:"args,omitempty"`
+}
+
+// FutureWebsocketOperationResponse :
+type FutureWebsocketOperationResponse struct {
+	Success bool   `json:"success"`
+	RetMsg  string `json:"ret_msg"`
+	ConnID  string `json:"conn_id"`
+	Request struct {
+		Op   FutureWebsocketEvent `json:"op"`
+		Args []interface{}        `json:"args"`
+	} `json:"request"`
+}
+
+// futureWebsocketTopic : topic with its params joined by "."
+func futureWebsocketTopic(topic FutureWebsocketTopic, params ...interface{}) string {
+	result := string(topic)
+	for _, param := range params {
+		result += fmt.Sprintf(".%v", param)
+	}
+	return result
+}
+
+// judgeFutureWebsocketTopic : returns empty topic for operation responses
+func judgeFutureWebsocketTopic(respBody []byte) (string, error) {
+	result := struct {
+		Topic string `json:"topic"`
+		FutureWebsocketOperationResponse
+	}{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", err
+	}
+	if result.Request.Op != "" {
+		if !result.Success {
+			return "", fmt.Errorf("%s failed: %s", result.Request.Op, result.RetMsg)
+		}
+		return "", nil
+	}
+	return result.Topic, nil
+}
+
+func buildFutureWebsocketParam(op FutureWebsocketEvent, args ...string) ([]byte, error) {
+	return json.Marshal(FutureWebsocketParam{
+		Op:   op,
+		Args: args,
+	})
+}
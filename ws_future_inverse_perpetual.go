@@ -0,0 +1,675 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// FutureWebsocketInversePerpetualService :
+// Public and private topics share one connection, private topics need Subscribe to authenticate first.
+type FutureWebsocketInversePerpetualService struct {
+	client     *WebSocketClient
+	connection websocketConn
+
+	writeMu      sync.Mutex
+	mu           sync.RWMutex
+	paramFuncMap map[string]func([]byte) error
+}
+
+const (
+	// FutureWebsocketInversePerpetualPath :
+	FutureWebsocketInversePerpetualPath = "/realtime"
+)
+
+// FutureWebsocketInversePerpetualOrderBookResponse :
+type FutureWebsocketInversePerpetualOrderBookResponse struct {
+	Topic       string                                       `json:"topic"`
+	Type        string                                       `json:"type"`
+	Data        FutureWebsocketInversePerpetualOrderBookData `json:"data"`
+	CrossSeq    int64                                        `json:"cross_seq"`
+	TimestampE6 int64                                        `json:"timestamp_e6"`
+}
+
+// FutureWebsocketInversePerpetualOrderBookData :
+// snapshot fills Snapshot, delta fills Delete, Update and Insert.
+type FutureWebsocketInversePerpetualOrderBookData struct {
+	Snapshot   []FutureWebsocketInversePerpetualOrderBookItem
+	Delete     []FutureWebsocketInversePerpetualOrderBookItem `json:"delete"`
+	Update     []FutureWebsocketInversePerpetualOrderBookItem `json:"update"`
+	Insert     []FutureWebsocketInversePerpetualOrderBookItem `json:"insert"`
+	TransactID int                                            `json:"transactID"`
+}
+
+// FutureWebsocketInversePerpetualOrderBookItem :
+type FutureWebsocketInversePerpetualOrderBookItem struct {
+	Price  string        `json:"price"`
+	Symbol SymbolInverse `json:"symbol"`
+	ID     int64         `json:"id"`
+	Side   Side          `json:"side"`
+	Size   float64       `json:"size"`
+}
+
+type futureWebsocketInversePerpetualOrderBookDelta struct {
+	Delete     []FutureWebsocketInversePerpetualOrderBookItem `json:"delete"`
+	Update     []FutureWebsocketInversePerpetualOrderBookItem `json:"update"`
+	Insert     []FutureWebsocketInversePerpetualOrderBookItem `json:"insert"`
+	TransactID int                                            `json:"transactID"`
+}
+
+// UnmarshalJSON :
+func (d *FutureWebsocketInversePerpetualOrderBookData) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &d.Snapshot); err == nil {
+		return nil
+	}
+	var delta futureWebsocketInversePerpetualOrderBookDelta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		return err
+	}
+	d.Delete = delta.Delete
+	d.Update = delta.Update
+	d.Insert = delta.Insert
+	d.TransactID = delta.TransactID
+	return nil
+}
+
+// MarshalJSON :
+func (d FutureWebsocketInversePerpetualOrderBookData) MarshalJSON() ([]byte, error) {
+	if d.Snapshot != nil {
+		return json.Marshal(d.Snapshot)
+	}
+	return json.Marshal(futureWebsocketInversePerpetualOrderBookDelta{
+		Delete:     d.Delete,
+		Update:     d.Update,
+		Insert:     d.Insert,
+		TransactID: d.TransactID,
+	})
+}
+
+// FutureWebsocketInversePerpetualTradeResponse :
+type FutureWebsocketInversePerpetualTradeResponse struct {
+	Topic string                                     `json:"topic"`
+	Data  []FutureWebsocketInversePerpetualTradeItem `json:"data"`
+}
+
+// FutureWebsocketInversePerpetualTradeItem :
+type FutureWebsocketInversePerpetualTradeItem struct {
+	Timestamp     string        `json:"timestamp"`
+	TradeTimeMs   int64         `json:"trade_time_ms"`
+	Symbol        SymbolInverse `json:"symbol"`
+	Side          Side          `json:"side"`
+	Size          float64       `json:"size"`
+	Price         float64       `json:"price"`
+	TickDirection TickDirection `json:"tick_direction"`
+	TradeID       string        `json:"trade_id"`
+	CrossSeq      int64         `json:"cross_seq"`
+}
+
+// FutureWebsocketInversePerpetualInstrumentInfoResponse :
+type FutureWebsocketInversePerpetualInstrumentInfoResponse struct {
+	Topic       string                                            `json:"topic"`
+	Type        string                                            `json:"type"`
+	Data        FutureWebsocketInversePerpetualInstrumentInfoData `json:"data"`
+	CrossSeq    int64                                             `json:"cross_seq"`
+	TimestampE6 int64                                             `json:"timestamp_e6"`
+}
+
+// FutureWebsocketInversePerpetualInstrumentInfoData :
+// snapshot fills Snapshot, delta fills Delete, Update and Insert with the changed fields only.
+type FutureWebsocketInversePerpetualInstrumentInfoData struct {
+	Snapshot *FutureWebsocketInversePerpetualInstrumentInfoItem
+	Delete   []FutureWebsocketInversePerpetualInstrumentInfoItem `json:"delete"`
+	Update   []FutureWebsocketInversePerpetualInstrumentInfoItem `json:"update"`
+	Insert   []FutureWebsocketInversePerpetualInstrumentInfoItem `json:"insert"`
+}
+
+type futureWebsocketInversePerpetualInstrumentInfoDelta struct {
+	Delete []FutureWebsocketInversePerpetualInstrumentInfoItem `json:"delete"`
+	Update []FutureWebsocketInversePerpetualInstrumentInfoItem `json:"update"`
+	Insert []FutureWebsocketInversePerpetualInstrumentInfoItem `json:"insert"`
+}
+
+// UnmarshalJSON :
+func (d *FutureWebsocketInversePerpetualInstrumentInfoData) UnmarshalJSON(data []byte) error {
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	_, hasDelete := keys["delete"]
+	_, hasUpdate := keys["update"]
+	_, hasInsert := keys["insert"]
+	if !hasDelete && !hasUpdate && !hasInsert {
+		return json.Unmarshal(data, &d.Snapshot)
+	}
+	var delta futureWebsocketInversePerpetualInstrumentInfoDelta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		return err
+	}
+	d.Delete = delta.Delete
+	d.Update = delta.Update
+	d.Insert = delta.Insert
+	return nil
+}
+
+// MarshalJSON :
+func (d FutureWebsocketInversePerpetualInstrumentInfoData) MarshalJSON() ([]byte, error) {
+	if d.Snapshot != nil {
+		return json.Marshal(d.Snapshot)
+	}
+	return json.Marshal(futureWebsocketInversePerpetualInstrumentInfoDelta{
+		Delete: d.Delete,
+		Update: d.Update,
+		Insert: d.Insert,
+	})
+}
+
+// FutureWebsocketInversePerpetualInstrumentInfoItem :
+type FutureWebsocketInversePerpetualInstrumentInfoItem struct {
+	ID                     int64         `json:"id"`
+	Symbol                 SymbolInverse `json:"symbol"`
+	LastPriceE4            int64         `json:"last_price_e4"`
+	LastPrice              string        `json:"last_price"`
+	Bid1PriceE4            int64         `json:"bid1_price_e4"`
+	Bid1Price              string        `json:"bid1_price"`
+	Ask1PriceE4            int64         `json:"ask1_price_e4"`
+	Ask1Price              string        `json:"ask1_price"`
+	LastTickDirection      TickDirection `json:"last_tick_direction"`
+	PrevPrice24hE4         int64         `json:"prev_price_24h_e4"`
+	PrevPrice24h           string        `json:"prev_price_24h"`
+	Price24hPcntE6         int64         `json:"price_24h_pcnt_e6"`
+	HighPrice24hE4         int64         `json:"high_price_24h_e4"`
+	HighPrice24h           string        `json:"high_price_24h"`
+	LowPrice24hE4          int64         `json:"low_price_24h_e4"`
+	LowPrice24h            string        `json:"low_price_24h"`
+	PrevPrice1hE4          int64         `json:"prev_price_1h_e4"`
+	PrevPrice1h            string        `json:"prev_price_1h"`
+	Price1hPcntE6          int64         `json:"price_1h_pcnt_e6"`
+	MarkPriceE4            int64         `json:"mark_price_e4"`
+	MarkPrice              string        `json:"mark_price"`
+	IndexPriceE4           int64         `json:"index_price_e4"`
+	IndexPrice             string        `json:"index_price"`
+	OpenInterest           int64         `json:"open_interest"`
+	OpenValueE8            int64         `json:"open_value_e8"`
+	TotalTurnoverE8        int64         `json:"total_turnover_e8"`
+	Turnover24hE8          int64         `json:"turnover_24h_e8"`
+	TotalVolume            int64         `json:"total_volume"`
+	Volume24h              int64         `json:"volume_24h"`
+	FundingRateE6          int64         `json:"funding_rate_e6"`
+	PredictedFundingRateE6 int64         `json:"predicted_funding_rate_e6"`
+	CrossSeq               int64         `json:"cross_seq"`
+	CreatedAt              string        `json:"created_at"`
+	UpdatedAt              string        `json:"updated_at"`
+	NextFundingTime        string        `json:"next_funding_time"`
+	CountdownHour          int           `json:"countdown_hour"`
+	FundingRateInterval    int           `json:"funding_rate_interval"`
+}
+
+// FutureWebsocketInversePerpetualKlineResponse :
+type FutureWebsocketInversePerpetualKlineResponse struct {
+	Topic       string                                     `json:"topic"`
+	Data        []FutureWebsocketInversePerpetualKlineItem `json:"data"`
+	TimestampE6 int64                                      `json:"timestamp_e6"`
+}
+
+// FutureWebsocketInversePerpetualKlineItem :
+type FutureWebsocketInversePerpetualKlineItem struct {
+	Start     int64   `json:"start"`
+	End       int64   `json:"end"`
+	Open      float64 `json:"open"`
+	Close     float64 `json:"close"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Volume    float64 `json:"volume"`
+	Turnover  float64 `json:"turnover"`
+	Confirm   bool    `json:"confirm"`
+	CrossSeq  int64   `json:"cross_seq"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// FutureWebsocketInversePerpetualPositionResponse :
+type FutureWebsocketInversePerpetualPositionResponse struct {
+	Topic  string                                        `json:"topic"`
+	Action string                                        `json:"action"`
+	Data   []FutureWebsocketInversePerpetualPositionItem `json:"data"`
+}
+
+// FutureWebsocketInversePerpetualPositionItem :
+type FutureWebsocketInversePerpetualPositionItem struct {
+	UserID           int           `json:"user_id"`
+	Symbol           SymbolInverse `json:"symbol"`
+	Size             float64       `json:"size"`
+	Side             Side          `json:"side"`
+	PositionValue    string        `json:"position_value"`
+	EntryPrice       string        `json:"entry_price"`
+	LiqPrice         string        `json:"liq_price"`
+	BustPrice        string        `json:"bust_price"`
+	Leverage         string        `json:"leverage"`
+	OrderMargin      string        `json:"order_margin"`
+	PositionMargin   string        `json:"position_margin"`
+	AvailableBalance string        `json:"available_balance"`
+	TakeProfit       string        `json:"take_profit"`
+	TpTriggerBy      string        `json:"tp_trigger_by"`
+	StopLoss         string        `json:"stop_loss"`
+	SlTriggerBy      string        `json:"sl_trigger_by"`
+	RealisedPnl      string        `json:"realised_pnl"`
+	TrailingStop     string        `json:"trailing_stop"`
+	TrailingActive   string        `json:"trailing_active"`
+	WalletBalance    string        `json:"wallet_balance"`
+	RiskID           int           `json:"risk_id"`
+	OccClosingFee    string        `json:"occ_closing_fee"`
+	OccFundingFee    string        `json:"occ_funding_fee"`
+	AutoAddMargin    int           `json:"auto_add_margin"`
+	CumRealisedPnl   string        `json:"cum_realised_pnl"`
+	PositionStatus   string        `json:"position_status"`
+	PositionSeq      int64         `json:"position_seq"`
+	Isolated         bool          `json:"Isolated"`
+	Mode             int           `json:"mode"`
+	PositionIdx      int           `json:"position_idx"`
+	TpSlMode         TpSlMode      `json:"tp_sl_mode"`
+	TpOrderNum       int           `json:"tp_order_num"`
+	SlOrderNum       int           `json:"sl_order_num"`
+	TpFreeSizeX      int64         `json:"tp_free_size_x"`
+	SlFreeSizeX      int64         `json:"sl_free_size_x"`
+}
+
+// FutureWebsocketInversePerpetualExecutionResponse :
+type FutureWebsocketInversePerpetualExecutionResponse struct {
+	Topic string                                         `json:"topic"`
+	Data  []FutureWebsocketInversePerpetualExecutionItem `json:"data"`
+}
+
+// FutureWebsocketInversePerpetualExecutionItem :
+type FutureWebsocketInversePerpetualExecutionItem struct {
+	Symbol      SymbolInverse `json:"symbol"`
+	Side        Side          `json:"side"`
+	OrderID     string        `json:"order_id"`
+	ExecID      string        `json:"exec_id"`
+	OrderLinkID string        `json:"order_link_id"`
+	Price       string        `json:"price"`
+	OrderQty    float64       `json:"order_qty"`
+	ExecType    ExecType      `json:"exec_type"`
+	ExecQty     float64       `json:"exec_qty"`
+	ExecFee     string        `json:"exec_fee"`
+	LeavesQty   float64       `json:"leaves_qty"`
+	IsMaker     bool          `json:"is_maker"`
+	TradeTime   string        `json:"trade_time"`
+}
+
+// FutureWebsocketInversePerpetualOrderResponse :
+type FutureWebsocketInversePerpetualOrderResponse struct {
+	Topic string                                     `json:"topic"`
+	Data  []FutureWebsocketInversePerpetualOrderItem `json:"data"`
+}
+
+// FutureWebsocketInversePerpetualOrderItem :
+type FutureWebsocketInversePerpetualOrderItem struct {
+	OrderID        string        `json:"order_id"`
+	OrderLinkID    string        `json:"order_link_id"`
+	Symbol         SymbolInverse `json:"symbol"`
+	Side           Side          `json:"side"`
+	OrderType      OrderType     `json:"order_type"`
+	Price          string        `json:"price"`
+	Qty            float64       `json:"qty"`
+	TimeInForce    TimeInForce   `json:"time_in_force"`
+	CreateType     string        `json:"create_type"`
+	CancelType     string        `json:"cancel_type"`
+	OrderStatus    OrderStatus   `json:"order_status"`
+	LeavesQty      float64       `json:"leaves_qty"`
+	CumExecQty     float64       `json:"cum_exec_qty"`
+	CumExecValue   string        `json:"cum_exec_value"`
+	CumExecFee     string        `json:"cum_exec_fee"`
+	Timestamp      string        `json:"timestamp"`
+	TakeProfit     string        `json:"take_profit"`
+	TpTriggerBy    string        `json:"tp_trigger_by"`
+	StopLoss       string        `json:"stop_loss"`
+	SlTriggerBy    string        `json:"sl_trigger_by"`
+	TrailingStop   string        `json:"trailing_stop"`
+	LastExecPrice  string        `json:"last_exec_price"`
+	ReduceOnly     bool          `json:"reduce_only"`
+	CloseOnTrigger bool          `json:"close_on_trigger"`
+}
+
+// FutureWebsocketInversePerpetualStopOrderResponse :
+type FutureWebsocketInversePerpetualStopOrderResponse struct {
+	Topic string                                         `json:"topic"`
+	Data  []FutureWebsocketInversePerpetualStopOrderItem `json:"data"`
+}
+
+// FutureWebsocketInversePerpetualStopOrderItem :
+type FutureWebsocketInversePerpetualStopOrderItem struct {
+	OrderID        string        `json:"order_id"`
+	OrderLinkID    string        `json:"order_link_id"`
+	UserID         int           `json:"user_id"`
+	Symbol         SymbolInverse `json:"symbol"`
+	Side           Side          `json:"side"`
+	OrderType      OrderType     `json:"order_type"`
+	Price          string        `json:"price"`
+	Qty            float64       `json:"qty"`
+	TimeInForce    TimeInForce   `json:"time_in_force"`
+	CreateType     string        `json:"create_type"`
+	CancelType     string        `json:"cancel_type"`
+	OrderStatus    string        `json:"order_status"`
+	StopOrderType  string        `json:"stop_order_type"`
+	TriggerBy      string        `json:"trigger_by"`
+	TriggerPrice   string        `json:"trigger_price"`
+	CloseOnTrigger bool          `json:"close_on_trigger"`
+	Timestamp      string        `json:"timestamp"`
+	TakeProfit     float64       `json:"take_profit"`
+	StopLoss       float64       `json:"stop_loss"`
+}
+
+// FutureWebsocketInversePerpetualWalletResponse :
+type FutureWebsocketInversePerpetualWalletResponse struct {
+	Topic string                                      `json:"topic"`
+	Data  []FutureWebsocketInversePerpetualWalletItem `json:"data"`
+}
+
+// FutureWebsocketInversePerpetualWalletItem :
+type FutureWebsocketInversePerpetualWalletItem struct {
+	UserID           int    `json:"user_id"`
+	Coin             Coin   `json:"coin"`
+	AvailableBalance string `json:"available_balance"`
+	WalletBalance    string `json:"wallet_balance"`
+}
+
+// addParamFunc :
+func (s *FutureWebsocketInversePerpetualService) addParamFunc(topic string, f func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramFuncMap[topic]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramFuncMap[topic] = f
+	return nil
+}
+
+// removeParamFunc :
+func (s *FutureWebsocketInversePerpetualService) removeParamFunc(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramFuncMap, topic)
+}
+
+// retrieveFunc :
+func (s *FutureWebsocketInversePerpetualService) retrieveFunc(topic string) (func([]byte) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramFuncMap[topic]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// judgeTopic :
+func (s *FutureWebsocketInversePerpetualService) judgeTopic(respBody []byte) (string, error) {
+	return judgeFutureWebsocketTopic(respBody)
+}
+
+// parseResponse :
+func (s *FutureWebsocketInversePerpetualService) parseResponse(respBody []byte, response interface{}) error {
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeMessage : gorilla/websocket supports only one concurrent writer
+func (s *FutureWebsocketInversePerpetualService) writeMessage(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.connection.WriteMessage(messageType, data)
+}
+
+// subscribe :
+func (s *FutureWebsocketInversePerpetualService) subscribe(topic string, f func([]byte) error) (func() error, error) {
+	if err := s.addParamFunc(topic, f); err != nil {
+		return nil, err
+	}
+	buf, err := buildFutureWebsocketParam(FutureWebsocketEventSubscribe, topic)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		s.removeParamFunc(topic)
+		return nil, err
+	}
+
+	return func() error {
+		buf, err := buildFutureWebsocketParam(FutureWebsocketEventUnsubscribe, topic)
+		if err != nil {
+			return err
+		}
+		if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+			return err
+		}
+		s.removeParamFunc(topic)
+		return nil
+	}, nil
+}
+
+// Subscribe : authenticates the connection, needed before subscribing private topics
+func (s *FutureWebsocketInversePerpetualService) Subscribe() error {
+	param, err := s.client.buildAuthParam()
+	if err != nil {
+		return err
+	}
+	if err := s.writeMessage(websocket.TextMessage, param); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SubscribeOrderBookL2 : orderBookL2_25
+func (s *FutureWebsocketInversePerpetualService) SubscribeOrderBookL2(symbol SymbolInverse, f func(FutureWebsocketInversePerpetualOrderBookResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicOrderBookL2, symbol), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualOrderBookResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeTrade :
+func (s *FutureWebsocketInversePerpetualService) SubscribeTrade(symbol SymbolInverse, f func(FutureWebsocketInversePerpetualTradeResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicTrade, symbol), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualTradeResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeInstrumentInfo :
+func (s *FutureWebsocketInversePerpetualService) SubscribeInstrumentInfo(symbol SymbolInverse, f func(FutureWebsocketInversePerpetualInstrumentInfoResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicInstrumentInfo, symbol), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualInstrumentInfoResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeKline : klineV2
+func (s *FutureWebsocketInversePerpetualService) SubscribeKline(symbol SymbolInverse, interval Interval, f func(FutureWebsocketInversePerpetualKlineResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicKlineV2, interval, symbol), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualKlineResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribePosition :
+func (s *FutureWebsocketInversePerpetualService) SubscribePosition(f func(FutureWebsocketInversePerpetualPositionResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicPosition), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualPositionResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeExecution :
+func (s *FutureWebsocketInversePerpetualService) SubscribeExecution(f func(FutureWebsocketInversePerpetualExecutionResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicExecution), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualExecutionResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeOrder :
+func (s *FutureWebsocketInversePerpetualService) SubscribeOrder(f func(FutureWebsocketInversePerpetualOrderResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicOrder), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualOrderResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeStopOrder :
+func (s *FutureWebsocketInversePerpetualService) SubscribeStopOrder(f func(FutureWebsocketInversePerpetualStopOrderResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicStopOrder), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualStopOrderResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeWallet :
+func (s *FutureWebsocketInversePerpetualService) SubscribeWallet(f func(FutureWebsocketInversePerpetualWalletResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicWallet), func(message []byte) error {
+		var resp FutureWebsocketInversePerpetualWalletResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// Start :
+func (s *FutureWebsocketInversePerpetualService) Start(ctx context.Context) {
+	parent := ctx
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			if err := s.RunContext(parent); err != nil {
+				if IsErrWebsocketClosed(err) {
+					return
+				}
+				log.Println(err)
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(20 * time.Second)
+	defer ticker.Stop()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.Ping(); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			log.Println("interrupt")
+
+			if err := s.Close(); err != nil {
+				return
+			}
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+			return
+		}
+	}
+}
+
+// Run :
+func (s *FutureWebsocketInversePerpetualService) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *FutureWebsocketInversePerpetualService) RunContext(ctx context.Context) error {
+	_, message, err := s.connection.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
+
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *FutureWebsocketInversePerpetualService) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *FutureWebsocketInversePerpetualService) handle(message []byte) error {
+	topic, err := s.judgeTopic(message)
+	if err != nil {
+		return err
+	}
+	if topic == "" {
+		return nil
+	}
+	f, err := s.retrieveFunc(topic)
+	if err != nil {
+		return err
+	}
+	if err := f(message); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Ping :
+func (s *FutureWebsocketInversePerpetualService) Ping() error {
+	buf, err := buildFutureWebsocketParam(FutureWebsocketEventPing)
+	if err != nil {
+		return err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close :
+func (s *FutureWebsocketInversePerpetualService) Close() error {
+	if err := s.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
+		return err
+	}
+	return nil
+}
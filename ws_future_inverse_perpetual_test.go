@@ -0,0 +1,131 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFutureWebsocketInversePerpetualOrderBookL2(t *testing.T) {
+	t.Run("snapshot", func(t *testing.T) {
+		respBody := FutureWebsocketInversePerpetualOrderBookResponse{
+			Topic: "orderBookL2_25.BTCUSD",
+			Type:  "snapshot",
+			Data: FutureWebsocketInversePerpetualOrderBookData{
+				Snapshot: []FutureWebsocketInversePerpetualOrderBookItem{
+					{Price: "2999.00", Symbol: SymbolInverseBTCUSD, ID: 29990000, Side: SideBuy, Size: 9},
+					{Price: "3001.00", Symbol: SymbolInverseBTCUSD, ID: 30010000, Side: SideSell, Size: 10},
+				},
+			},
+			CrossSeq:    11518,
+			TimestampE6: 1555743483000000,
+		}
+		bytesBody, err := json.Marshal(respBody)
+		require.NoError(t, err)
+
+		server, teardown := testhelper.NewWebsocketServer(
+			testhelper.WithWebsocketHandlerOption(FutureWebsocketInversePerpetualPath, bytesBody),
+		)
+		defer teardown()
+
+		wsClient := NewTestWebsocketClient().
+			WithBaseURL(server.URL)
+
+		svc, err := wsClient.Future().InversePerpetual()
+		require.NoError(t, err)
+
+		unsubscribe, err := svc.SubscribeOrderBookL2(SymbolInverseBTCUSD, func(response FutureWebsocketInversePerpetualOrderBookResponse) error {
+			assert.Equal(t, respBody, response)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, svc.Run())
+		assert.NoError(t, unsubscribe())
+		assert.NoError(t, svc.Ping())
+		assert.NoError(t, svc.Close())
+	})
+	t.Run("delta", func(t *testing.T) {
+		respBody := FutureWebsocketInversePerpetualOrderBookResponse{
+			Topic: "orderBookL2_25.BTCUSD",
+			Type:  "delta",
+			Data: FutureWebsocketInversePerpetualOrderBookData{
+				Delete: []FutureWebsocketInversePerpetualOrderBookItem{
+					{Price: "3001.00", Symbol: SymbolInverseBTCUSD, ID: 30010000, Side: SideSell},
+				},
+				Update: []FutureWebsocketInversePerpetualOrderBookItem{
+					{Price: "2999.00", Symbol: SymbolInverseBTCUSD, ID: 29990000, Side: SideBuy, Size: 8},
+				},
+				Insert: []FutureWebsocketInversePerpetualOrderBookItem{
+					{Price: "2998.00", Symbol: SymbolInverseBTCUSD, ID: 29980000, Side: SideBuy, Size: 8},
+				},
+				TransactID: 0,
+			},
+			CrossSeq:    11519,
+			TimestampE6: 1555743483000001,
+		}
+		bytesBody, err := json.Marshal(respBody)
+		require.NoError(t, err)
+
+		server, teardown := testhelper.NewWebsocketServer(
+			testhelper.WithWebsocketHandlerOption(FutureWebsocketInversePerpetualPath, bytesBody),
+		)
+		defer teardown()
+
+		wsClient := NewTestWebsocketClient().
+			WithBaseURL(server.URL)
+
+		svc, err := wsClient.Future().InversePerpetual()
+		require.NoError(t, err)
+
+		_, err = svc.SubscribeOrderBookL2(SymbolInverseBTCUSD, func(response FutureWebsocketInversePerpetualOrderBookResponse) error {
+			assert.Equal(t, respBody, response)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.NoError(t, svc.Run())
+		assert.NoError(t, svc.Close())
+	})
+}
+
+func TestFutureWebsocketInversePerpetualWallet(t *testing.T) {
+	respBody := FutureWebsocketInversePerpetualWalletResponse{
+		Topic: "wallet",
+		Data: []FutureWebsocketInversePerpetualWalletItem{
+			{
+				UserID:           738713,
+				Coin:             CoinBTC,
+				AvailableBalance: "1.50121026",
+				WalletBalance:    "1.50121261",
+			},
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(FutureWebsocketInversePerpetualPath, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Future().InversePerpetual()
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Subscribe())
+
+	_, err = svc.SubscribeWallet(func(response FutureWebsocketInversePerpetualWalletResponse) error {
+		assert.Equal(t, respBody, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, svc.Close())
+}
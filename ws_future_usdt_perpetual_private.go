@@ -0,0 +1,418 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// FutureWebsocketUSDTPerpetualPrivateService :
+type FutureWebsocketUSDTPerpetualPrivateService struct {
+	client     *WebSocketClient
+	connection websocketConn
+
+	writeMu      sync.Mutex
+	mu           sync.RWMutex
+	paramFuncMap map[string]func([]byte) error
+}
+
+const (
+	// FutureWebsocketUSDTPerpetualPrivatePath :
+	FutureWebsocketUSDTPerpetualPrivatePath = "/realtime_private"
+)
+
+// FutureWebsocketUSDTPerpetualPositionResponse :
+type FutureWebsocketUSDTPerpetualPositionResponse struct {
+	Topic  string                                     `json:"topic"`
+	Action string                                     `json:"action"`
+	Data   []FutureWebsocketUSDTPerpetualPositionItem `json:"data"`
+}
+
+// FutureWebsocketUSDTPerpetualPositionItem :
+type FutureWebsocketUSDTPerpetualPositionItem struct {
+	UserID           string     `json:"user_id"`
+	Symbol           SymbolUSDT `json:"symbol"`
+	Size             float64    `json:"size"`
+	Side             Side       `json:"side"`
+	PositionValue    string     `json:"position_value"`
+	EntryPrice       string     `json:"entry_price"`
+	LiqPrice         string     `json:"liq_price"`
+	BustPrice        string     `json:"bust_price"`
+	Leverage         string     `json:"leverage"`
+	OrderMargin      string     `json:"order_margin"`
+	PositionMargin   string     `json:"position_margin"`
+	OccClosingFee    string     `json:"occ_closing_fee"`
+	TakeProfit       string     `json:"take_profit"`
+	TpTriggerBy      string     `json:"tp_trigger_by"`
+	StopLoss         string     `json:"stop_loss"`
+	SlTriggerBy      string     `json:"sl_trigger_by"`
+	TrailingStop     string     `json:"trailing_stop"`
+	RealisedPnl      string     `json:"realised_pnl"`
+	AutoAddMargin    string     `json:"auto_add_margin"`
+	CumRealisedPnl   string     `json:"cum_realised_pnl"`
+	PositionStatus   string     `json:"position_status"`
+	PositionID       string     `json:"position_id"`
+	PositionSeq      string     `json:"position_seq"`
+	AdlRankIndicator string     `json:"adl_rank_indicator"`
+	FreeQty          float64    `json:"free_qty"`
+	TpSlMode         TpSlMode   `json:"tp_sl_mode"`
+	RiskID           string     `json:"risk_id"`
+	Isolated         bool       `json:"isolated"`
+	Mode             string     `json:"mode"`
+	PositionIdx      string     `json:"position_idx"`
+}
+
+// FutureWebsocketUSDTPerpetualExecutionResponse :
+type FutureWebsocketUSDTPerpetualExecutionResponse struct {
+	Topic string                                      `json:"topic"`
+	Data  []FutureWebsocketUSDTPerpetualExecutionItem `json:"data"`
+}
+
+// FutureWebsocketUSDTPerpetualExecutionItem :
+type FutureWebsocketUSDTPerpetualExecutionItem struct {
+	Symbol      SymbolUSDT `json:"symbol"`
+	Side        Side       `json:"side"`
+	OrderID     string     `json:"order_id"`
+	ExecID      string     `json:"exec_id"`
+	OrderLinkID string     `json:"order_link_id"`
+	Price       float64    `json:"price"`
+	OrderQty    float64    `json:"order_qty"`
+	ExecType    ExecType   `json:"exec_type"`
+	ExecQty     float64    `json:"exec_qty"`
+	ExecFee     float64    `json:"exec_fee"`
+	LeavesQty   float64    `json:"leaves_qty"`
+	IsMaker     bool       `json:"is_maker"`
+	TradeTime   string     `json:"trade_time"`
+}
+
+// FutureWebsocketUSDTPerpetualOrderResponse :
+type FutureWebsocketUSDTPerpetualOrderResponse struct {
+	Topic  string                                  `json:"topic"`
+	Action string                                  `json:"action"`
+	Data   []FutureWebsocketUSDTPerpetualOrderItem `json:"data"`
+}
+
+// FutureWebsocketUSDTPerpetualOrderItem :
+type FutureWebsocketUSDTPerpetualOrderItem struct {
+	OrderID        string      `json:"order_id"`
+	OrderLinkID    string      `json:"order_link_id"`
+	Symbol         SymbolUSDT  `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Price          float64     `json:"price"`
+	Qty            float64     `json:"qty"`
+	LeavesQty      float64     `json:"leaves_qty"`
+	LastExecPrice  float64     `json:"last_exec_price"`
+	CumExecQty     float64     `json:"cum_exec_qty"`
+	CumExecValue   float64     `json:"cum_exec_value"`
+	CumExecFee     float64     `json:"cum_exec_fee"`
+	TimeInForce    TimeInForce `json:"time_in_force"`
+	CreateType     string      `json:"create_type"`
+	CancelType     string      `json:"cancel_type"`
+	OrderStatus    OrderStatus `json:"order_status"`
+	TakeProfit     float64     `json:"take_profit"`
+	StopLoss       float64     `json:"stop_loss"`
+	TrailingStop   float64     `json:"trailing_stop"`
+	CreateTime     string      `json:"create_time"`
+	UpdateTime     string      `json:"update_time"`
+	ReduceOnly     bool        `json:"reduce_only"`
+	CloseOnTrigger bool        `json:"close_on_trigger"`
+	PositionIdx    string      `json:"position_idx"`
+}
+
+// FutureWebsocketUSDTPerpetualStopOrderResponse :
+type FutureWebsocketUSDTPerpetualStopOrderResponse struct {
+	Topic string                                      `json:"topic"`
+	Data  []FutureWebsocketUSDTPerpetualStopOrderItem `json:"data"`
+}
+
+// FutureWebsocketUSDTPerpetualStopOrderItem :
+type FutureWebsocketUSDTPerpetualStopOrderItem struct {
+	StopOrderID    string      `json:"stop_order_id"`
+	OrderLinkID    string      `json:"order_link_id"`
+	UserID         string      `json:"user_id"`
+	Symbol         SymbolUSDT  `json:"symbol"`
+	Side           Side        `json:"side"`
+	OrderType      OrderType   `json:"order_type"`
+	Price          float64     `json:"price"`
+	Qty            float64     `json:"qty"`
+	TimeInForce    TimeInForce `json:"time_in_force"`
+	OrderStatus    string      `json:"order_status"`
+	TriggerPrice   float64     `json:"trigger_price"`
+	TriggerBy      string      `json:"trigger_by"`
+	CreateTime     string      `json:"create_time"`
+	UpdateTime     string      `json:"update_time"`
+	ReduceOnly     bool        `json:"reduce_only"`
+	CloseOnTrigger bool        `json:"close_on_trigger"`
+	PositionIdx    string      `json:"position_idx"`
+}
+
+// FutureWebsocketUSDTPerpetualWalletResponse :
+type FutureWebsocketUSDTPerpetualWalletResponse struct {
+	Topic string                                   `json:"topic"`
+	Data  []FutureWebsocketUSDTPerpetualWalletItem `json:"data"`
+}
+
+// FutureWebsocketUSDTPerpetualWalletItem :
+type FutureWebsocketUSDTPerpetualWalletItem struct {
+	WalletBalance    float64 `json:"wallet_balance"`
+	AvailableBalance float64 `json:"available_balance"`
+}
+
+// addParamFunc :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) addParamFunc(topic string, f func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramFuncMap[topic]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramFuncMap[topic] = f
+	return nil
+}
+
+// removeParamFunc :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) removeParamFunc(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramFuncMap, topic)
+}
+
+// retrieveFunc :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) retrieveFunc(topic string) (func([]byte) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramFuncMap[topic]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// judgeTopic :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) judgeTopic(respBody []byte) (string, error) {
+	return judgeFutureWebsocketTopic(respBody)
+}
+
+// parseResponse :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) parseResponse(respBody []byte, response interface{}) error {
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeMessage : gorilla/websocket supports only one concurrent writer
+func (s *FutureWebsocketUSDTPerpetualPrivateService) writeMessage(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.connection.WriteMessage(messageType, data)
+}
+
+// subscribe :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) subscribe(topic string, f func([]byte) error) (func() error, error) {
+	if err := s.addParamFunc(topic, f); err != nil {
+		return nil, err
+	}
+	buf, err := buildFutureWebsocketParam(FutureWebsocketEventSubscribe, topic)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		s.removeParamFunc(topic)
+		return nil, err
+	}
+
+	return func() error {
+		buf, err := buildFutureWebsocketParam(FutureWebsocketEventUnsubscribe, topic)
+		if err != nil {
+			return err
+		}
+		if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+			return err
+		}
+		s.removeParamFunc(topic)
+		return nil
+	}, nil
+}
+
+// Subscribe : authenticates the connection, needed before subscribing any topic
+func (s *FutureWebsocketUSDTPerpetualPrivateService) Subscribe() error {
+	param, err := s.client.buildAuthParam()
+	if err != nil {
+		return err
+	}
+	if err := s.writeMessage(websocket.TextMessage, param); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SubscribePosition :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) SubscribePosition(f func(FutureWebsocketUSDTPerpetualPositionResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicPosition), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualPositionResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeExecution :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) SubscribeExecution(f func(FutureWebsocketUSDTPerpetualExecutionResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicExecution), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualExecutionResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeOrder :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) SubscribeOrder(f func(FutureWebsocketUSDTPerpetualOrderResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicOrder), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualOrderResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeStopOrder :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) SubscribeStopOrder(f func(FutureWebsocketUSDTPerpetualStopOrderResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicStopOrder), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualStopOrderResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeWallet :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) SubscribeWallet(f func(FutureWebsocketUSDTPerpetualWalletResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicWallet), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualWalletResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// Start :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) Start(ctx context.Context) {
+	parent := ctx
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			if err := s.RunContext(parent); err != nil {
+				if IsErrWebsocketClosed(err) {
+					return
+				}
+				log.Println(err)
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(20 * time.Second)
+	defer ticker.Stop()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.Ping(); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			log.Println("interrupt")
+
+			if err := s.Close(); err != nil {
+				return
+			}
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+			return
+		}
+	}
+}
+
+// Run :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *FutureWebsocketUSDTPerpetualPrivateService) RunContext(ctx context.Context) error {
+	_, message, err := s.connection.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
+
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *FutureWebsocketUSDTPerpetualPrivateService) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) handle(message []byte) error {
+	topic, err := s.judgeTopic(message)
+	if err != nil {
+		return err
+	}
+	if topic == "" {
+		return nil
+	}
+	f, err := s.retrieveFunc(topic)
+	if err != nil {
+		return err
+	}
+	if err := f(message); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Ping :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) Ping() error {
+	buf, err := buildFutureWebsocketParam(FutureWebsocketEventPing)
+	if err != nil {
+		return err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close :
+func (s *FutureWebsocketUSDTPerpetualPrivateService) Close() error {
+	if err := s.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
+		return err
+	}
+	return nil
+}
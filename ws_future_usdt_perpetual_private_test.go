@@ -0,0 +1,56 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFutureWebsocketUSDTPerpetualPrivateExecution(t *testing.T) {
+	respBody := FutureWebsocketUSDTPerpetualExecutionResponse{
+		Topic: "execution",
+		Data: []FutureWebsocketUSDTPerpetualExecutionItem{
+			{
+				Symbol:    SymbolUSDTBTC,
+				Side:      SideSell,
+				OrderID:   "xxxxxxxx-xxxx-xxxx-9a8f-4a973eb5c418",
+				ExecID:    "xxxxxxxx-xxxx-xxxx-8b66-c3d2fcd352f6",
+				Price:     11527.5,
+				OrderQty:  0.001,
+				ExecType:  ExecTypeTrade,
+				ExecQty:   0.001,
+				ExecFee:   0.00864563,
+				TradeTime: "2020-08-12T21:16:18.142746Z",
+			},
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(FutureWebsocketUSDTPerpetualPrivatePath, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Future().USDTPerpetual().Private()
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Subscribe())
+
+	unsubscribe, err := svc.SubscribeExecution(func(response FutureWebsocketUSDTPerpetualExecutionResponse) error {
+		assert.Equal(t, respBody, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
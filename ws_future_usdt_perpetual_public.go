@@ -0,0 +1,424 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// FutureWebsocketUSDTPerpetualPublicService :
+type FutureWebsocketUSDTPerpetualPublicService struct {
+	connection websocketConn
+
+	writeMu      sync.Mutex
+	mu           sync.RWMutex
+	paramFuncMap map[string]func([]byte) error
+}
+
+const (
+	// FutureWebsocketUSDTPerpetualPublicPath :
+	FutureWebsocketUSDTPerpetualPublicPath = "/realtime_public"
+)
+
+// FutureWebsocketUSDTPerpetualOrderBookResponse :
+type FutureWebsocketUSDTPerpetualOrderBookResponse struct {
+	Topic       string                                    `json:"topic"`
+	Type        string                                    `json:"type"`
+	Data        FutureWebsocketUSDTPerpetualOrderBookData `json:"data"`
+	CrossSeq    string                                    `json:"cross_seq"`
+	TimestampE6 string                                    `json:"timestamp_e6"`
+}
+
+// FutureWebsocketUSDTPerpetualOrderBookData :
+// snapshot fills OrderBook, delta fills Delete, Update and Insert.
+type FutureWebsocketUSDTPerpetualOrderBookData struct {
+	OrderBook []FutureWebsocketUSDTPerpetualOrderBookItem `json:"order_book,omitempty"`
+	Delete    []FutureWebsocketUSDTPerpetualOrderBookItem `json:"delete,omitempty"`
+	Update    []FutureWebsocketUSDTPerpetualOrderBookItem `json:"update,omitempty"`
+	Insert    []FutureWebsocketUSDTPerpetualOrderBookItem `json:"insert,omitempty"`
+}
+
+// FutureWebsocketUSDTPerpetualOrderBookItem :
+type FutureWebsocketUSDTPerpetualOrderBookItem struct {
+	Price  string     `json:"price"`
+	Symbol SymbolUSDT `json:"symbol"`
+	ID     string     `json:"id"`
+	Side   Side       `json:"side"`
+	Size   float64    `json:"size"`
+}
+
+// FutureWebsocketUSDTPerpetualTradeResponse :
+type FutureWebsocketUSDTPerpetualTradeResponse struct {
+	Topic string                                  `json:"topic"`
+	Data  []FutureWebsocketUSDTPerpetualTradeItem `json:"data"`
+}
+
+// FutureWebsocketUSDTPerpetualTradeItem :
+type FutureWebsocketUSDTPerpetualTradeItem struct {
+	Symbol        SymbolUSDT    `json:"symbol"`
+	TickDirection TickDirection `json:"tick_direction"`
+	Price         string        `json:"price"`
+	Size          float64       `json:"size"`
+	Timestamp     string        `json:"timestamp"`
+	TradeTimeMs   string        `json:"trade_time_ms"`
+	Side          Side          `json:"side"`
+	TradeID       string        `json:"trade_id"`
+}
+
+// FutureWebsocketUSDTPerpetualInstrumentInfoResponse :
+type FutureWebsocketUSDTPerpetualInstrumentInfoResponse struct {
+	Topic       string                                         `json:"topic"`
+	Type        string                                         `json:"type"`
+	Data        FutureWebsocketUSDTPerpetualInstrumentInfoData `json:"data"`
+	CrossSeq    string                                         `json:"cross_seq"`
+	TimestampE6 string                                         `json:"timestamp_e6"`
+}
+
+// FutureWebsocketUSDTPerpetualInstrumentInfoData :
+// snapshot fills Snapshot, delta fills Delete, Update and Insert with the changed fields only.
+type FutureWebsocketUSDTPerpetualInstrumentInfoData struct {
+	Snapshot *FutureWebsocketUSDTPerpetualInstrumentInfoItem
+	Delete   []FutureWebsocketUSDTPerpetualInstrumentInfoItem `json:"delete"`
+	Update   []FutureWebsocketUSDTPerpetualInstrumentInfoItem `json:"update"`
+	Insert   []FutureWebsocketUSDTPerpetualInstrumentInfoItem `json:"insert"`
+}
+
+type futureWebsocketUSDTPerpetualInstrumentInfoDelta struct {
+	Delete []FutureWebsocketUSDTPerpetualInstrumentInfoItem `json:"delete"`
+	Update []FutureWebsocketUSDTPerpetualInstrumentInfoItem `json:"update"`
+	Insert []FutureWebsocketUSDTPerpetualInstrumentInfoItem `json:"insert"`
+}
+
+// UnmarshalJSON :
+func (d *FutureWebsocketUSDTPerpetualInstrumentInfoData) UnmarshalJSON(data []byte) error {
+	keys := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	_, hasDelete := keys["delete"]
+	_, hasUpdate := keys["update"]
+	_, hasInsert := keys["insert"]
+	if !hasDelete && !hasUpdate && !hasInsert {
+		return json.Unmarshal(data, &d.Snapshot)
+	}
+	var delta futureWebsocketUSDTPerpetualInstrumentInfoDelta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		return err
+	}
+	d.Delete = delta.Delete
+	d.Update = delta.Update
+	d.Insert = delta.Insert
+	return nil
+}
+
+// MarshalJSON :
+func (d FutureWebsocketUSDTPerpetualInstrumentInfoData) MarshalJSON() ([]byte, error) {
+	if d.Snapshot != nil {
+		return json.Marshal(d.Snapshot)
+	}
+	return json.Marshal(futureWebsocketUSDTPerpetualInstrumentInfoDelta{
+		Delete: d.Delete,
+		Update: d.Update,
+		Insert: d.Insert,
+	})
+}
+
+// FutureWebsocketUSDTPerpetualInstrumentInfoItem :
+type FutureWebsocketUSDTPerpetualInstrumentInfoItem struct {
+	ID                     int64         `json:"id"`
+	Symbol                 SymbolUSDT    `json:"symbol"`
+	LastPriceE4            string        `json:"last_price_e4"`
+	LastPrice              string        `json:"last_price"`
+	Bid1PriceE4            string        `json:"bid1_price_e4"`
+	Bid1Price              string        `json:"bid1_price"`
+	Ask1PriceE4            string        `json:"ask1_price_e4"`
+	Ask1Price              string        `json:"ask1_price"`
+	LastTickDirection      TickDirection `json:"last_tick_direction"`
+	PrevPrice24hE4         string        `json:"prev_price_24h_e4"`
+	PrevPrice24h           string        `json:"prev_price_24h"`
+	Price24hPcntE6         string        `json:"price_24h_pcnt_e6"`
+	HighPrice24hE4         string        `json:"high_price_24h_e4"`
+	HighPrice24h           string        `json:"high_price_24h"`
+	LowPrice24hE4          string        `json:"low_price_24h_e4"`
+	LowPrice24h            string        `json:"low_price_24h"`
+	PrevPrice1hE4          string        `json:"prev_price_1h_e4"`
+	PrevPrice1h            string        `json:"prev_price_1h"`
+	Price1hPcntE6          string        `json:"price_1h_pcnt_e6"`
+	MarkPriceE4            string        `json:"mark_price_e4"`
+	MarkPrice              string        `json:"mark_price"`
+	IndexPriceE4           string        `json:"index_price_e4"`
+	IndexPrice             string        `json:"index_price"`
+	OpenInterestE8         string        `json:"open_interest_e8"`
+	TotalTurnoverE8        string        `json:"total_turnover_e8"`
+	Turnover24hE8          string        `json:"turnover_24h_e8"`
+	TotalVolumeE8          string        `json:"total_volume_e8"`
+	Volume24hE8            string        `json:"volume_24h_e8"`
+	FundingRateE6          string        `json:"funding_rate_e6"`
+	PredictedFundingRateE6 string        `json:"predicted_funding_rate_e6"`
+	CrossSeq               string        `json:"cross_seq"`
+	CreatedAt              string        `json:"created_at"`
+	UpdatedAt              string        `json:"updated_at"`
+	NextFundingTime        string        `json:"next_funding_time"`
+	CountDownHour          string        `json:"count_down_hour"`
+	FundingRateInterval    string        `json:"funding_rate_interval"`
+	SettleTimeE9           string        `json:"settle_time_e9"`
+	DelistingStatus        string        `json:"delisting_status"`
+}
+
+// FutureWebsocketUSDTPerpetualCandleResponse :
+type FutureWebsocketUSDTPerpetualCandleResponse struct {
+	Topic       string                                   `json:"topic"`
+	Data        []FutureWebsocketUSDTPerpetualCandleItem `json:"data"`
+	TimestampE6 int64                                    `json:"timestamp_e6"`
+}
+
+// FutureWebsocketUSDTPerpetualCandleItem :
+type FutureWebsocketUSDTPerpetualCandleItem struct {
+	Start     int64   `json:"start"`
+	End       int64   `json:"end"`
+	Period    string  `json:"period"`
+	Open      float64 `json:"open"`
+	Close     float64 `json:"close"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Volume    string  `json:"volume"`
+	Turnover  string  `json:"turnover"`
+	Confirm   bool    `json:"confirm"`
+	CrossSeq  int64   `json:"cross_seq"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+// addParamFunc :
+func (s *FutureWebsocketUSDTPerpetualPublicService) addParamFunc(topic string, f func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramFuncMap[topic]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramFuncMap[topic] = f
+	return nil
+}
+
+// removeParamFunc :
+func (s *FutureWebsocketUSDTPerpetualPublicService) removeParamFunc(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramFuncMap, topic)
+}
+
+// retrieveFunc :
+func (s *FutureWebsocketUSDTPerpetualPublicService) retrieveFunc(topic string) (func([]byte) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramFuncMap[topic]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// judgeTopic :
+func (s *FutureWebsocketUSDTPerpetualPublicService) judgeTopic(respBody []byte) (string, error) {
+	return judgeFutureWebsocketTopic(respBody)
+}
+
+// parseResponse :
+func (s *FutureWebsocketUSDTPerpetualPublicService) parseResponse(respBody []byte, response interface{}) error {
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeMessage : gorilla/websocket supports only one concurrent writer
+func (s *FutureWebsocketUSDTPerpetualPublicService) writeMessage(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.connection.WriteMessage(messageType, data)
+}
+
+// subscribe :
+func (s *FutureWebsocketUSDTPerpetualPublicService) subscribe(topic string, f func([]byte) error) (func() error, error) {
+	if err := s.addParamFunc(topic, f); err != nil {
+		return nil, err
+	}
+	buf, err := buildFutureWebsocketParam(FutureWebsocketEventSubscribe, topic)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		s.removeParamFunc(topic)
+		return nil, err
+	}
+
+	return func() error {
+		buf, err := buildFutureWebsocketParam(FutureWebsocketEventUnsubscribe, topic)
+		if err != nil {
+			return err
+		}
+		if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+			return err
+		}
+		s.removeParamFunc(topic)
+		return nil
+	}, nil
+}
+
+// SubscribeOrderBookL2 : orderBookL2_25
+func (s *FutureWebsocketUSDTPerpetualPublicService) SubscribeOrderBookL2(symbol SymbolUSDT, f func(FutureWebsocketUSDTPerpetualOrderBookResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicOrderBookL2, symbol), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualOrderBookResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeTrade :
+func (s *FutureWebsocketUSDTPerpetualPublicService) SubscribeTrade(symbol SymbolUSDT, f func(FutureWebsocketUSDTPerpetualTradeResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicTrade, symbol), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualTradeResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeInstrumentInfo :
+func (s *FutureWebsocketUSDTPerpetualPublicService) SubscribeInstrumentInfo(symbol SymbolUSDT, f func(FutureWebsocketUSDTPerpetualInstrumentInfoResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicInstrumentInfo, symbol), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualInstrumentInfoResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// SubscribeKline : candle
+func (s *FutureWebsocketUSDTPerpetualPublicService) SubscribeKline(symbol SymbolUSDT, interval Interval, f func(FutureWebsocketUSDTPerpetualCandleResponse) error) (func() error, error) {
+	return s.subscribe(futureWebsocketTopic(FutureWebsocketTopicCandle, interval, symbol), func(message []byte) error {
+		var resp FutureWebsocketUSDTPerpetualCandleResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	})
+}
+
+// Start :
+func (s *FutureWebsocketUSDTPerpetualPublicService) Start(ctx context.Context) {
+	parent := ctx
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			if err := s.RunContext(parent); err != nil {
+				if IsErrWebsocketClosed(err) {
+					return
+				}
+				log.Println(err)
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(20 * time.Second)
+	defer ticker.Stop()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.Ping(); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			log.Println("interrupt")
+
+			if err := s.Close(); err != nil {
+				return
+			}
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+			return
+		}
+	}
+}
+
+// Run :
+func (s *FutureWebsocketUSDTPerpetualPublicService) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *FutureWebsocketUSDTPerpetualPublicService) RunContext(ctx context.Context) error {
+	_, message, err := s.connection.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
+
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *FutureWebsocketUSDTPerpetualPublicService) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *FutureWebsocketUSDTPerpetualPublicService) handle(message []byte) error {
+	topic, err := s.judgeTopic(message)
+	if err != nil {
+		return err
+	}
+	if topic == "" {
+		return nil
+	}
+	f, err := s.retrieveFunc(topic)
+	if err != nil {
+		return err
+	}
+	if err := f(message); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Ping :
+func (s *FutureWebsocketUSDTPerpetualPublicService) Ping() error {
+	buf, err := buildFutureWebsocketParam(FutureWebsocketEventPing)
+	if err != nil {
+		return err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close :
+func (s *FutureWebsocketUSDTPerpetualPublicService) Close() error {
+	if err := s.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFutureWebsocketUSDTPerpetualPublicInstrumentInfo(t *testing.T) {
+	respBody := FutureWebsocketUSDTPerpetualInstrumentInfoResponse{
+		Topic: "instrument_info.100ms.BTCUSDT",
+		Type:  "delta",
+		Data: FutureWebsocketUSDTPerpetualInstrumentInfoData{
+			Update: []FutureWebsocketUSDTPerpetualInstrumentInfoItem{
+				{
+					ID:          1,
+					Symbol:      SymbolUSDTBTC,
+					LastPriceE4: "81165000",
+					LastPrice:   "8116.50",
+					CrossSeq:    "1053192657",
+					UpdatedAt:   "2020-06-23T08:32:29Z",
+				},
+			},
+		},
+		CrossSeq:    "1053192657",
+		TimestampE6: "1592901149350426",
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(FutureWebsocketUSDTPerpetualPublicPath, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Future().USDTPerpetual().Public()
+	require.NoError(t, err)
+
+	unsubscribe, err := svc.SubscribeInstrumentInfo(SymbolUSDTBTC, func(response FutureWebsocketUSDTPerpetualInstrumentInfoResponse) error {
+		assert.Equal(t, respBody, response)
+		assert.Nil(t, response.Data.Snapshot)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
@@ -0,0 +1,321 @@
+package bybit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebsocketHealthMonitor :
+// WebsocketHealthMonitor watches the connections of a WebSocketClient for missing pongs and quiet topics.
+type WebsocketHealthMonitor struct {
+	pongTimeout   time.Duration
+	checkInterval time.Duration
+	thresholds    map[string]time.Duration
+	changeFunc    func(WebsocketHealthStatus)
+	now           func() time.Time
+
+	mu          sync.Mutex
+	started     time.Time
+	connections []*websocketConnectionHealth
+	topics      map[string]time.Time
+	last        *WebsocketHealthStatus
+}
+
+// WebsocketHealthStatus :
+type WebsocketHealthStatus struct {
+	Healthy bool
+	// StaleTopics : topics quiet for longer than their threshold, in lexical order
+	StaleTopics []string
+	Connections []WebsocketConnectionHealth
+}
+
+// WebsocketConnectionHealth :
+type WebsocketConnectionHealth struct {
+	Path        string
+	LastMessage time.Time
+	LastPing    time.Time
+	LastPong    time.Time
+	// PongOverdue : no pong has arrived within the pong timeout of the last ping
+	PongOverdue bool
+}
+
+type websocketConnectionHealth struct {
+	path        string
+	lastMessage time.Time
+	lastPing    time.Time
+	lastPong    time.Time
+}
+
+// NewWebsocketHealthMonitor :
+func NewWebsocketHealthMonitor() *WebsocketHealthMonitor {
+	return &WebsocketHealthMonitor{
+		pongTimeout:   10 * time.Second,
+		checkInterval: time.Second,
+		thresholds:    map[string]time.Duration{},
+		now:           time.Now,
+		topics:        map[string]time.Time{},
+	}
+}
+
+// WithPongTimeout :
+func (m *WebsocketHealthMonitor) WithPongTimeout(d time.Duration) *WebsocketHealthMonitor {
+	m.pongTimeout = d
+
+	return m
+}
+
+// WithCheckInterval : how often Start checks
+func (m *WebsocketHealthMonitor) WithCheckInterval(d time.Duration) *WebsocketHealthMonitor {
+	m.checkInterval = d
+
+	return m
+}
+
+// WithStaleThreshold : the topic is stale once no frame of it arrived for d.
+// topic is the topic field of the frame, e.g. orderbook.50.BTCUSDT, followed by "." and the symbol for spot v1 (trade.BTCUSDT).
+func (m *WebsocketHealthMonitor) WithStaleThreshold(topic string, d time.Duration) *WebsocketHealthMonitor {
+	m.thresholds[topic] = d
+
+	return m
+}
+
+// OnChange : f is called by Check whenever the health or the set of stale topics changes
+func (m *WebsocketHealthMonitor) OnChange(f func(WebsocketHealthStatus)) *WebsocketHealthMonitor {
+	m.changeFunc = f
+
+	return m
+}
+
+// Status : health as of now
+func (m *WebsocketHealthMonitor) Status() WebsocketHealthStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status(m.now())
+}
+
+// status : m.mu must be held
+func (m *WebsocketHealthMonitor) status(now time.Time) WebsocketHealthStatus {
+	result := WebsocketHealthStatus{
+		Healthy:     true,
+		StaleTopics: []string{},
+		Connections: []WebsocketConnectionHealth{},
+	}
+	for _, c := range m.connections {
+		health := WebsocketConnectionHealth{
+			Path:        c.path,
+			LastMessage: c.lastMessage,
+			LastPing:    c.lastPing,
+			LastPong:    c.lastPong,
+		}
+		if !c.lastPing.IsZero() && c.lastPong.Before(c.lastPing) && now.Sub(c.lastPing) > m.pongTimeout {
+			health.PongOverdue = true
+			result.Healthy = false
+		}
+		result.Connections = append(result.Connections, health)
+	}
+	if m.started.IsZero() {
+		return result
+	}
+	for topic, threshold := range m.thresholds {
+		last, ok := m.topics[topic]
+		if !ok {
+			last = m.started
+		}
+		if now.Sub(last) > threshold {
+			result.StaleTopics = append(result.StaleTopics, topic)
+			result.Healthy = false
+		}
+	}
+	sort.Strings(result.StaleTopics)
+	return result
+}
+
+// Check : evaluates the health and calls the OnChange func if it changed
+func (m *WebsocketHealthMonitor) Check() WebsocketHealthStatus {
+	m.mu.Lock()
+	status := m.status(m.now())
+	changed := m.last == nil || m.last.Healthy != status.Healthy || !equalStrings(m.last.StaleTopics, status.StaleTopics)
+	m.last = &status
+	m.mu.Unlock()
+
+	if changed && m.changeFunc != nil {
+		m.changeFunc(status)
+	}
+	return status
+}
+
+// Start : checks every check interval until ctx is done
+func (m *WebsocketHealthMonitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check()
+		}
+	}
+}
+
+// wrap :
+func (m *WebsocketHealthMonitor) wrap(path string, conn websocketConn) websocketConn {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state := &websocketConnectionHealth{path: path}
+	m.connections = append(m.connections, state)
+	if m.started.IsZero() {
+		m.started = m.now()
+	}
+	if ws, ok := conn.(*websocket.Conn); ok {
+		ws.SetPongHandler(func(string) error {
+			m.ponged(state)
+			return nil
+		})
+	}
+	return &websocketHealthConn{
+		websocketConn: conn,
+		monitor:       m,
+		state:         state,
+	}
+}
+
+// forget : the connection is closed, its health no longer counts
+func (m *WebsocketHealthMonitor) forget(state *websocketConnectionHealth) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, c := range m.connections {
+		if c == state {
+			m.connections = append(m.connections[:i], m.connections[i+1:]...)
+			return
+		}
+	}
+}
+
+func (m *WebsocketHealthMonitor) ponged(state *websocketConnectionHealth) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state.lastPong = m.now()
+}
+
+func (m *WebsocketHealthMonitor) pinged(state *websocketConnectionHealth) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state.lastPing = m.now()
+}
+
+// observe : records an inbound frame
+func (m *WebsocketHealthMonitor) observe(state *websocketConnectionHealth, message []byte) {
+	topic, pong := judgeWebsocketHealthFrame(message)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	state.lastMessage = now
+	if pong {
+		state.lastPong = now
+	}
+	if topic != "" {
+		m.topics[topic] = now
+	}
+}
+
+// judgeWebsocketHealthFrame : topic of a data frame and whether the frame answers a ping
+func judgeWebsocketHealthFrame(message []byte) (string, bool) {
+	frame := struct {
+		Topic  string          `json:"topic"`
+		Symbol string          `json:"symbol"`
+		Params json.RawMessage `json:"params"`
+		Op     string          `json:"op"`
+		RetMsg string          `json:"ret_msg"`
+		Pong   json.RawMessage `json:"pong"`
+	}{}
+	if err := json.Unmarshal(message, &frame); err != nil {
+		return "", false
+	}
+	pong := frame.Op == "pong" || frame.RetMsg == "pong" || frame.Pong != nil
+
+	symbol := frame.Symbol
+	if symbol == "" && len(frame.Params) > 0 {
+		params := struct {
+			Symbol string `json:"symbol"`
+		}{}
+		if err := json.Unmarshal(frame.Params, &params); err == nil {
+			symbol = params.Symbol
+		}
+	}
+	topic := frame.Topic
+	if topic != "" && symbol != "" {
+		topic += "." + symbol
+	}
+	return topic, pong
+}
+
+type websocketHealthConn struct {
+	websocketConn
+	monitor *WebsocketHealthMonitor
+	state   *websocketConnectionHealth
+}
+
+// ReadMessage :
+func (c *websocketHealthConn) ReadMessage() (int, []byte, error) {
+	messageType, message, err := c.websocketConn.ReadMessage()
+	if err == nil {
+		c.monitor.observe(c.state, message)
+	}
+	return messageType, message, err
+}
+
+// Close : drops the connection from the monitor
+func (c *websocketHealthConn) Close() error {
+	c.monitor.forget(c.state)
+	return c.websocketConn.Close()
+}
+
+// WriteMessage : control pings and JSON pings both start the pong timeout,
+// a close frame drops the connection from the monitor as the services close that way
+func (c *websocketHealthConn) WriteMessage(messageType int, data []byte) error {
+	if err := c.websocketConn.WriteMessage(messageType, data); err != nil {
+		return err
+	}
+	switch {
+	case messageType == websocket.CloseMessage:
+		c.monitor.forget(c.state)
+	case messageType == websocket.PingMessage || isWebsocketJSONPing(data):
+		c.monitor.pinged(c.state)
+	}
+	return nil
+}
+
+func isWebsocketJSONPing(data []byte) bool {
+	if !bytes.Contains(data, []byte("ping")) {
+		return false
+	}
+	frame := struct {
+		Op   string          `json:"op"`
+		Ping json.RawMessage `json:"ping"`
+	}{}
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return false
+	}
+	return frame.Op == "ping" || frame.Ping != nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
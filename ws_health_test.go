@@ -0,0 +1,130 @@
+package bybit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketHealthMonitor(t *testing.T) {
+	respBody := map[string]interface{}{
+		"topic": "tickers.BTCUSDT",
+		"type":  "snapshot",
+		"ts":    1673853746003,
+		"data": map[string]interface{}{
+			"symbol":    "BTCUSDT",
+			"lastPrice": "21109.77",
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(V5WebsocketPublicPath+"/"+string(CategoryV5Linear), bytesBody),
+	)
+	defer teardown()
+
+	now := time.Unix(1673853746, 0)
+	statuses := []WebsocketHealthStatus{}
+	monitor := NewWebsocketHealthMonitor().
+		WithPongTimeout(10*time.Second).
+		WithStaleThreshold("tickers.BTCUSDT", 5*time.Second).
+		OnChange(func(status WebsocketHealthStatus) {
+			statuses = append(statuses, status)
+		})
+	monitor.now = func() time.Time { return now }
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+	wsClient.WithHealthMonitor(monitor)
+
+	svc, err := wsClient.V5().Public(CategoryV5Linear)
+	require.NoError(t, err)
+	_, err = svc.SubscribeTicker(V5WebsocketPublicTickerParamKey{Symbol: SymbolV5BTCUSDT}, func(V5WebsocketPublicTickerResponse) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	now = now.Add(time.Second)
+	require.NoError(t, svc.Run())
+	assert.True(t, monitor.Check().Healthy)
+
+	now = now.Add(6 * time.Second)
+	status := monitor.Check()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, status.StaleTopics)
+
+	require.NoError(t, svc.Ping())
+	require.NoError(t, svc.Run())
+	status = monitor.Check()
+	assert.True(t, status.Healthy)
+	assert.Equal(t, now, status.Connections[0].LastPing)
+
+	now = now.Add(11 * time.Second)
+	status = monitor.Check()
+	assert.False(t, status.Healthy)
+	assert.True(t, status.Connections[0].PongOverdue)
+
+	monitor.observe(monitor.connections[0], []byte(`{"success":true,"ret_msg":"pong","conn_id":"1","op":"ping"}`))
+	status = monitor.Status()
+	assert.False(t, status.Connections[0].PongOverdue)
+
+	require.Len(t, statuses, 4)
+	assert.Equal(t, []bool{true, false, true, false}, []bool{statuses[0].Healthy, statuses[1].Healthy, statuses[2].Healthy, statuses[3].Healthy})
+	assert.NoError(t, svc.Close())
+}
+
+func TestJudgeWebsocketHealthFrame(t *testing.T) {
+	tests := []struct {
+		message string
+		topic   string
+		pong    bool
+	}{
+		{`{"topic":"orderbook.50.BTCUSDT","type":"delta","data":{}}`, "orderbook.50.BTCUSDT", false},
+		{`{"symbol":"BTCUSDT","topic":"trade","params":{"binary":"false"},"data":[]}`, "trade.BTCUSDT", false},
+		{`{"topic":"bookTicker","params":{"symbol":"BTCUSDT","binary":"false"},"data":{}}`, "bookTicker.BTCUSDT", false},
+		{`{"op":"pong","args":["1675418560633"]}`, "", true},
+		{`{"success":true,"ret_msg":"pong","request":{"op":"ping","args":null}}`, "", true},
+		{`{"pong":1535975085152}`, "", true},
+		{`[{"e":"outboundAccountInfo"}]`, "", false},
+	}
+	for _, tt := range tests {
+		topic, pong := judgeWebsocketHealthFrame([]byte(tt.message))
+		assert.Equal(t, tt.topic, topic, tt.message)
+		assert.Equal(t, tt.pong, pong, tt.message)
+	}
+}
+
+func TestWebsocketHealthMonitorReconnect(t *testing.T) {
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(V5WebsocketPublicPath+"/"+string(CategoryV5Linear), []byte(`{"success":true,"ret_msg":"pong","conn_id":"1","op":"ping"}`)),
+	)
+	defer teardown()
+
+	now := time.Unix(1673853746, 0)
+	monitor := NewWebsocketHealthMonitor().WithPongTimeout(10 * time.Second)
+	monitor.now = func() time.Time { return now }
+	wsClient := NewTestWebsocketClient().WithBaseURL(server.URL)
+	wsClient.WithHealthMonitor(monitor)
+
+	svc, err := wsClient.V5().Public(CategoryV5Linear)
+	require.NoError(t, err)
+	require.NoError(t, svc.Ping())
+	now = now.Add(11 * time.Second)
+	status := monitor.Check()
+	require.False(t, status.Healthy)
+	require.True(t, status.Connections[0].PongOverdue)
+
+	require.NoError(t, svc.Close())
+	reconnected, err := wsClient.V5().Public(CategoryV5Linear)
+	require.NoError(t, err)
+	defer reconnected.Close()
+
+	status = monitor.Check()
+	assert.True(t, status.Healthy, "the closed connection no longer counts")
+	assert.Len(t, status.Connections, 1)
+}
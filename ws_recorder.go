@@ -0,0 +1,289 @@
+package bybit
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebsocketRecord : one inbound frame, stored as a JSON line
+type WebsocketRecord struct {
+	// Time : receive time in unix nanoseconds
+	Time int64 `json:"t"`
+	// Path : path the connection was dialed to
+	Path string `json:"p"`
+	// Connection : 0 for the first connection dialed to Path, 1 for the second and so on
+	Connection int    `json:"c"`
+	Message    string `json:"m"`
+}
+
+// WebsocketRecorder :
+// WebsocketRecorder appends every inbound frame of the connections of a WebSocketClient to a writer.
+type WebsocketRecorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	closer io.Closer
+	dials  map[string]int
+}
+
+// NewWebsocketRecorder :
+func NewWebsocketRecorder(w io.Writer) *WebsocketRecorder {
+	return &WebsocketRecorder{
+		w:     w,
+		enc:   json.NewEncoder(w),
+		dials: map[string]int{},
+	}
+}
+
+// OpenWebsocketRecorder : appends to the named file, compressed when the name ends with .gz
+func OpenWebsocketRecorder(name string) (*WebsocketRecorder, error) {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	r := NewWebsocketRecorder(f)
+	r.closer = f
+	if strings.HasSuffix(name, ".gz") {
+		r.WithGzip()
+	}
+	return r, nil
+}
+
+// WithGzip : must be called before the first frame is recorded
+func (r *WebsocketRecorder) WithGzip() *WebsocketRecorder {
+	r.gz = gzip.NewWriter(r.w)
+	r.enc = json.NewEncoder(r.gz)
+
+	return r
+}
+
+// record :
+func (r *WebsocketRecorder) record(path string, connection int, message []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(WebsocketRecord{
+		Time:       time.Now().UnixNano(),
+		Path:       path,
+		Connection: connection,
+		Message:    string(message),
+	})
+}
+
+// Flush : writes out frames held by the gzip writer
+func (r *WebsocketRecorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.gz == nil {
+		return nil
+	}
+	return r.gz.Flush()
+}
+
+// Close : closes the file opened by OpenWebsocketRecorder as well
+func (r *WebsocketRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.gz != nil {
+		if err := r.gz.Close(); err != nil {
+			return err
+		}
+	}
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
+
+// wrap :
+func (r *WebsocketRecorder) wrap(path string, conn websocketConn) websocketConn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	connection := r.dials[path]
+	r.dials[path]++
+	return &websocketRecordingConn{
+		websocketConn: conn,
+		recorder:      r,
+		path:          path,
+		connection:    connection,
+	}
+}
+
+type websocketRecordingConn struct {
+	websocketConn
+	recorder   *WebsocketRecorder
+	path       string
+	connection int
+}
+
+// ReadMessage : a frame that can not be recorded is still delivered
+func (c *websocketRecordingConn) ReadMessage() (int, []byte, error) {
+	messageType, message, err := c.websocketConn.ReadMessage()
+	if err != nil {
+		return messageType, message, err
+	}
+	if err := c.recorder.record(c.path, c.connection, message); err != nil {
+		log.Println(err)
+	}
+	return messageType, message, nil
+}
+
+// WebsocketReplayer :
+// WebsocketReplayer feeds a recording back to the services of a WebSocketClient.
+// The n-th connection dialed to a path receives the frames the n-th connection to that path recorded.
+type WebsocketReplayer struct {
+	records map[string][][]WebsocketRecord
+	origin  int64
+	speed   float64
+
+	mu        sync.Mutex
+	dials     map[string]int
+	startOnce sync.Once
+	start     time.Time
+}
+
+// NewWebsocketReplayer : reads the whole recording, gzip is detected
+func NewWebsocketReplayer(r io.Reader) (*WebsocketReplayer, error) {
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		src = gz
+	}
+
+	replayer := &WebsocketReplayer{
+		records: map[string][][]WebsocketRecord{},
+		speed:   1,
+		dials:   map[string]int{},
+	}
+	dec := json.NewDecoder(src)
+	for {
+		var record WebsocketRecord
+		if err := dec.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if record.Connection < 0 {
+			return nil, errors.New("negative connection in record")
+		}
+		if replayer.origin == 0 || record.Time < replayer.origin {
+			replayer.origin = record.Time
+		}
+		connections := replayer.records[record.Path]
+		for len(connections) <= record.Connection {
+			connections = append(connections, nil)
+		}
+		connections[record.Connection] = append(connections[record.Connection], record)
+		replayer.records[record.Path] = connections
+	}
+	return replayer, nil
+}
+
+// OpenWebsocketReplayer :
+func OpenWebsocketReplayer(name string) (*WebsocketReplayer, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewWebsocketReplayer(f)
+}
+
+// WithSpeed : 1 replays at the original pace, 10 ten times faster and 0 as fast as possible
+func (r *WebsocketReplayer) WithSpeed(speed float64) *WebsocketReplayer {
+	r.speed = speed
+
+	return r
+}
+
+// dial :
+func (r *WebsocketReplayer) dial(path string) websocketConn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	connection := r.dials[path]
+	r.dials[path]++
+	var records []WebsocketRecord
+	if connections := r.records[path]; connection < len(connections) {
+		records = connections[connection]
+	}
+	return &websocketReplayConn{
+		replayer: r,
+		records:  records,
+		done:     make(chan struct{}),
+	}
+}
+
+// due : when the frame received at t is replayed, the clock starts with the first read of any connection
+func (r *WebsocketReplayer) due(t int64) time.Time {
+	r.startOnce.Do(func() {
+		r.start = time.Now()
+	})
+	if r.speed <= 0 {
+		return time.Time{}
+	}
+	return r.start.Add(time.Duration(float64(t-r.origin) / r.speed))
+}
+
+type websocketReplayConn struct {
+	replayer  *WebsocketReplayer
+	records   []WebsocketRecord
+	next      int
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// ReadMessage : the end of the recording reads as a normal closure
+func (c *websocketReplayConn) ReadMessage() (int, []byte, error) {
+	closed := &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	if c.next >= len(c.records) {
+		return -1, nil, closed
+	}
+	record := c.records[c.next]
+	c.next++
+
+	timer := time.NewTimer(time.Until(c.replayer.due(record.Time)))
+	defer timer.Stop()
+	select {
+	case <-c.done:
+		return -1, nil, closed
+	case <-timer.C:
+	}
+	return websocket.TextMessage, []byte(record.Message), nil
+}
+
+// WriteMessage : subscriptions and pings are dropped
+func (c *websocketReplayConn) WriteMessage(messageType int, data []byte) error {
+	select {
+	case <-c.done:
+		return websocket.ErrCloseSent
+	default:
+	}
+	if messageType == websocket.CloseMessage {
+		return c.Close()
+	}
+	return nil
+}
+
+// Close :
+func (c *websocketReplayConn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	return nil
+}
@@ -0,0 +1,106 @@
+package bybit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketRecorder(t *testing.T) {
+	respBody := V5WebsocketPublicTickerResponse{
+		Topic:     "tickers.BTCUSDT",
+		Type:      "snapshot",
+		TimeStamp: 1673853746003,
+		Data: V5WebsocketPublicTickerData{
+			Symbol:    SymbolV5BTCUSDT,
+			LastPrice: "21109.77",
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	path := V5WebsocketPublicPath + "/" + string(CategoryV5Linear)
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(path, bytesBody),
+	)
+	defer teardown()
+
+	for _, compressed := range []bool{false, true} {
+		var buf bytes.Buffer
+		recorder := NewWebsocketRecorder(&buf)
+		if compressed {
+			recorder.WithGzip()
+		}
+
+		{
+			wsClient := NewTestWebsocketClient().
+				WithBaseURL(server.URL)
+			wsClient.WithRecorder(recorder)
+
+			svc, err := wsClient.V5().Public(CategoryV5Linear)
+			require.NoError(t, err)
+			_, err = svc.SubscribeTicker(V5WebsocketPublicTickerParamKey{Symbol: SymbolV5BTCUSDT}, func(V5WebsocketPublicTickerResponse) error {
+				return nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, svc.Run())
+			require.NoError(t, svc.Close())
+			require.NoError(t, recorder.Close())
+		}
+
+		replayer, err := NewWebsocketReplayer(&buf)
+		require.NoError(t, err)
+
+		wsClient := NewTestWebsocketClient().
+			WithBaseURL("ws://127.0.0.1:0")
+		wsClient.WithReplayer(replayer.WithSpeed(0))
+
+		svc, err := wsClient.V5().Public(CategoryV5Linear)
+		require.NoError(t, err)
+		called := 0
+		_, err = svc.SubscribeTicker(V5WebsocketPublicTickerParamKey{Symbol: SymbolV5BTCUSDT}, func(response V5WebsocketPublicTickerResponse) error {
+			called++
+			assert.Equal(t, respBody.Topic, response.Topic)
+			assert.Equal(t, respBody.Data.LastPrice, response.Data.LastPrice)
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, svc.Run())
+		assert.True(t, IsErrWebsocketClosed(svc.Run()))
+		assert.Equal(t, 1, called)
+	}
+}
+
+func TestWebsocketReplayerSpeed(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	base := time.Now().UnixNano()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, enc.Encode(WebsocketRecord{
+			Time:    base + int64(i)*int64(100*time.Millisecond),
+			Path:    SpotWebsocketV1PublicV1Path,
+			Message: `{"topic":"unknown"}`,
+		}))
+	}
+
+	replayer, err := NewWebsocketReplayer(&buf)
+	require.NoError(t, err)
+
+	wsClient := NewTestWebsocketClient()
+	wsClient.WithReplayer(replayer.WithSpeed(2))
+
+	svc, err := wsClient.Spot().V1().PublicV1()
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, svc.Run())
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(100*time.Millisecond))
+	assert.True(t, IsErrWebsocketClosed(svc.Run()))
+}
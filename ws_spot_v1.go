@@ -1,9 +1,5 @@
 package bybit
 
-import (
-	"github.com/gorilla/websocket"
-)
-
 // SpotWebsocketV1Service :
 type SpotWebsocketV1Service struct {
 	client *WebSocketClient
@@ -11,34 +7,38 @@ type SpotWebsocketV1Service struct {
 
 // PublicV1 :
 func (s *SpotWebsocketV1Service) PublicV1() (*SpotWebsocketV1PublicV1Service, error) {
-	url := s.client.baseURL + SpotWebsocketV1PublicV1Path
-	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	c, err := s.client.dial(SpotWebsocketV1PublicV1Path)
 	if err != nil {
 		return nil, err
 	}
 	return &SpotWebsocketV1PublicV1Service{
-		connection:    c,
-		paramTradeMap: map[SpotWebsocketV1PublicV1TradeParamKey]func(SpotWebsocketV1PublicV1TradeResponse) error{},
+		connection:        c,
+		paramTradeMap:     map[SpotWebsocketV1PublicV1TradeParamKey]func(SpotWebsocketV1PublicV1TradeResponse) error{},
+		paramDepthMap:     map[SpotWebsocketV1PublicV1ParamKey]func(SpotWebsocketV1PublicV1DepthResponse) error{},
+		paramKlineMap:     map[SpotWebsocketV1PublicV1ParamKey]func(SpotWebsocketV1PublicV1KlineResponse) error{},
+		paramRealtimesMap: map[SpotWebsocketV1PublicV1ParamKey]func(SpotWebsocketV1PublicV1RealtimesResponse) error{},
 	}, nil
 }
 
 // PublicV2 :
 func (s *SpotWebsocketV1Service) PublicV2() (*SpotWebsocketV1PublicV2Service, error) {
-	url := s.client.baseURL + SpotWebsocketV1PublicV2Path
-	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	c, err := s.client.dial(SpotWebsocketV1PublicV2Path)
 	if err != nil {
 		return nil, err
 	}
 	return &SpotWebsocketV1PublicV2Service{
-		connection:    c,
-		paramTradeMap: map[SpotWebsocketV1PublicV2TradeParamKey]func(SpotWebsocketV1PublicV2TradeResponse) error{},
+		connection:         c,
+		paramTradeMap:      map[SpotWebsocketV1PublicV2TradeParamKey]func(SpotWebsocketV1PublicV2TradeResponse) error{},
+		paramDepthMap:      map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2DepthResponse) error{},
+		paramKlineMap:      map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2KlineResponse) error{},
+		paramRealtimesMap:  map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2RealtimesResponse) error{},
+		paramBookTickerMap: map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2BookTickerResponse) error{},
 	}, nil
 }
 
 // Private :
 func (s *SpotWebsocketV1Service) Private() (*SpotWebsocketV1PrivateService, error) {
-	url := s.client.baseURL + SpotWebsocketV1PrivatePath
-	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	c, err := s.client.dial(SpotWebsocketV1PrivatePath)
 	if err != nil {
 		return nil, err
 	}
@@ -46,5 +46,8 @@ func (s *SpotWebsocketV1Service) Private() (*SpotWebsocketV1PrivateService, erro
 		client:                      s.client,
 		connection:                  c,
 		paramOutboundAccountInfoMap: map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateOutboundAccountInfoResponse) error{},
+		paramExecutionReportMap:     map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateExecutionReportResponse) error{},
+		paramTicketInfoMap:          map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateTicketInfoResponse) error{},
+		paramStopExecutionReportMap: map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateStopExecutionReportResponse) error{},
 	}, nil
 }
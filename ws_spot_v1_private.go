@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -15,9 +16,13 @@ import (
 // SpotWebsocketV1PrivateService :
 type SpotWebsocketV1PrivateService struct {
 	client     *WebSocketClient
-	connection *websocket.Conn
+	connection websocketConn
 
+	mu                          sync.RWMutex
 	paramOutboundAccountInfoMap map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateOutboundAccountInfoResponse) error
+	paramExecutionReportMap     map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateExecutionReportResponse) error
+	paramTicketInfoMap          map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateTicketInfoResponse) error
+	paramStopExecutionReportMap map[SpotWebsocketV1PrivateParamKey]func(SpotWebsocketV1PrivateStopExecutionReportResponse) error
 }
 
 const (
@@ -31,6 +36,12 @@ type SpotWebsocketV1PrivateEventType string
 const (
 	// SpotWebsocketV1PrivateEventTypeOutboundAccountInfo :
 	SpotWebsocketV1PrivateEventTypeOutboundAccountInfo = "outboundAccountInfo"
+	// SpotWebsocketV1PrivateEventTypeExecutionReport :
+	SpotWebsocketV1PrivateEventTypeExecutionReport = "executionReport"
+	// SpotWebsocketV1PrivateEventTypeTicketInfo :
+	SpotWebsocketV1PrivateEventTypeTicketInfo = "ticketInfo"
+	// SpotWebsocketV1PrivateEventTypeStopExecutionReport :
+	SpotWebsocketV1PrivateEventTypeStopExecutionReport = "stopExecutionReport"
 )
 
 // SpotWebsocketV1PrivateParamKey :
@@ -93,6 +104,8 @@ func (r *SpotWebsocketV1PrivateOutboundAccountInfoResponse) Key() SpotWebsocketV
 
 // addParamOutboundAccountInfoFunc :
 func (s *SpotWebsocketV1PrivateService) addParamOutboundAccountInfoFunc(param SpotWebsocketV1PrivateParamKey, f func(SpotWebsocketV1PrivateOutboundAccountInfoResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if _, exist := s.paramOutboundAccountInfoMap[param]; exist {
 		return errors.New("already registered for this param")
 	}
@@ -102,6 +115,8 @@ func (s *SpotWebsocketV1PrivateService) addParamOutboundAccountInfoFunc(param Sp
 
 // retrieveOutboundAccountInfoFunc :
 func (s *SpotWebsocketV1PrivateService) retrieveOutboundAccountInfoFunc(key SpotWebsocketV1PrivateParamKey) (func(SpotWebsocketV1PrivateOutboundAccountInfoResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	f, exist := s.paramOutboundAccountInfoMap[key]
 	if !exist {
 		return nil, errors.New("func not found")
@@ -131,7 +146,7 @@ func (r *spotWebsocketV1PrivateEventJudge) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &parsedArrayData); err != nil {
 		return err
 	}
-	if len(parsedArrayData) != 1 {
+	if len(parsedArrayData) == 0 {
 		return errors.New("unexpected response")
 	}
 	r.EventType = SpotWebsocketV1PrivateEventType(parsedArrayData[0]["e"].(string))
@@ -178,15 +193,251 @@ func (s *SpotWebsocketV1PrivateService) RegisterFuncOutboundAccountInfo(f func(S
 	return nil
 }
 
+// SpotWebsocketV1PrivateExecutionReportResponse :
+// one message may carry several reports
+type SpotWebsocketV1PrivateExecutionReportResponse struct {
+	Contents []SpotWebsocketV1PrivateExecutionReportResponseContent
+}
+
+// SpotWebsocketV1PrivateExecutionReportResponseContent :
+type SpotWebsocketV1PrivateExecutionReportResponseContent struct {
+	EventType                SpotWebsocketV1PrivateEventType `json:"e"`
+	Timestamp                string                          `json:"E"`
+	Symbol                   SymbolSpot                      `json:"s"`
+	ClientOrderID            string                          `json:"c"`
+	Side                     string                          `json:"S"`
+	OrderType                OrderTypeSpot                   `json:"o"`
+	TimeInForce              TimeInForceSpot                 `json:"f"`
+	Quantity                 string                          `json:"q"`
+	Price                    string                          `json:"p"`
+	OrderStatus              OrderStatusSpot                 `json:"X"`
+	OrderID                  string                          `json:"i"`
+	OpponentOrderID          string                          `json:"M"`
+	LastFilledQuantity       string                          `json:"l"`
+	CumulativeFilledQuantity string                          `json:"z"`
+	LastExecutedPrice        string                          `json:"L"`
+	TradingFee               string                          `json:"n"`
+	FeeAsset                 string                          `json:"N"`
+	IsNormal                 bool                            `json:"u"`
+	IsWorking                bool                            `json:"w"`
+	IsLimitMaker             bool                            `json:"m"`
+	OrderCreationTime        string                          `json:"O"`
+	CumulativeQuoteQuantity  string                          `json:"Z"`
+	AccountID                string                          `json:"A"`
+	IsClose                  bool                            `json:"C"`
+	Leverage                 string                          `json:"v"`
+	LiquidationStatus        string                          `json:"d"`
+	TradeID                  string                          `json:"t"`
+}
+
+// UnmarshalJSON :
+func (r *SpotWebsocketV1PrivateExecutionReportResponse) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Contents)
+}
+
+// MarshalJSON :
+func (r *SpotWebsocketV1PrivateExecutionReportResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Contents)
+}
+
+// Key :
+func (r *SpotWebsocketV1PrivateExecutionReportResponse) Key() SpotWebsocketV1PrivateParamKey {
+	return SpotWebsocketV1PrivateParamKey{
+		EventType: SpotWebsocketV1PrivateEventTypeExecutionReport,
+	}
+}
+
+// SpotWebsocketV1PrivateTicketInfoResponse :
+// one message may carry several tickets
+type SpotWebsocketV1PrivateTicketInfoResponse struct {
+	Contents []SpotWebsocketV1PrivateTicketInfoResponseContent
+}
+
+// SpotWebsocketV1PrivateTicketInfoResponseContent :
+type SpotWebsocketV1PrivateTicketInfoResponseContent struct {
+	EventType      SpotWebsocketV1PrivateEventType `json:"e"`
+	Timestamp      string                          `json:"E"`
+	Symbol         SymbolSpot                      `json:"s"`
+	Quantity       string                          `json:"q"`
+	TradeTime      string                          `json:"t"`
+	Price          string                          `json:"p"`
+	TradeID        string                          `json:"T"`
+	OrderID        string                          `json:"o"`
+	ClientOrderID  string                          `json:"c"`
+	MatchOrderID   string                          `json:"O"`
+	AccountID      string                          `json:"a"`
+	MatchAccountID string                          `json:"A"`
+	IsMaker        bool                            `json:"m"`
+	Side           string                          `json:"S"`
+}
+
+// UnmarshalJSON :
+func (r *SpotWebsocketV1PrivateTicketInfoResponse) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Contents)
+}
+
+// MarshalJSON :
+func (r *SpotWebsocketV1PrivateTicketInfoResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Contents)
+}
+
+// Key :
+func (r *SpotWebsocketV1PrivateTicketInfoResponse) Key() SpotWebsocketV1PrivateParamKey {
+	return SpotWebsocketV1PrivateParamKey{
+		EventType: SpotWebsocketV1PrivateEventTypeTicketInfo,
+	}
+}
+
+// SpotWebsocketV1PrivateStopExecutionReportResponse :
+// one message may carry several reports
+type SpotWebsocketV1PrivateStopExecutionReportResponse struct {
+	Contents []SpotWebsocketV1PrivateStopExecutionReportResponseContent
+}
+
+// SpotWebsocketV1PrivateStopExecutionReportResponseContent :
+type SpotWebsocketV1PrivateStopExecutionReportResponseContent struct {
+	EventType     SpotWebsocketV1PrivateEventType `json:"e"`
+	Timestamp     string                          `json:"E"`
+	Symbol        SymbolSpot                      `json:"s"`
+	ClientOrderID string                          `json:"c"`
+	Side          string                          `json:"S"`
+	OrderType     OrderTypeSpot                   `json:"o"`
+	TimeInForce   TimeInForceSpot                 `json:"f"`
+	Quantity      string                          `json:"q"`
+	Price         string                          `json:"p"`
+	OrderStatus   string                          `json:"X"`
+	OrderID       string                          `json:"i"`
+	CreationTime  string                          `json:"T"`
+	TriggerPrice  string                          `json:"t"`
+}
+
+// UnmarshalJSON :
+func (r *SpotWebsocketV1PrivateStopExecutionReportResponse) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.Contents)
+}
+
+// MarshalJSON :
+func (r *SpotWebsocketV1PrivateStopExecutionReportResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Contents)
+}
+
+// Key :
+func (r *SpotWebsocketV1PrivateStopExecutionReportResponse) Key() SpotWebsocketV1PrivateParamKey {
+	return SpotWebsocketV1PrivateParamKey{
+		EventType: SpotWebsocketV1PrivateEventTypeStopExecutionReport,
+	}
+}
+
+// addParamExecutionReportFunc :
+func (s *SpotWebsocketV1PrivateService) addParamExecutionReportFunc(param SpotWebsocketV1PrivateParamKey, f func(SpotWebsocketV1PrivateExecutionReportResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramExecutionReportMap[param]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramExecutionReportMap[param] = f
+	return nil
+}
+
+// retrieveExecutionReportFunc : nil when none is registered
+func (s *SpotWebsocketV1PrivateService) retrieveExecutionReportFunc(key SpotWebsocketV1PrivateParamKey) func(SpotWebsocketV1PrivateExecutionReportResponse) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paramExecutionReportMap[key]
+}
+
+// addParamTicketInfoFunc :
+func (s *SpotWebsocketV1PrivateService) addParamTicketInfoFunc(param SpotWebsocketV1PrivateParamKey, f func(SpotWebsocketV1PrivateTicketInfoResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramTicketInfoMap[param]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramTicketInfoMap[param] = f
+	return nil
+}
+
+// retrieveTicketInfoFunc : nil when none is registered
+func (s *SpotWebsocketV1PrivateService) retrieveTicketInfoFunc(key SpotWebsocketV1PrivateParamKey) func(SpotWebsocketV1PrivateTicketInfoResponse) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paramTicketInfoMap[key]
+}
+
+// addParamStopExecutionReportFunc :
+func (s *SpotWebsocketV1PrivateService) addParamStopExecutionReportFunc(param SpotWebsocketV1PrivateParamKey, f func(SpotWebsocketV1PrivateStopExecutionReportResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramStopExecutionReportMap[param]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramStopExecutionReportMap[param] = f
+	return nil
+}
+
+// retrieveStopExecutionReportFunc : nil when none is registered
+func (s *SpotWebsocketV1PrivateService) retrieveStopExecutionReportFunc(key SpotWebsocketV1PrivateParamKey) func(SpotWebsocketV1PrivateStopExecutionReportResponse) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paramStopExecutionReportMap[key]
+}
+
+// RegisterFuncExecutionReport :
+func (s *SpotWebsocketV1PrivateService) RegisterFuncExecutionReport(f func(SpotWebsocketV1PrivateExecutionReportResponse) error) error {
+	key := SpotWebsocketV1PrivateParamKey{
+		EventType: SpotWebsocketV1PrivateEventTypeExecutionReport,
+	}
+	if err := s.addParamExecutionReportFunc(key, f); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RegisterFuncTicketInfo :
+func (s *SpotWebsocketV1PrivateService) RegisterFuncTicketInfo(f func(SpotWebsocketV1PrivateTicketInfoResponse) error) error {
+	key := SpotWebsocketV1PrivateParamKey{
+		EventType: SpotWebsocketV1PrivateEventTypeTicketInfo,
+	}
+	if err := s.addParamTicketInfoFunc(key, f); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RegisterFuncStopExecutionReport :
+func (s *SpotWebsocketV1PrivateService) RegisterFuncStopExecutionReport(f func(SpotWebsocketV1PrivateStopExecutionReportResponse) error) error {
+	key := SpotWebsocketV1PrivateParamKey{
+		EventType: SpotWebsocketV1PrivateEventTypeStopExecutionReport,
+	}
+	if err := s.addParamStopExecutionReportFunc(key, f); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UnregisterFunc : the func registered for the event type, if any, so that another can be registered
+func (s *SpotWebsocketV1PrivateService) UnregisterFunc(eventType SpotWebsocketV1PrivateEventType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := SpotWebsocketV1PrivateParamKey{
+		EventType: eventType,
+	}
+	delete(s.paramOutboundAccountInfoMap, key)
+	delete(s.paramExecutionReportMap, key)
+	delete(s.paramTicketInfoMap, key)
+	delete(s.paramStopExecutionReportMap, key)
+}
+
 // Start :
 func (s *SpotWebsocketV1PrivateService) Start(ctx context.Context) {
+	parent := ctx
 	done := make(chan struct{})
 
 	go func() {
 		defer close(done)
 
 		for {
-			if err := s.Run(); err != nil {
+			if err := s.RunContext(parent); err != nil {
 				if IsErrWebsocketClosed(err) {
 					return
 				}
@@ -227,15 +478,32 @@ func (s *SpotWebsocketV1PrivateService) Start(ctx context.Context) {
 
 // Run :
 func (s *SpotWebsocketV1PrivateService) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *SpotWebsocketV1PrivateService) RunContext(ctx context.Context) error {
 	_, message, err := s.connection.ReadMessage()
 	if err != nil {
 		return err
 	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
 
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *SpotWebsocketV1PrivateService) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *SpotWebsocketV1PrivateService) handle(message []byte) error {
 	topic, err := s.judgeEventType(message)
 	if err != nil {
 		return err
 	}
+	// the order and ticket events are pushed whether a func is registered or not, those without one are skipped
 	switch topic {
 	case SpotWebsocketV1PrivateEventTypeOutboundAccountInfo:
 		var resp SpotWebsocketV1PrivateOutboundAccountInfoResponse
@@ -249,6 +517,36 @@ func (s *SpotWebsocketV1PrivateService) Run() error {
 		if err := f(resp); err != nil {
 			return err
 		}
+	case SpotWebsocketV1PrivateEventTypeExecutionReport:
+		var resp SpotWebsocketV1PrivateExecutionReportResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		if f := s.retrieveExecutionReportFunc(resp.Key()); f != nil {
+			if err := f(resp); err != nil {
+				return err
+			}
+		}
+	case SpotWebsocketV1PrivateEventTypeTicketInfo:
+		var resp SpotWebsocketV1PrivateTicketInfoResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		if f := s.retrieveTicketInfoFunc(resp.Key()); f != nil {
+			if err := f(resp); err != nil {
+				return err
+			}
+		}
+	case SpotWebsocketV1PrivateEventTypeStopExecutionReport:
+		var resp SpotWebsocketV1PrivateStopExecutionReportResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		if f := s.retrieveStopExecutionReportFunc(resp.Key()); f != nil {
+			if err := f(resp); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -53,3 +53,87 @@ func TestSpotWebsocketV1PrivateOutboundAccountInfo(t *testing.T) {
 	assert.NoError(t, svc.Ping())
 	assert.NoError(t, svc.Close())
 }
+
+func TestSpotWebsocketV1PrivateExecutionReport(t *testing.T) {
+	respBody := SpotWebsocketV1PrivateExecutionReportResponse{
+		Contents: []SpotWebsocketV1PrivateExecutionReportResponseContent{
+			{
+				EventType:         SpotWebsocketV1PrivateEventTypeExecutionReport,
+				Timestamp:         "1664285837492",
+				Symbol:            SymbolSpotBTCUSDT,
+				ClientOrderID:     "1664285837000",
+				Side:              "BUY",
+				OrderType:         OrderTypeSpotLimit,
+				TimeInForce:       TimeInForceSpotGTC,
+				Quantity:          "0.001",
+				Price:             "19000",
+				OrderStatus:       OrderStatusSpotNew,
+				OrderID:           "1252283404325358336",
+				IsNormal:          true,
+				IsWorking:         true,
+				OrderCreationTime: "1664285837480",
+				AccountID:         "8121006",
+				LiquidationStatus: "NO_LIQ",
+			},
+		},
+	}
+	bytesBody, err := json.Marshal(&respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PrivatePath, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Spot().V1().Private()
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Subscribe())
+
+	calls := 0
+	require.NoError(t, svc.RegisterFuncExecutionReport(func(response SpotWebsocketV1PrivateExecutionReportResponse) error {
+		assert.Equal(t, respBody, response)
+		calls++
+		return nil
+	}))
+
+	assert.NoError(t, svc.Run())
+	svc.UnregisterFunc(SpotWebsocketV1PrivateEventTypeExecutionReport)
+	require.NoError(t, svc.Subscribe())
+	assert.NoError(t, svc.Run(), "an unregistered executionReport does not stop Run")
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
+
+func TestSpotWebsocketV1PrivateUnregisterWhileRunning(t *testing.T) {
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PrivatePath, []byte(`[{"e":"ticketInfo","s":"BTCUSDT"}]`)),
+	)
+	defer teardown()
+
+	svc, err := NewTestWebsocketClient().WithBaseURL(server.URL).Spot().V1().Private()
+	require.NoError(t, err)
+
+	const messages = 20
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < messages; i++ {
+			if err := svc.Run(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	for i := 0; i < messages; i++ {
+		require.NoError(t, svc.RegisterFuncTicketInfo(func(SpotWebsocketV1PrivateTicketInfoResponse) error { return nil }))
+		require.NoError(t, svc.Subscribe())
+		svc.UnregisterFunc(SpotWebsocketV1PrivateEventTypeTicketInfo)
+	}
+	assert.NoError(t, <-done)
+	assert.NoError(t, svc.Close())
+}
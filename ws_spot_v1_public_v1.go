@@ -7,6 +7,8 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -14,9 +16,13 @@ import (
 
 // SpotWebsocketV1PublicV1Service :
 type SpotWebsocketV1PublicV1Service struct {
-	connection *websocket.Conn
+	connection websocketConn
 
-	paramTradeMap map[SpotWebsocketV1PublicV1TradeParamKey]func(SpotWebsocketV1PublicV1TradeResponse) error
+	mu                sync.RWMutex
+	paramTradeMap     map[SpotWebsocketV1PublicV1TradeParamKey]func(SpotWebsocketV1PublicV1TradeResponse) error
+	paramDepthMap     map[SpotWebsocketV1PublicV1ParamKey]func(SpotWebsocketV1PublicV1DepthResponse) error
+	paramKlineMap     map[SpotWebsocketV1PublicV1ParamKey]func(SpotWebsocketV1PublicV1KlineResponse) error
+	paramRealtimesMap map[SpotWebsocketV1PublicV1ParamKey]func(SpotWebsocketV1PublicV1RealtimesResponse) error
 }
 
 const (
@@ -40,8 +46,23 @@ type SpotWebsocketV1PublicV1Topic string
 const (
 	// SpotWebsocketV1PublicV1TopicTrade :
 	SpotWebsocketV1PublicV1TopicTrade = SpotWebsocketV1PublicV1Topic("trade")
+	// SpotWebsocketV1PublicV1TopicDepth :
+	SpotWebsocketV1PublicV1TopicDepth = SpotWebsocketV1PublicV1Topic("depth")
+	// SpotWebsocketV1PublicV1TopicDiffDepth :
+	SpotWebsocketV1PublicV1TopicDiffDepth = SpotWebsocketV1PublicV1Topic("diffDepth")
+	// SpotWebsocketV1PublicV1TopicMergedDepth :
+	SpotWebsocketV1PublicV1TopicMergedDepth = SpotWebsocketV1PublicV1Topic("mergedDepth")
+	// SpotWebsocketV1PublicV1TopicKline : the interval is appended, e.g. kline_1m
+	SpotWebsocketV1PublicV1TopicKline = SpotWebsocketV1PublicV1Topic("kline_")
+	// SpotWebsocketV1PublicV1TopicRealtimes :
+	SpotWebsocketV1PublicV1TopicRealtimes = SpotWebsocketV1PublicV1Topic("realtimes")
 )
 
+// IsKline :
+func (t SpotWebsocketV1PublicV1Topic) IsKline() bool {
+	return strings.HasPrefix(string(t), string(SpotWebsocketV1PublicV1TopicKline))
+}
+
 // SpotWebsocketV1PublicV1TradeParamKey :
 type SpotWebsocketV1PublicV1TradeParamKey struct {
 	Symbol SymbolSpot
@@ -106,6 +127,8 @@ func (p *SpotWebsocketV1PublicV1TradeParam) Key() SpotWebsocketV1PublicV1TradePa
 
 // addParamTradeFunc :
 func (s *SpotWebsocketV1PublicV1Service) addParamTradeFunc(param SpotWebsocketV1PublicV1TradeParamKey, f func(SpotWebsocketV1PublicV1TradeResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if _, exist := s.paramTradeMap[param]; exist {
 		return errors.New("already registered for this param")
 	}
@@ -115,11 +138,15 @@ func (s *SpotWebsocketV1PublicV1Service) addParamTradeFunc(param SpotWebsocketV1
 
 // removeParamTradeFunc :
 func (s *SpotWebsocketV1PublicV1Service) removeParamTradeFunc(key SpotWebsocketV1PublicV1TradeParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.paramTradeMap, key)
 }
 
 // retrieveTradeFunc :
 func (s *SpotWebsocketV1PublicV1Service) retrieveTradeFunc(key SpotWebsocketV1PublicV1TradeParamKey) (func(SpotWebsocketV1PublicV1TradeResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	f, exist := s.paramTradeMap[key]
 	if !exist {
 		return nil, errors.New("func not found")
@@ -131,10 +158,14 @@ func (s *SpotWebsocketV1PublicV1Service) retrieveTradeFunc(key SpotWebsocketV1Pu
 func (s *SpotWebsocketV1PublicV1Service) judgeTopic(respBody []byte) (SpotWebsocketV1PublicV1Topic, error) {
 	result := struct {
 		Topic SpotWebsocketV1PublicV1Topic `json:"topic"`
+		Event SpotWebsocketV1PublicV1Event `json:"event"`
 	}{}
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return "", err
 	}
+	if result.Event == SpotWebsocketV1PublicV1EventSubscribe || result.Event == SpotWebsocketV1PublicV1EventUnsubscribe {
+		return "", nil
+	}
 	return result.Topic, nil
 }
 
@@ -181,15 +212,322 @@ func (s *SpotWebsocketV1PublicV1Service) SubscribeTrade(symbol SymbolSpot, f fun
 	}, nil
 }
 
+// SpotWebsocketV1PublicV1ParamKey :
+type SpotWebsocketV1PublicV1ParamKey struct {
+	Symbol SymbolSpot
+	Topic  SpotWebsocketV1PublicV1Topic
+}
+
+// SpotWebsocketV1PublicV1ParamChild :
+type SpotWebsocketV1PublicV1ParamChild struct {
+	Binary    bool `json:"binary"`
+	DumpScale int  `json:"dumpScale,omitempty"`
+}
+
+// SpotWebsocketV1PublicV1Param :
+type SpotWebsocketV1PublicV1Param struct {
+	Symbol SymbolSpot                        `json:"symbol"`
+	Topic  SpotWebsocketV1PublicV1Topic      `json:"topic"`
+	Event  SpotWebsocketV1PublicV1Event      `json:"event"`
+	Params SpotWebsocketV1PublicV1ParamChild `json:"params"`
+}
+
+// Key :
+func (p *SpotWebsocketV1PublicV1Param) Key() SpotWebsocketV1PublicV1ParamKey {
+	return SpotWebsocketV1PublicV1ParamKey{
+		Symbol: p.Symbol,
+		Topic:  p.Topic,
+	}
+}
+
+// SpotWebsocketV1PublicV1ResponseParams :
+type SpotWebsocketV1PublicV1ResponseParams struct {
+	RealtimeInterval string `json:"realtimeInterval,omitempty"`
+	Binary           string `json:"binary"`
+	DumpScale        int    `json:"dumpScale,omitempty"`
+}
+
+// SpotWebsocketV1PublicV1DepthResponse : depth, diffDepth and mergedDepth
+type SpotWebsocketV1PublicV1DepthResponse struct {
+	Symbol         SymbolSpot                   `json:"symbol"`
+	SymbolName     string                       `json:"symbolName"`
+	Topic          SpotWebsocketV1PublicV1Topic `json:"topic"`
+	SendTime       int                          `json:"sendTime"`
+	IsFirstMessage bool                         `json:"f"`
+
+	Params SpotWebsocketV1PublicV1ResponseParams `json:"params"`
+	Data   []SpotWebsocketV1PublicV1DepthContent `json:"data"`
+}
+
+// SpotWebsocketV1PublicV1DepthContent :
+type SpotWebsocketV1PublicV1DepthContent struct {
+	Symbol    SymbolSpot `json:"s"`
+	Timestamp int        `json:"t"`
+	Version   string     `json:"v"`
+	Bids      [][]string `json:"b"`
+	Asks      [][]string `json:"a"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV1DepthResponse) Key() SpotWebsocketV1PublicV1ParamKey {
+	return SpotWebsocketV1PublicV1ParamKey{
+		Symbol: r.Symbol,
+		Topic:  r.Topic,
+	}
+}
+
+// SpotWebsocketV1PublicV1KlineResponse :
+type SpotWebsocketV1PublicV1KlineResponse struct {
+	Symbol         SymbolSpot                   `json:"symbol"`
+	SymbolName     string                       `json:"symbolName"`
+	Topic          SpotWebsocketV1PublicV1Topic `json:"topic"`
+	SendTime       int                          `json:"sendTime"`
+	IsFirstMessage bool                         `json:"f"`
+
+	Params SpotWebsocketV1PublicV1ResponseParams `json:"params"`
+	Data   []SpotWebsocketV1PublicV1KlineContent `json:"data"`
+}
+
+// SpotWebsocketV1PublicV1KlineContent :
+type SpotWebsocketV1PublicV1KlineContent struct {
+	StartTime  int        `json:"t"`
+	Symbol     SymbolSpot `json:"s"`
+	SymbolName string     `json:"sn"`
+	Close      string     `json:"c"`
+	High       string     `json:"h"`
+	Low        string     `json:"l"`
+	Open       string     `json:"o"`
+	Volume     string     `json:"v"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV1KlineResponse) Key() SpotWebsocketV1PublicV1ParamKey {
+	return SpotWebsocketV1PublicV1ParamKey{
+		Symbol: r.Symbol,
+		Topic:  r.Topic,
+	}
+}
+
+// SpotWebsocketV1PublicV1RealtimesResponse :
+type SpotWebsocketV1PublicV1RealtimesResponse struct {
+	Symbol         SymbolSpot                   `json:"symbol"`
+	SymbolName     string                       `json:"symbolName"`
+	Topic          SpotWebsocketV1PublicV1Topic `json:"topic"`
+	SendTime       int                          `json:"sendTime"`
+	IsFirstMessage bool                         `json:"f"`
+
+	Params SpotWebsocketV1PublicV1ResponseParams     `json:"params"`
+	Data   []SpotWebsocketV1PublicV1RealtimesContent `json:"data"`
+}
+
+// SpotWebsocketV1PublicV1RealtimesContent :
+type SpotWebsocketV1PublicV1RealtimesContent struct {
+	Timestamp   int        `json:"t"`
+	Symbol      SymbolSpot `json:"s"`
+	SymbolName  string     `json:"sn"`
+	Close       string     `json:"c"`
+	High        string     `json:"h"`
+	Low         string     `json:"l"`
+	Open        string     `json:"o"`
+	Volume      string     `json:"v"`
+	QuoteVolume string     `json:"qv"`
+	Change      string     `json:"m"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV1RealtimesResponse) Key() SpotWebsocketV1PublicV1ParamKey {
+	return SpotWebsocketV1PublicV1ParamKey{
+		Symbol: r.Symbol,
+		Topic:  r.Topic,
+	}
+}
+
+// addParamDepthFunc :
+func (s *SpotWebsocketV1PublicV1Service) addParamDepthFunc(key SpotWebsocketV1PublicV1ParamKey, f func(SpotWebsocketV1PublicV1DepthResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramDepthMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramDepthMap[key] = f
+	return nil
+}
+
+// removeParamDepthFunc :
+func (s *SpotWebsocketV1PublicV1Service) removeParamDepthFunc(key SpotWebsocketV1PublicV1ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramDepthMap, key)
+}
+
+// retrieveDepthFunc :
+func (s *SpotWebsocketV1PublicV1Service) retrieveDepthFunc(key SpotWebsocketV1PublicV1ParamKey) (func(SpotWebsocketV1PublicV1DepthResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramDepthMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// addParamKlineFunc :
+func (s *SpotWebsocketV1PublicV1Service) addParamKlineFunc(key SpotWebsocketV1PublicV1ParamKey, f func(SpotWebsocketV1PublicV1KlineResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramKlineMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramKlineMap[key] = f
+	return nil
+}
+
+// removeParamKlineFunc :
+func (s *SpotWebsocketV1PublicV1Service) removeParamKlineFunc(key SpotWebsocketV1PublicV1ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramKlineMap, key)
+}
+
+// retrieveKlineFunc :
+func (s *SpotWebsocketV1PublicV1Service) retrieveKlineFunc(key SpotWebsocketV1PublicV1ParamKey) (func(SpotWebsocketV1PublicV1KlineResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramKlineMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// addParamRealtimesFunc :
+func (s *SpotWebsocketV1PublicV1Service) addParamRealtimesFunc(key SpotWebsocketV1PublicV1ParamKey, f func(SpotWebsocketV1PublicV1RealtimesResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramRealtimesMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramRealtimesMap[key] = f
+	return nil
+}
+
+// removeParamRealtimesFunc :
+func (s *SpotWebsocketV1PublicV1Service) removeParamRealtimesFunc(key SpotWebsocketV1PublicV1ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramRealtimesMap, key)
+}
+
+// retrieveRealtimesFunc :
+func (s *SpotWebsocketV1PublicV1Service) retrieveRealtimesFunc(key SpotWebsocketV1PublicV1ParamKey) (func(SpotWebsocketV1PublicV1RealtimesResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramRealtimesMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// subscribe : sends param and returns the func to cancel it, remove is called once cancelled or when sending fails
+func (s *SpotWebsocketV1PublicV1Service) subscribe(param SpotWebsocketV1PublicV1Param, remove func()) (func() error, error) {
+	param.Event = SpotWebsocketV1PublicV1EventSubscribe
+	buf, err := json.Marshal(param)
+	if err != nil {
+		remove()
+		return nil, err
+	}
+	if err := s.connection.WriteMessage(websocket.TextMessage, buf); err != nil {
+		remove()
+		return nil, err
+	}
+
+	return func() error {
+		param.Event = SpotWebsocketV1PublicV1EventUnsubscribe
+		buf, err := json.Marshal(param)
+		if err != nil {
+			return err
+		}
+		if err := s.connection.WriteMessage(websocket.TextMessage, buf); err != nil {
+			return err
+		}
+		remove()
+		return nil
+	}, nil
+}
+
+// subscribeDepth :
+func (s *SpotWebsocketV1PublicV1Service) subscribeDepth(param SpotWebsocketV1PublicV1Param, f func(SpotWebsocketV1PublicV1DepthResponse) error) (func() error, error) {
+	key := param.Key()
+	if err := s.addParamDepthFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamDepthFunc(key) })
+}
+
+// SubscribeDepth :
+func (s *SpotWebsocketV1PublicV1Service) SubscribeDepth(symbol SymbolSpot, f func(response SpotWebsocketV1PublicV1DepthResponse) error) (func() error, error) {
+	return s.subscribeDepth(SpotWebsocketV1PublicV1Param{
+		Symbol: symbol,
+		Topic:  SpotWebsocketV1PublicV1TopicDepth,
+	}, f)
+}
+
+// SubscribeDiffDepth :
+func (s *SpotWebsocketV1PublicV1Service) SubscribeDiffDepth(symbol SymbolSpot, f func(response SpotWebsocketV1PublicV1DepthResponse) error) (func() error, error) {
+	return s.subscribeDepth(SpotWebsocketV1PublicV1Param{
+		Symbol: symbol,
+		Topic:  SpotWebsocketV1PublicV1TopicDiffDepth,
+	}, f)
+}
+
+// SubscribeMergedDepth : dumpScale is the number of decimal places the prices are merged to
+func (s *SpotWebsocketV1PublicV1Service) SubscribeMergedDepth(symbol SymbolSpot, dumpScale int, f func(response SpotWebsocketV1PublicV1DepthResponse) error) (func() error, error) {
+	return s.subscribeDepth(SpotWebsocketV1PublicV1Param{
+		Symbol: symbol,
+		Topic:  SpotWebsocketV1PublicV1TopicMergedDepth,
+		Params: SpotWebsocketV1PublicV1ParamChild{
+			DumpScale: dumpScale,
+		},
+	}, f)
+}
+
+// SubscribeKline :
+func (s *SpotWebsocketV1PublicV1Service) SubscribeKline(symbol SymbolSpot, interval Interval, f func(response SpotWebsocketV1PublicV1KlineResponse) error) (func() error, error) {
+	param := SpotWebsocketV1PublicV1Param{
+		Symbol: symbol,
+		Topic:  SpotWebsocketV1PublicV1TopicKline + SpotWebsocketV1PublicV1Topic(interval),
+	}
+	key := param.Key()
+	if err := s.addParamKlineFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamKlineFunc(key) })
+}
+
+// SubscribeRealtimes :
+func (s *SpotWebsocketV1PublicV1Service) SubscribeRealtimes(symbol SymbolSpot, f func(response SpotWebsocketV1PublicV1RealtimesResponse) error) (func() error, error) {
+	param := SpotWebsocketV1PublicV1Param{
+		Symbol: symbol,
+		Topic:  SpotWebsocketV1PublicV1TopicRealtimes,
+	}
+	key := param.Key()
+	if err := s.addParamRealtimesFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamRealtimesFunc(key) })
+}
+
 // Start :
 func (s *SpotWebsocketV1PublicV1Service) Start(ctx context.Context) {
+	parent := ctx
 	done := make(chan struct{})
 
 	go func() {
 		defer close(done)
 
 		for {
-			if err := s.Run(); err != nil {
+			if err := s.RunContext(parent); err != nil {
 				if IsErrWebsocketClosed(err) {
 					return
 				}
@@ -230,11 +568,27 @@ func (s *SpotWebsocketV1PublicV1Service) Start(ctx context.Context) {
 
 // Run :
 func (s *SpotWebsocketV1PublicV1Service) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *SpotWebsocketV1PublicV1Service) RunContext(ctx context.Context) error {
 	_, message, err := s.connection.ReadMessage()
 	if err != nil {
 		return err
 	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
 
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *SpotWebsocketV1PublicV1Service) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *SpotWebsocketV1PublicV1Service) handle(message []byte) error {
 	topic, err := s.judgeTopic(message)
 	if err != nil {
 		return err
@@ -252,6 +606,45 @@ func (s *SpotWebsocketV1PublicV1Service) Run() error {
 		if err := f(resp); err != nil {
 			return err
 		}
+	case SpotWebsocketV1PublicV1TopicDepth, SpotWebsocketV1PublicV1TopicDiffDepth, SpotWebsocketV1PublicV1TopicMergedDepth:
+		var resp SpotWebsocketV1PublicV1DepthResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveDepthFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
+	case SpotWebsocketV1PublicV1TopicRealtimes:
+		var resp SpotWebsocketV1PublicV1RealtimesResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveRealtimesFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
+	default:
+		if !topic.IsKline() {
+			break
+		}
+		var resp SpotWebsocketV1PublicV1KlineResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveKlineFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
 	}
 	return nil
 }
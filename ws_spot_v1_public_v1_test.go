@@ -55,3 +55,137 @@ func TestSpotWebsocketV1PublicV1Trade(t *testing.T) {
 	assert.NoError(t, svc.Ping())
 	assert.NoError(t, svc.Close())
 }
+
+func TestSpotWebsocketV1PublicV1MergedDepth(t *testing.T) {
+	respBody := SpotWebsocketV1PublicV1DepthResponse{
+		Symbol:         SymbolSpotBTCUSDT,
+		SymbolName:     string(SymbolSpotBTCUSDT),
+		Topic:          SpotWebsocketV1PublicV1TopicMergedDepth,
+		SendTime:       1664284020685,
+		IsFirstMessage: true,
+		Params: SpotWebsocketV1PublicV1ResponseParams{
+			Binary:    "false",
+			DumpScale: 1,
+		},
+		Data: []SpotWebsocketV1PublicV1DepthContent{
+			{
+				Symbol:    SymbolSpotBTCUSDT,
+				Timestamp: 1664283342503,
+				Version:   "112801745_18",
+				Bids:      [][]string{{"20191.6", "0.0014"}},
+				Asks:      [][]string{{"20191.7", "0.5"}},
+			},
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PublicV1Path, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Spot().V1().PublicV1()
+	require.NoError(t, err)
+
+	unsubscribe, err := svc.SubscribeMergedDepth(SymbolSpotBTCUSDT, 1, func(response SpotWebsocketV1PublicV1DepthResponse) error {
+		assert.Equal(t, respBody, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
+
+func TestSpotWebsocketV1PublicV1Kline(t *testing.T) {
+	respBody := SpotWebsocketV1PublicV1KlineResponse{
+		Symbol:         SymbolSpotBTCUSDT,
+		SymbolName:     string(SymbolSpotBTCUSDT),
+		Topic:          SpotWebsocketV1PublicV1Topic("kline_1m"),
+		SendTime:       1664284020685,
+		IsFirstMessage: false,
+		Params: SpotWebsocketV1PublicV1ResponseParams{
+			RealtimeInterval: "24h",
+			Binary:           "false",
+		},
+		Data: []SpotWebsocketV1PublicV1KlineContent{
+			{
+				StartTime:  1664283300000,
+				Symbol:     SymbolSpotBTCUSDT,
+				SymbolName: string(SymbolSpotBTCUSDT),
+				Close:      "20191.69",
+				High:       "20200",
+				Low:        "20180.01",
+				Open:       "20185.5",
+				Volume:     "16.3306",
+			},
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PublicV1Path, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Spot().V1().PublicV1()
+	require.NoError(t, err)
+
+	unsubscribe, err := svc.SubscribeKline(SymbolSpotBTCUSDT, SpotInterval1m, func(response SpotWebsocketV1PublicV1KlineResponse) error {
+		assert.Equal(t, respBody, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
+
+func TestSpotWebsocketV1PublicV1SubscribeWhileRunning(t *testing.T) {
+	bytesBody, err := json.Marshal(SpotWebsocketV1PublicV1KlineResponse{
+		Symbol: SymbolSpotBTCUSDT,
+		Topic:  SpotWebsocketV1PublicV1Topic("kline_1m"),
+	})
+	require.NoError(t, err)
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PublicV1Path, bytesBody),
+	)
+	defer teardown()
+
+	svc, err := NewTestWebsocketClient().WithBaseURL(server.URL).Spot().V1().PublicV1()
+	require.NoError(t, err)
+	_, err = svc.SubscribeKline(SymbolSpotBTCUSDT, SpotInterval1m, func(SpotWebsocketV1PublicV1KlineResponse) error { return nil })
+	require.NoError(t, err)
+
+	const subscriptions = 10
+	done := make(chan error, 1)
+	go func() {
+		// one message for each subscribe and unsubscribe
+		for i := 0; i < 1+2*subscriptions; i++ {
+			if err := svc.Run(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	for i := 0; i < subscriptions; i++ {
+		unsubscribe, err := svc.SubscribeKline(SymbolSpotETHUSDT, SpotInterval1m, func(SpotWebsocketV1PublicV1KlineResponse) error { return nil })
+		require.NoError(t, err)
+		require.NoError(t, unsubscribe())
+	}
+	assert.NoError(t, <-done)
+	assert.NoError(t, svc.Close())
+}
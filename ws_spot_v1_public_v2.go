@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -14,9 +15,14 @@ import (
 
 // SpotWebsocketV1PublicV2Service :
 type SpotWebsocketV1PublicV2Service struct {
-	connection *websocket.Conn
-
-	paramTradeMap map[SpotWebsocketV1PublicV2TradeParamKey]func(SpotWebsocketV1PublicV2TradeResponse) error
+	connection websocketConn
+
+	mu                 sync.RWMutex
+	paramTradeMap      map[SpotWebsocketV1PublicV2TradeParamKey]func(SpotWebsocketV1PublicV2TradeResponse) error
+	paramDepthMap      map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2DepthResponse) error
+	paramKlineMap      map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2KlineResponse) error
+	paramRealtimesMap  map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2RealtimesResponse) error
+	paramBookTickerMap map[SpotWebsocketV1PublicV2ParamKey]func(SpotWebsocketV1PublicV2BookTickerResponse) error
 }
 
 const (
@@ -40,6 +46,16 @@ type SpotWebsocketV1PublicV2Topic string
 const (
 	// SpotWebsocketV1PublicV2TopicTrade :
 	SpotWebsocketV1PublicV2TopicTrade = SpotWebsocketV1PublicV2Topic("trade")
+	// SpotWebsocketV1PublicV2TopicDepth :
+	SpotWebsocketV1PublicV2TopicDepth = SpotWebsocketV1PublicV2Topic("depth")
+	// SpotWebsocketV1PublicV2TopicMergedDepth :
+	SpotWebsocketV1PublicV2TopicMergedDepth = SpotWebsocketV1PublicV2Topic("mergedDepth")
+	// SpotWebsocketV1PublicV2TopicKline :
+	SpotWebsocketV1PublicV2TopicKline = SpotWebsocketV1PublicV2Topic("kline")
+	// SpotWebsocketV1PublicV2TopicRealtimes :
+	SpotWebsocketV1PublicV2TopicRealtimes = SpotWebsocketV1PublicV2Topic("realtimes")
+	// SpotWebsocketV1PublicV2TopicBookTicker :
+	SpotWebsocketV1PublicV2TopicBookTicker = SpotWebsocketV1PublicV2Topic("bookTicker")
 )
 
 // SpotWebsocketV1PublicV2TradeParamKey :
@@ -102,6 +118,8 @@ func (p *SpotWebsocketV1PublicV2TradeParam) Key() SpotWebsocketV1PublicV2TradePa
 
 // addParamTradeFunc :
 func (s *SpotWebsocketV1PublicV2Service) addParamTradeFunc(param SpotWebsocketV1PublicV2TradeParamKey, f func(SpotWebsocketV1PublicV2TradeResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if _, exist := s.paramTradeMap[param]; exist {
 		return errors.New("already registered for this param")
 	}
@@ -111,11 +129,15 @@ func (s *SpotWebsocketV1PublicV2Service) addParamTradeFunc(param SpotWebsocketV1
 
 // removeParamTradeFunc :
 func (s *SpotWebsocketV1PublicV2Service) removeParamTradeFunc(key SpotWebsocketV1PublicV2TradeParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.paramTradeMap, key)
 }
 
 // retrieveTradeFunc :
 func (s *SpotWebsocketV1PublicV2Service) retrieveTradeFunc(key SpotWebsocketV1PublicV2TradeParamKey) (func(SpotWebsocketV1PublicV2TradeResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	f, exist := s.paramTradeMap[key]
 	if !exist {
 		return nil, errors.New("func not found")
@@ -132,7 +154,7 @@ func (s *SpotWebsocketV1PublicV2Service) judgeTopic(respBody []byte) (SpotWebsoc
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return "", err
 	}
-	if result.Event == SpotWebsocketV1PublicV2EventSubscribe {
+	if result.Event == SpotWebsocketV1PublicV2EventSubscribe || result.Event == SpotWebsocketV1PublicV2EventUnsubscribe {
 		return "", nil
 	}
 	return result.Topic, nil
@@ -181,14 +203,379 @@ func (s *SpotWebsocketV1PublicV2Service) SubscribeTrade(symbol SymbolSpot, f fun
 	}, nil
 }
 
+// SpotWebsocketV1PublicV2ParamKey :
+type SpotWebsocketV1PublicV2ParamKey struct {
+	Symbol    SymbolSpot
+	Topic     SpotWebsocketV1PublicV2Topic
+	KlineType Interval
+}
+
+// SpotWebsocketV1PublicV2ParamChild :
+type SpotWebsocketV1PublicV2ParamChild struct {
+	Symbol    SymbolSpot `json:"symbol"`
+	Binary    bool       `json:"binary"`
+	KlineType Interval   `json:"klineType,omitempty"`
+	DumpScale int        `json:"dumpScale,omitempty"`
+}
+
+// SpotWebsocketV1PublicV2Param :
+type SpotWebsocketV1PublicV2Param struct {
+	Topic  SpotWebsocketV1PublicV2Topic      `json:"topic"`
+	Event  SpotWebsocketV1PublicV2Event      `json:"event"`
+	Params SpotWebsocketV1PublicV2ParamChild `json:"params"`
+}
+
+// Key :
+func (p *SpotWebsocketV1PublicV2Param) Key() SpotWebsocketV1PublicV2ParamKey {
+	return SpotWebsocketV1PublicV2ParamKey{
+		Symbol:    p.Params.Symbol,
+		Topic:     p.Topic,
+		KlineType: p.Params.KlineType,
+	}
+}
+
+// SpotWebsocketV1PublicV2ResponseParams :
+type SpotWebsocketV1PublicV2ResponseParams struct {
+	Symbol     SymbolSpot `json:"symbol"`
+	SymbolName string     `json:"symbolName"`
+	Binary     string     `json:"binary"`
+	KlineType  Interval   `json:"klineType,omitempty"`
+	DumpScale  string     `json:"dumpScale,omitempty"`
+}
+
+// SpotWebsocketV1PublicV2DepthResponse : depth and mergedDepth
+type SpotWebsocketV1PublicV2DepthResponse struct {
+	Topic  SpotWebsocketV1PublicV2Topic          `json:"topic"`
+	Params SpotWebsocketV1PublicV2ResponseParams `json:"params"`
+	Data   SpotWebsocketV1PublicV2DepthContent   `json:"data"`
+}
+
+// SpotWebsocketV1PublicV2DepthContent :
+type SpotWebsocketV1PublicV2DepthContent struct {
+	Symbol    SymbolSpot `json:"s"`
+	Timestamp int        `json:"t"`
+	Version   string     `json:"v"`
+	Bids      [][]string `json:"b"`
+	Asks      [][]string `json:"a"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV2DepthResponse) Key() SpotWebsocketV1PublicV2ParamKey {
+	return SpotWebsocketV1PublicV2ParamKey{
+		Symbol: r.Params.Symbol,
+		Topic:  r.Topic,
+	}
+}
+
+// SpotWebsocketV1PublicV2KlineResponse :
+type SpotWebsocketV1PublicV2KlineResponse struct {
+	Topic  SpotWebsocketV1PublicV2Topic          `json:"topic"`
+	Params SpotWebsocketV1PublicV2ResponseParams `json:"params"`
+	Data   SpotWebsocketV1PublicV2KlineContent   `json:"data"`
+}
+
+// SpotWebsocketV1PublicV2KlineContent :
+type SpotWebsocketV1PublicV2KlineContent struct {
+	StartTime  int        `json:"t"`
+	Symbol     SymbolSpot `json:"s"`
+	SymbolName string     `json:"sn"`
+	Close      string     `json:"c"`
+	High       string     `json:"h"`
+	Low        string     `json:"l"`
+	Open       string     `json:"o"`
+	Volume     string     `json:"v"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV2KlineResponse) Key() SpotWebsocketV1PublicV2ParamKey {
+	return SpotWebsocketV1PublicV2ParamKey{
+		Symbol:    r.Params.Symbol,
+		Topic:     r.Topic,
+		KlineType: r.Params.KlineType,
+	}
+}
+
+// SpotWebsocketV1PublicV2RealtimesResponse :
+type SpotWebsocketV1PublicV2RealtimesResponse struct {
+	Topic  SpotWebsocketV1PublicV2Topic            `json:"topic"`
+	Params SpotWebsocketV1PublicV2ResponseParams   `json:"params"`
+	Data   SpotWebsocketV1PublicV2RealtimesContent `json:"data"`
+}
+
+// SpotWebsocketV1PublicV2RealtimesContent :
+type SpotWebsocketV1PublicV2RealtimesContent struct {
+	Timestamp   int        `json:"t"`
+	Symbol      SymbolSpot `json:"s"`
+	Close       string     `json:"c"`
+	High        string     `json:"h"`
+	Low         string     `json:"l"`
+	Open        string     `json:"o"`
+	Volume      string     `json:"v"`
+	QuoteVolume string     `json:"qv"`
+	Change      string     `json:"m"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV2RealtimesResponse) Key() SpotWebsocketV1PublicV2ParamKey {
+	return SpotWebsocketV1PublicV2ParamKey{
+		Symbol: r.Params.Symbol,
+		Topic:  r.Topic,
+	}
+}
+
+// SpotWebsocketV1PublicV2BookTickerResponse :
+type SpotWebsocketV1PublicV2BookTickerResponse struct {
+	Topic  SpotWebsocketV1PublicV2Topic             `json:"topic"`
+	Params SpotWebsocketV1PublicV2ResponseParams    `json:"params"`
+	Data   SpotWebsocketV1PublicV2BookTickerContent `json:"data"`
+}
+
+// SpotWebsocketV1PublicV2BookTickerContent :
+type SpotWebsocketV1PublicV2BookTickerContent struct {
+	Symbol   SymbolSpot `json:"symbol"`
+	BidPrice string     `json:"bidPrice"`
+	BidQty   string     `json:"bidQty"`
+	AskPrice string     `json:"askPrice"`
+	AskQty   string     `json:"askQty"`
+	Time     int        `json:"time"`
+}
+
+// Key :
+func (r *SpotWebsocketV1PublicV2BookTickerResponse) Key() SpotWebsocketV1PublicV2ParamKey {
+	return SpotWebsocketV1PublicV2ParamKey{
+		Symbol: r.Params.Symbol,
+		Topic:  r.Topic,
+	}
+}
+
+// addParamDepthFunc :
+func (s *SpotWebsocketV1PublicV2Service) addParamDepthFunc(key SpotWebsocketV1PublicV2ParamKey, f func(SpotWebsocketV1PublicV2DepthResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramDepthMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramDepthMap[key] = f
+	return nil
+}
+
+// removeParamDepthFunc :
+func (s *SpotWebsocketV1PublicV2Service) removeParamDepthFunc(key SpotWebsocketV1PublicV2ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramDepthMap, key)
+}
+
+// retrieveDepthFunc :
+func (s *SpotWebsocketV1PublicV2Service) retrieveDepthFunc(key SpotWebsocketV1PublicV2ParamKey) (func(SpotWebsocketV1PublicV2DepthResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramDepthMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// addParamKlineFunc :
+func (s *SpotWebsocketV1PublicV2Service) addParamKlineFunc(key SpotWebsocketV1PublicV2ParamKey, f func(SpotWebsocketV1PublicV2KlineResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramKlineMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramKlineMap[key] = f
+	return nil
+}
+
+// removeParamKlineFunc :
+func (s *SpotWebsocketV1PublicV2Service) removeParamKlineFunc(key SpotWebsocketV1PublicV2ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramKlineMap, key)
+}
+
+// retrieveKlineFunc :
+func (s *SpotWebsocketV1PublicV2Service) retrieveKlineFunc(key SpotWebsocketV1PublicV2ParamKey) (func(SpotWebsocketV1PublicV2KlineResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramKlineMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// addParamRealtimesFunc :
+func (s *SpotWebsocketV1PublicV2Service) addParamRealtimesFunc(key SpotWebsocketV1PublicV2ParamKey, f func(SpotWebsocketV1PublicV2RealtimesResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramRealtimesMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramRealtimesMap[key] = f
+	return nil
+}
+
+// removeParamRealtimesFunc :
+func (s *SpotWebsocketV1PublicV2Service) removeParamRealtimesFunc(key SpotWebsocketV1PublicV2ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramRealtimesMap, key)
+}
+
+// retrieveRealtimesFunc :
+func (s *SpotWebsocketV1PublicV2Service) retrieveRealtimesFunc(key SpotWebsocketV1PublicV2ParamKey) (func(SpotWebsocketV1PublicV2RealtimesResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramRealtimesMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// addParamBookTickerFunc :
+func (s *SpotWebsocketV1PublicV2Service) addParamBookTickerFunc(key SpotWebsocketV1PublicV2ParamKey, f func(SpotWebsocketV1PublicV2BookTickerResponse) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramBookTickerMap[key]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramBookTickerMap[key] = f
+	return nil
+}
+
+// removeParamBookTickerFunc :
+func (s *SpotWebsocketV1PublicV2Service) removeParamBookTickerFunc(key SpotWebsocketV1PublicV2ParamKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramBookTickerMap, key)
+}
+
+// retrieveBookTickerFunc :
+func (s *SpotWebsocketV1PublicV2Service) retrieveBookTickerFunc(key SpotWebsocketV1PublicV2ParamKey) (func(SpotWebsocketV1PublicV2BookTickerResponse) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramBookTickerMap[key]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// subscribe : sends param and returns the func to cancel it, remove is called once cancelled or when sending fails
+func (s *SpotWebsocketV1PublicV2Service) subscribe(param SpotWebsocketV1PublicV2Param, remove func()) (func() error, error) {
+	param.Event = SpotWebsocketV1PublicV2EventSubscribe
+	buf, err := json.Marshal(param)
+	if err != nil {
+		remove()
+		return nil, err
+	}
+	if err := s.connection.WriteMessage(websocket.TextMessage, buf); err != nil {
+		remove()
+		return nil, err
+	}
+
+	return func() error {
+		param.Event = SpotWebsocketV1PublicV2EventUnsubscribe
+		buf, err := json.Marshal(param)
+		if err != nil {
+			return err
+		}
+		if err := s.connection.WriteMessage(websocket.TextMessage, buf); err != nil {
+			return err
+		}
+		remove()
+		return nil
+	}, nil
+}
+
+// subscribeDepth :
+func (s *SpotWebsocketV1PublicV2Service) subscribeDepth(param SpotWebsocketV1PublicV2Param, f func(SpotWebsocketV1PublicV2DepthResponse) error) (func() error, error) {
+	key := param.Key()
+	if err := s.addParamDepthFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamDepthFunc(key) })
+}
+
+// SubscribeDepth :
+func (s *SpotWebsocketV1PublicV2Service) SubscribeDepth(symbol SymbolSpot, f func(response SpotWebsocketV1PublicV2DepthResponse) error) (func() error, error) {
+	return s.subscribeDepth(SpotWebsocketV1PublicV2Param{
+		Topic: SpotWebsocketV1PublicV2TopicDepth,
+		Params: SpotWebsocketV1PublicV2ParamChild{
+			Symbol: symbol,
+		},
+	}, f)
+}
+
+// SubscribeMergedDepth : dumpScale is the number of decimal places the prices are merged to
+func (s *SpotWebsocketV1PublicV2Service) SubscribeMergedDepth(symbol SymbolSpot, dumpScale int, f func(response SpotWebsocketV1PublicV2DepthResponse) error) (func() error, error) {
+	return s.subscribeDepth(SpotWebsocketV1PublicV2Param{
+		Topic: SpotWebsocketV1PublicV2TopicMergedDepth,
+		Params: SpotWebsocketV1PublicV2ParamChild{
+			Symbol:    symbol,
+			DumpScale: dumpScale,
+		},
+	}, f)
+}
+
+// SubscribeKline :
+func (s *SpotWebsocketV1PublicV2Service) SubscribeKline(symbol SymbolSpot, interval Interval, f func(response SpotWebsocketV1PublicV2KlineResponse) error) (func() error, error) {
+	param := SpotWebsocketV1PublicV2Param{
+		Topic: SpotWebsocketV1PublicV2TopicKline,
+		Params: SpotWebsocketV1PublicV2ParamChild{
+			Symbol:    symbol,
+			KlineType: interval,
+		},
+	}
+	key := param.Key()
+	if err := s.addParamKlineFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamKlineFunc(key) })
+}
+
+// SubscribeRealtimes :
+func (s *SpotWebsocketV1PublicV2Service) SubscribeRealtimes(symbol SymbolSpot, f func(response SpotWebsocketV1PublicV2RealtimesResponse) error) (func() error, error) {
+	param := SpotWebsocketV1PublicV2Param{
+		Topic: SpotWebsocketV1PublicV2TopicRealtimes,
+		Params: SpotWebsocketV1PublicV2ParamChild{
+			Symbol: symbol,
+		},
+	}
+	key := param.Key()
+	if err := s.addParamRealtimesFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamRealtimesFunc(key) })
+}
+
+// SubscribeBookTicker :
+func (s *SpotWebsocketV1PublicV2Service) SubscribeBookTicker(symbol SymbolSpot, f func(response SpotWebsocketV1PublicV2BookTickerResponse) error) (func() error, error) {
+	param := SpotWebsocketV1PublicV2Param{
+		Topic: SpotWebsocketV1PublicV2TopicBookTicker,
+		Params: SpotWebsocketV1PublicV2ParamChild{
+			Symbol: symbol,
+		},
+	}
+	key := param.Key()
+	if err := s.addParamBookTickerFunc(key, f); err != nil {
+		return nil, err
+	}
+	return s.subscribe(param, func() { s.removeParamBookTickerFunc(key) })
+}
+
 // Start :
 func (s *SpotWebsocketV1PublicV2Service) Start(ctx context.Context) {
+	parent := ctx
 	done := make(chan struct{})
 
 	go func() {
 		defer close(done)
 		for {
-			if err := s.Run(); err != nil {
+			if err := s.RunContext(parent); err != nil {
 				if IsErrWebsocketClosed(err) {
 					return
 				}
@@ -229,11 +616,27 @@ func (s *SpotWebsocketV1PublicV2Service) Start(ctx context.Context) {
 
 // Run :
 func (s *SpotWebsocketV1PublicV2Service) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *SpotWebsocketV1PublicV2Service) RunContext(ctx context.Context) error {
 	_, message, err := s.connection.ReadMessage()
 	if err != nil {
 		return err
 	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
 
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *SpotWebsocketV1PublicV2Service) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *SpotWebsocketV1PublicV2Service) handle(message []byte) error {
 	topic, err := s.judgeTopic(message)
 	if err != nil {
 		return err
@@ -251,6 +654,54 @@ func (s *SpotWebsocketV1PublicV2Service) Run() error {
 		if err := f(resp); err != nil {
 			return err
 		}
+	case SpotWebsocketV1PublicV2TopicDepth, SpotWebsocketV1PublicV2TopicMergedDepth:
+		var resp SpotWebsocketV1PublicV2DepthResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveDepthFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
+	case SpotWebsocketV1PublicV2TopicKline:
+		var resp SpotWebsocketV1PublicV2KlineResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveKlineFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
+	case SpotWebsocketV1PublicV2TopicRealtimes:
+		var resp SpotWebsocketV1PublicV2RealtimesResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveRealtimesFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
+	case SpotWebsocketV1PublicV2TopicBookTicker:
+		var resp SpotWebsocketV1PublicV2BookTickerResponse
+		if err := s.parseResponse(message, &resp); err != nil {
+			return err
+		}
+		f, err := s.retrieveBookTickerFunc(resp.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(resp); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -49,3 +49,132 @@ func TestSpotWebsocketV1PublicV2Trade(t *testing.T) {
 	assert.NoError(t, svc.Ping())
 	assert.NoError(t, svc.Close())
 }
+
+func TestSpotWebsocketV1PublicV2Kline(t *testing.T) {
+	respBody := SpotWebsocketV1PublicV2KlineResponse{
+		Topic: SpotWebsocketV1PublicV2TopicKline,
+		Params: SpotWebsocketV1PublicV2ResponseParams{
+			Symbol:     SymbolSpotBTCUSDT,
+			SymbolName: string(SymbolSpotBTCUSDT),
+			Binary:     "false",
+			KlineType:  SpotInterval1m,
+		},
+		Data: SpotWebsocketV1PublicV2KlineContent{
+			StartTime:  1664283300000,
+			Symbol:     SymbolSpotBTCUSDT,
+			SymbolName: string(SymbolSpotBTCUSDT),
+			Close:      "20191.69",
+			High:       "20200",
+			Low:        "20180.01",
+			Open:       "20185.5",
+			Volume:     "16.3306",
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PublicV2Path, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Spot().V1().PublicV2()
+	require.NoError(t, err)
+
+	unsubscribe, err := svc.SubscribeKline(SymbolSpotBTCUSDT, SpotInterval1m, func(response SpotWebsocketV1PublicV2KlineResponse) error {
+		assert.Equal(t, respBody, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
+
+func TestSpotWebsocketV1PublicV2BookTicker(t *testing.T) {
+	respBody := SpotWebsocketV1PublicV2BookTickerResponse{
+		Topic: SpotWebsocketV1PublicV2TopicBookTicker,
+		Params: SpotWebsocketV1PublicV2ResponseParams{
+			Symbol:     SymbolSpotBTCUSDT,
+			SymbolName: string(SymbolSpotBTCUSDT),
+			Binary:     "false",
+		},
+		Data: SpotWebsocketV1PublicV2BookTickerContent{
+			Symbol:   SymbolSpotBTCUSDT,
+			BidPrice: "20191.68",
+			BidQty:   "0.5",
+			AskPrice: "20191.69",
+			AskQty:   "0.1",
+			Time:     1664283342503,
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PublicV2Path, bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.Spot().V1().PublicV2()
+	require.NoError(t, err)
+
+	unsubscribe, err := svc.SubscribeBookTicker(SymbolSpotBTCUSDT, func(response SpotWebsocketV1PublicV2BookTickerResponse) error {
+		assert.Equal(t, respBody, response)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
+
+func TestSpotWebsocketV1PublicV2SubscribeWhileRunning(t *testing.T) {
+	bytesBody, err := json.Marshal(SpotWebsocketV1PublicV2KlineResponse{
+		Topic: SpotWebsocketV1PublicV2TopicKline,
+		Params: SpotWebsocketV1PublicV2ResponseParams{
+			Symbol:    SymbolSpotBTCUSDT,
+			KlineType: SpotInterval1m,
+		},
+	})
+	require.NoError(t, err)
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(SpotWebsocketV1PublicV2Path, bytesBody),
+	)
+	defer teardown()
+
+	svc, err := NewTestWebsocketClient().WithBaseURL(server.URL).Spot().V1().PublicV2()
+	require.NoError(t, err)
+	_, err = svc.SubscribeKline(SymbolSpotBTCUSDT, SpotInterval1m, func(SpotWebsocketV1PublicV2KlineResponse) error { return nil })
+	require.NoError(t, err)
+
+	const subscriptions = 10
+	done := make(chan error, 1)
+	go func() {
+		// one message for each subscribe and unsubscribe
+		for i := 0; i < 1+2*subscriptions; i++ {
+			if err := svc.Run(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	for i := 0; i < subscriptions; i++ {
+		unsubscribe, err := svc.SubscribeKline(SymbolSpotETHUSDT, SpotInterval1m, func(SpotWebsocketV1PublicV2KlineResponse) error { return nil })
+		require.NoError(t, err)
+		require.NoError(t, unsubscribe())
+	}
+	assert.NoError(t, <-done)
+	assert.NoError(t, svc.Close())
+}
@@ -0,0 +1,33 @@
+package bybit
+
+const (
+	// V5WebsocketPublicPath :
+	V5WebsocketPublicPath = "/v5/public"
+)
+
+// V5WebsocketServiceI :
+type V5WebsocketServiceI interface {
+	Public(CategoryV5) (V5WebsocketPublicServiceI, error)
+	PublicPool(CategoryV5) *V5WebsocketPublicPool
+}
+
+// V5WebsocketService :
+type V5WebsocketService struct {
+	client *WebSocketClient
+}
+
+// Public :
+func (s *V5WebsocketService) Public(category CategoryV5) (V5WebsocketPublicServiceI, error) {
+	return newV5WebsocketPublicService(s.client, category)
+}
+
+// PublicPool :
+// Subscriptions made through the pool are sharded across as many connections as needed.
+func (s *V5WebsocketService) PublicPool(category CategoryV5) *V5WebsocketPublicPool {
+	return newV5WebsocketPublicPool(s.client, category)
+}
+
+// V5 :
+func (c *WebSocketClient) V5() V5WebsocketServiceI {
+	return &V5WebsocketService{c}
+}
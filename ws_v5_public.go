@@ -0,0 +1,555 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// V5WebsocketPublicServiceI :
+type V5WebsocketPublicServiceI interface {
+	Start(context.Context)
+	Run() error
+	RunContext(context.Context) error
+	MessageContext() context.Context
+	Ping() error
+	Close() error
+
+	SubscribeOrderBook(V5WebsocketPublicOrderBookParamKey, func(V5WebsocketPublicOrderBookResponse) error) (func() error, error)
+	SubscribeTrade(V5WebsocketPublicTradeParamKey, func(V5WebsocketPublicTradeResponse) error) (func() error, error)
+	SubscribeTicker(V5WebsocketPublicTickerParamKey, func(V5WebsocketPublicTickerResponse) error) (func() error, error)
+	SubscribeKline(V5WebsocketPublicKlineParamKey, func(V5WebsocketPublicKlineResponse) error) (func() error, error)
+}
+
+// V5WebsocketPublicService :
+type V5WebsocketPublicService struct {
+	client     *WebSocketClient
+	connection websocketConn
+	category   CategoryV5
+
+	maxArgsPerRequest int
+
+	writeMu      sync.Mutex
+	mu           sync.RWMutex
+	paramFuncMap map[string]func([]byte) error
+}
+
+const (
+	// V5WebsocketPublicMaxArgsPerRequest : args limit of one subscribe request, spot is the strictest category
+	V5WebsocketPublicMaxArgsPerRequest = 10
+)
+
+// V5WebsocketOperation :
+type V5WebsocketOperation string
+
+const (
+	// V5WebsocketOperationSubscribe :
+	V5WebsocketOperationSubscribe = V5WebsocketOperation("subscribe")
+	// V5WebsocketOperationUnsubscribe :
+	V5WebsocketOperationUnsubscribe = V5WebsocketOperation("unsubscribe")
+	// V5WebsocketOperationPing :
+	V5WebsocketOperationPing = V5WebsocketOperation("ping")
+	// V5WebsocketOperationPong :
+	V5WebsocketOperationPong = V5WebsocketOperation("pong")
+)
+
+// V5WebsocketPublicTopic :
+type V5WebsocketPublicTopic string
+
+const (
+	// V5WebsocketPublicTopicOrderBook :
+	V5WebsocketPublicTopicOrderBook = V5WebsocketPublicTopic("orderbook")
+	// V5WebsocketPublicTopicTrade :
+	V5WebsocketPublicTopicTrade = V5WebsocketPublicTopic("publicTrade")
+	// V5WebsocketPublicTopicTicker :
+	V5WebsocketPublicTopicTicker = V5WebsocketPublicTopic("tickers")
+	// V5WebsocketPublicTopicKline :
+	V5WebsocketPublicTopicKline = V5WebsocketPublicTopic("kline")
+)
+
+// V5WebsocketPublicParam :
+type V5WebsocketPublicParam struct {
+	ReqID string               `json:"req_id,omitempty"`
+	Op    V5WebsocketOperation `json:"op"`
+	Args  []string             `json:"args,omitempty"`
+}
+
+// V5WebsocketOperationResponse :
+type V5WebsocketOperationResponse struct {
+	Success bool                 `json:"success"`
+	RetMsg  string               `json:"ret_msg"`
+	ConnID  string               `json:"conn_id"`
+	ReqID   string               `json:"req_id"`
+	Op      V5WebsocketOperation `json:"op"`
+}
+
+// V5WebsocketPublicOrderBookParamKey :
+type V5WebsocketPublicOrderBookParamKey struct {
+	Depth  int
+	Symbol SymbolV5
+}
+
+// Topic :
+func (k V5WebsocketPublicOrderBookParamKey) Topic() string {
+	return fmt.Sprintf("%s.%d.%s", V5WebsocketPublicTopicOrderBook, k.Depth, k.Symbol)
+}
+
+// V5WebsocketPublicOrderBookResponse :
+type V5WebsocketPublicOrderBookResponse struct {
+	Topic     string                         `json:"topic"`
+	Type      string                         `json:"type"`
+	TimeStamp int64                          `json:"ts"`
+	Data      V5WebsocketPublicOrderBookData `json:"data"`
+}
+
+// V5WebsocketPublicOrderBookData :
+type V5WebsocketPublicOrderBookData struct {
+	Symbol   SymbolV5              `json:"s"`
+	Bids     V5GetOrderbookBidAsks `json:"b"`
+	Asks     V5GetOrderbookBidAsks `json:"a"`
+	UpdateID int                   `json:"u"`
+	Seq      int                   `json:"seq"`
+}
+
+// V5WebsocketPublicTradeParamKey :
+type V5WebsocketPublicTradeParamKey struct {
+	Symbol SymbolV5
+}
+
+// Topic :
+func (k V5WebsocketPublicTradeParamKey) Topic() string {
+	return fmt.Sprintf("%s.%s", V5WebsocketPublicTopicTrade, k.Symbol)
+}
+
+// V5WebsocketPublicTradeResponse :
+type V5WebsocketPublicTradeResponse struct {
+	Topic     string                       `json:"topic"`
+	Type      string                       `json:"type"`
+	TimeStamp int64                        `json:"ts"`
+	Data      []V5WebsocketPublicTradeData `json:"data"`
+}
+
+// V5WebsocketPublicTradeData :
+type V5WebsocketPublicTradeData struct {
+	Timestamp  int64         `json:"T"`
+	Symbol     SymbolV5      `json:"s"`
+	Side       Side          `json:"S"`
+	Size       string        `json:"v"`
+	Price      string        `json:"p"`
+	Direction  TickDirection `json:"L"`
+	TradeID    string        `json:"i"`
+	BlockTrade bool          `json:"BT"`
+}
+
+// V5WebsocketPublicTickerParamKey :
+type V5WebsocketPublicTickerParamKey struct {
+	Symbol SymbolV5
+}
+
+// Topic :
+func (k V5WebsocketPublicTickerParamKey) Topic() string {
+	return fmt.Sprintf("%s.%s", V5WebsocketPublicTopicTicker, k.Symbol)
+}
+
+// V5WebsocketPublicTickerResponse :
+// Fields not sent for the category are left empty.
+type V5WebsocketPublicTickerResponse struct {
+	Topic     string                      `json:"topic"`
+	Type      string                      `json:"type"`
+	TimeStamp int64                       `json:"ts"`
+	CrossSeq  int64                       `json:"cs"`
+	Data      V5WebsocketPublicTickerData `json:"data"`
+}
+
+// V5WebsocketPublicTickerData :
+type V5WebsocketPublicTickerData struct {
+	Symbol            SymbolV5      `json:"symbol"`
+	TickDirection     TickDirection `json:"tickDirection"`
+	LastPrice         string        `json:"lastPrice"`
+	PrevPrice24H      string        `json:"prevPrice24h"`
+	Price24HPcnt      string        `json:"price24hPcnt"`
+	HighPrice24H      string        `json:"highPrice24h"`
+	LowPrice24H       string        `json:"lowPrice24h"`
+	PrevPrice1H       string        `json:"prevPrice1h"`
+	MarkPrice         string        `json:"markPrice"`
+	IndexPrice        string        `json:"indexPrice"`
+	OpenInterest      string        `json:"openInterest"`
+	OpenInterestValue string        `json:"openInterestValue"`
+	Turnover24H       string        `json:"turnover24h"`
+	Volume24H         string        `json:"volume24h"`
+	NextFundingTime   MillisTime    `json:"nextFundingTime"`
+	FundingRate       string        `json:"fundingRate"`
+	Bid1Price         string        `json:"bid1Price"`
+	Bid1Size          string        `json:"bid1Size"`
+	Ask1Price         string        `json:"ask1Price"`
+	Ask1Size          string        `json:"ask1Size"`
+	UsdIndexPrice     string        `json:"usdIndexPrice"`
+}
+
+// V5WebsocketPublicKlineParamKey :
+type V5WebsocketPublicKlineParamKey struct {
+	Interval Interval
+	Symbol   SymbolV5
+}
+
+// Topic :
+func (k V5WebsocketPublicKlineParamKey) Topic() string {
+	return fmt.Sprintf("%s.%s.%s", V5WebsocketPublicTopicKline, k.Interval, k.Symbol)
+}
+
+// V5WebsocketPublicKlineResponse :
+type V5WebsocketPublicKlineResponse struct {
+	Topic     string                       `json:"topic"`
+	Type      string                       `json:"type"`
+	TimeStamp int64                        `json:"ts"`
+	Data      []V5WebsocketPublicKlineData `json:"data"`
+}
+
+// V5WebsocketPublicKlineData :
+type V5WebsocketPublicKlineData struct {
+	Start     int64    `json:"start"`
+	End       int64    `json:"end"`
+	Interval  Interval `json:"interval"`
+	Open      string   `json:"open"`
+	Close     string   `json:"close"`
+	High      string   `json:"high"`
+	Low       string   `json:"low"`
+	Volume    string   `json:"volume"`
+	Turnover  string   `json:"turnover"`
+	Confirm   bool     `json:"confirm"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+func newV5WebsocketPublicService(client *WebSocketClient, category CategoryV5) (*V5WebsocketPublicService, error) {
+	c, err := client.dial(V5WebsocketPublicPath + "/" + string(category))
+	if err != nil {
+		return nil, err
+	}
+	return &V5WebsocketPublicService{
+		client:            client,
+		connection:        c,
+		category:          category,
+		maxArgsPerRequest: V5WebsocketPublicMaxArgsPerRequest,
+		paramFuncMap:      map[string]func([]byte) error{},
+	}, nil
+}
+
+// addParamFunc :
+func (s *V5WebsocketPublicService) addParamFunc(topic string, f func([]byte) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exist := s.paramFuncMap[topic]; exist {
+		return errors.New("already registered for this param")
+	}
+	s.paramFuncMap[topic] = f
+	return nil
+}
+
+// removeParamFunc :
+func (s *V5WebsocketPublicService) removeParamFunc(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.paramFuncMap, topic)
+}
+
+// retrieveFunc :
+func (s *V5WebsocketPublicService) retrieveFunc(topic string) (func([]byte) error, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, exist := s.paramFuncMap[topic]
+	if !exist {
+		return nil, errors.New("func not found")
+	}
+	return f, nil
+}
+
+// topics : registered topics in lexical order
+func (s *V5WebsocketPublicService) topics() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	topics := make([]string, 0, len(s.paramFuncMap))
+	for topic := range s.paramFuncMap {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// judgeTopic :
+func (s *V5WebsocketPublicService) judgeTopic(respBody []byte) (string, error) {
+	result := struct {
+		Topic string `json:"topic"`
+		V5WebsocketOperationResponse
+	}{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", err
+	}
+	if result.Op != "" {
+		if !result.Success && result.Op != V5WebsocketOperationPong {
+			return "", fmt.Errorf("%s failed: %s", result.Op, result.RetMsg)
+		}
+		return "", nil
+	}
+	return result.Topic, nil
+}
+
+// parseResponse :
+func (s *V5WebsocketPublicService) parseResponse(respBody []byte, response interface{}) error {
+	return parseV5WebsocketResponse(respBody, response)
+}
+
+func parseV5WebsocketResponse(respBody []byte, response interface{}) error {
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return err
+	}
+	return nil
+}
+
+// writeOperation : sends the operation, splitting args into requests within maxArgsPerRequest
+func (s *V5WebsocketPublicService) writeOperation(op V5WebsocketOperation, args []string) error {
+	for len(args) > 0 {
+		n := len(args)
+		if s.maxArgsPerRequest > 0 && n > s.maxArgsPerRequest {
+			n = s.maxArgsPerRequest
+		}
+		buf, err := json.Marshal(V5WebsocketPublicParam{
+			Op:   op,
+			Args: args[:n],
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+			return err
+		}
+		args = args[n:]
+	}
+	return nil
+}
+
+// writeMessage : gorilla/websocket supports only one concurrent writer
+func (s *V5WebsocketPublicService) writeMessage(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.connection.WriteMessage(messageType, data)
+}
+
+// subscribeTopics : registers all funcs and subscribes them in as few requests as possible
+func (s *V5WebsocketPublicService) subscribeTopics(funcs map[string]func([]byte) error) error {
+	topics := make([]string, 0, len(funcs))
+	for topic, f := range funcs {
+		if err := s.addParamFunc(topic, f); err != nil {
+			for _, added := range topics {
+				s.removeParamFunc(added)
+			}
+			return fmt.Errorf("%s: %w", topic, err)
+		}
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	if err := s.writeOperation(V5WebsocketOperationSubscribe, topics); err != nil {
+		for _, topic := range topics {
+			s.removeParamFunc(topic)
+		}
+		return err
+	}
+	return nil
+}
+
+// unsubscribeTopics :
+func (s *V5WebsocketPublicService) unsubscribeTopics(topics []string) error {
+	if err := s.writeOperation(V5WebsocketOperationUnsubscribe, topics); err != nil {
+		return err
+	}
+	for _, topic := range topics {
+		s.removeParamFunc(topic)
+	}
+	return nil
+}
+
+func (s *V5WebsocketPublicService) subscribe(topic string, f func([]byte) error) (func() error, error) {
+	if err := s.subscribeTopics(map[string]func([]byte) error{topic: f}); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return s.unsubscribeTopics([]string{topic})
+	}, nil
+}
+
+func v5WebsocketPublicOrderBookFunc(f func(V5WebsocketPublicOrderBookResponse) error) func([]byte) error {
+	return func(message []byte) error {
+		var resp V5WebsocketPublicOrderBookResponse
+		if err := parseV5WebsocketResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	}
+}
+
+func v5WebsocketPublicTradeFunc(f func(V5WebsocketPublicTradeResponse) error) func([]byte) error {
+	return func(message []byte) error {
+		var resp V5WebsocketPublicTradeResponse
+		if err := parseV5WebsocketResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	}
+}
+
+func v5WebsocketPublicTickerFunc(f func(V5WebsocketPublicTickerResponse) error) func([]byte) error {
+	return func(message []byte) error {
+		var resp V5WebsocketPublicTickerResponse
+		if err := parseV5WebsocketResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	}
+}
+
+func v5WebsocketPublicKlineFunc(f func(V5WebsocketPublicKlineResponse) error) func([]byte) error {
+	return func(message []byte) error {
+		var resp V5WebsocketPublicKlineResponse
+		if err := parseV5WebsocketResponse(message, &resp); err != nil {
+			return err
+		}
+		return f(resp)
+	}
+}
+
+// SubscribeOrderBook :
+func (s *V5WebsocketPublicService) SubscribeOrderBook(key V5WebsocketPublicOrderBookParamKey, f func(V5WebsocketPublicOrderBookResponse) error) (func() error, error) {
+	return s.subscribe(key.Topic(), v5WebsocketPublicOrderBookFunc(f))
+}
+
+// SubscribeTrade :
+func (s *V5WebsocketPublicService) SubscribeTrade(key V5WebsocketPublicTradeParamKey, f func(V5WebsocketPublicTradeResponse) error) (func() error, error) {
+	return s.subscribe(key.Topic(), v5WebsocketPublicTradeFunc(f))
+}
+
+// SubscribeTicker :
+func (s *V5WebsocketPublicService) SubscribeTicker(key V5WebsocketPublicTickerParamKey, f func(V5WebsocketPublicTickerResponse) error) (func() error, error) {
+	return s.subscribe(key.Topic(), v5WebsocketPublicTickerFunc(f))
+}
+
+// SubscribeKline :
+func (s *V5WebsocketPublicService) SubscribeKline(key V5WebsocketPublicKlineParamKey, f func(V5WebsocketPublicKlineResponse) error) (func() error, error) {
+	return s.subscribe(key.Topic(), v5WebsocketPublicKlineFunc(f))
+}
+
+// Start :
+func (s *V5WebsocketPublicService) Start(ctx context.Context) {
+	parent := ctx
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			if err := s.RunContext(parent); err != nil {
+				if IsErrWebsocketClosed(err) {
+					return
+				}
+				log.Println(err)
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(20 * time.Second)
+	defer ticker.Stop()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.Ping(); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			log.Println("interrupt")
+
+			if err := s.Close(); err != nil {
+				return
+			}
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+			return
+		}
+	}
+}
+
+// Run :
+func (s *V5WebsocketPublicService) Run() error {
+	return s.RunContext(context.Background())
+}
+
+// RunContext : reads and handles one message, its span a child of ctx when tracing
+func (s *V5WebsocketPublicService) RunContext(ctx context.Context) error {
+	_, message, err := s.connection.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return dispatchWebsocketMessage(ctx, s.connection, message, func() error {
+		return s.handle(message)
+	})
+}
+
+// MessageContext : of the message whose handler is running, holding its span when tracing
+func (s *V5WebsocketPublicService) MessageContext() context.Context {
+	return websocketMessageContext(s.connection)
+}
+
+// handle :
+func (s *V5WebsocketPublicService) handle(message []byte) error {
+	topic, err := s.judgeTopic(message)
+	if err != nil {
+		return err
+	}
+	if topic == "" {
+		return nil
+	}
+	f, err := s.retrieveFunc(topic)
+	if err != nil {
+		return err
+	}
+	if err := f(message); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Ping :
+// v5 expects the JSON heartbeat rather than a control frame.
+func (s *V5WebsocketPublicService) Ping() error {
+	buf, err := json.Marshal(V5WebsocketPublicParam{Op: V5WebsocketOperationPing})
+	if err != nil {
+		return err
+	}
+	if err := s.writeMessage(websocket.TextMessage, buf); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close :
+func (s *V5WebsocketPublicService) Close() error {
+	if err := s.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")); err != nil {
+		return err
+	}
+	return nil
+}
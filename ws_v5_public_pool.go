@@ -0,0 +1,591 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// V5WebsocketPublicMaxTopicsPerConnection : default topics limit of one pooled connection
+	V5WebsocketPublicMaxTopicsPerConnection = 200
+)
+
+// V5WebsocketPublicPool :
+// V5WebsocketPublicPool shards subscriptions across connections so that no connection holds more than
+// maxTopicsPerConnection topics, and moves the topics of a dropped connection to the remaining ones.
+type V5WebsocketPublicPool struct {
+	client   *WebSocketClient
+	category CategoryV5
+
+	maxArgsPerRequest      int
+	maxTopicsPerConnection int
+	reconnectInterval      time.Duration
+
+	mu     sync.Mutex
+	shards []*V5WebsocketPublicService
+	funcs  map[string]func([]byte) error
+	owners map[string]*V5WebsocketPublicService
+	// reserved : topics being subscribed by shard, counted against its capacity
+	reserved map[*V5WebsocketPublicService]int
+	ctx      context.Context
+	closed   bool
+	wg       sync.WaitGroup
+	dropFunc func(shard *V5WebsocketPublicService, err error)
+}
+
+func newV5WebsocketPublicPool(client *WebSocketClient, category CategoryV5) *V5WebsocketPublicPool {
+	return &V5WebsocketPublicPool{
+		client:                 client,
+		category:               category,
+		maxArgsPerRequest:      V5WebsocketPublicMaxArgsPerRequest,
+		maxTopicsPerConnection: V5WebsocketPublicMaxTopicsPerConnection,
+		reconnectInterval:      time.Second,
+		funcs:                  map[string]func([]byte) error{},
+		owners:                 map[string]*V5WebsocketPublicService{},
+		reserved:               map[*V5WebsocketPublicService]int{},
+	}
+}
+
+// WithMaxArgsPerRequest :
+func (p *V5WebsocketPublicPool) WithMaxArgsPerRequest(n int) *V5WebsocketPublicPool {
+	p.maxArgsPerRequest = n
+
+	return p
+}
+
+// WithMaxTopicsPerConnection :
+func (p *V5WebsocketPublicPool) WithMaxTopicsPerConnection(n int) *V5WebsocketPublicPool {
+	p.maxTopicsPerConnection = n
+
+	return p
+}
+
+// WithReconnectInterval :
+func (p *V5WebsocketPublicPool) WithReconnectInterval(d time.Duration) *V5WebsocketPublicPool {
+	p.reconnectInterval = d
+
+	return p
+}
+
+// OnDrop :
+// f is called whenever a connection of the pool is lost, before its topics are moved.
+func (p *V5WebsocketPublicPool) OnDrop(f func(shard *V5WebsocketPublicService, err error)) *V5WebsocketPublicPool {
+	p.dropFunc = f
+
+	return p
+}
+
+// Connections : number of open connections
+func (p *V5WebsocketPublicPool) Connections() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.shards)
+}
+
+// Topics : subscribed topics in lexical order
+func (p *V5WebsocketPublicPool) Topics() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	topics := make([]string, 0, len(p.funcs))
+	for topic := range p.funcs {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// SubscribeOrderBooks :
+func (p *V5WebsocketPublicPool) SubscribeOrderBooks(keys []V5WebsocketPublicOrderBookParamKey, f func(V5WebsocketPublicOrderBookResponse) error) (func() error, error) {
+	funcs := map[string]func([]byte) error{}
+	for _, key := range keys {
+		funcs[key.Topic()] = v5WebsocketPublicOrderBookFunc(f)
+	}
+	return p.subscribe(funcs)
+}
+
+// SubscribeTrades :
+func (p *V5WebsocketPublicPool) SubscribeTrades(keys []V5WebsocketPublicTradeParamKey, f func(V5WebsocketPublicTradeResponse) error) (func() error, error) {
+	funcs := map[string]func([]byte) error{}
+	for _, key := range keys {
+		funcs[key.Topic()] = v5WebsocketPublicTradeFunc(f)
+	}
+	return p.subscribe(funcs)
+}
+
+// SubscribeTickers :
+func (p *V5WebsocketPublicPool) SubscribeTickers(keys []V5WebsocketPublicTickerParamKey, f func(V5WebsocketPublicTickerResponse) error) (func() error, error) {
+	funcs := map[string]func([]byte) error{}
+	for _, key := range keys {
+		funcs[key.Topic()] = v5WebsocketPublicTickerFunc(f)
+	}
+	return p.subscribe(funcs)
+}
+
+// SubscribeKlines :
+func (p *V5WebsocketPublicPool) SubscribeKlines(keys []V5WebsocketPublicKlineParamKey, f func(V5WebsocketPublicKlineResponse) error) (func() error, error) {
+	funcs := map[string]func([]byte) error{}
+	for _, key := range keys {
+		funcs[key.Topic()] = v5WebsocketPublicKlineFunc(f)
+	}
+	return p.subscribe(funcs)
+}
+
+// subscribe : the returned func unsubscribes every given topic wherever it lives at that time
+func (p *V5WebsocketPublicPool) subscribe(funcs map[string]func([]byte) error) (func() error, error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, errors.New("pool already closed")
+	}
+	topics := make([]string, 0, len(funcs))
+	for topic := range funcs {
+		if _, exist := p.funcs[topic]; exist {
+			p.mu.Unlock()
+			return nil, fmt.Errorf("%s: already registered for this param", topic)
+		}
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	// the topics are the pool's from now on, a topic without owner is waiting for a shard
+	for _, topic := range topics {
+		p.funcs[topic] = funcs[topic]
+	}
+	p.mu.Unlock()
+
+	dialed, err := p.assign(topics)
+	if err != nil {
+		p.rollback(topics, dialed)
+		return nil, err
+	}
+
+	return func() error {
+		return p.unsubscribe(topics)
+	}, nil
+}
+
+// unsubscribe : forgets the topics whose shard unsubscribed them, the others can be retried
+func (p *V5WebsocketPublicPool) unsubscribe(topics []string) error {
+	p.mu.Lock()
+	byShard := map[*V5WebsocketPublicService][]string{}
+	for _, topic := range topics {
+		if _, ok := p.funcs[topic]; !ok {
+			continue
+		}
+		owner, ok := p.owners[topic]
+		if !ok {
+			delete(p.funcs, topic)
+			continue
+		}
+		byShard[owner] = append(byShard[owner], topic)
+	}
+	p.mu.Unlock()
+
+	var errs []error
+	for shard, shardTopics := range byShard {
+		if err := shard.unsubscribeTopics(shardTopics); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.mu.Lock()
+		for _, topic := range shardTopics {
+			if p.owners[topic] == shard {
+				delete(p.owners, topic)
+				delete(p.funcs, topic)
+			}
+		}
+		p.mu.Unlock()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unsubscribe: %v", errs)
+	}
+	return nil
+}
+
+// rollback : forgets the topics of a failed subscribe, unsubscribing those a shard already took,
+// and drops the shards dialed for them
+func (p *V5WebsocketPublicPool) rollback(topics []string, dialed []*V5WebsocketPublicService) {
+	p.mu.Lock()
+	byShard := map[*V5WebsocketPublicService][]string{}
+	for _, topic := range topics {
+		if owner, ok := p.owners[topic]; ok {
+			byShard[owner] = append(byShard[owner], topic)
+		}
+		delete(p.owners, topic)
+		delete(p.funcs, topic)
+	}
+	p.mu.Unlock()
+
+	for shard, shardTopics := range byShard {
+		if err := shard.unsubscribeTopics(shardTopics); err != nil {
+			log.Println(err)
+		}
+	}
+	p.dropIdle(dialed)
+}
+
+// assign : places the topics, which wait for a shard in p.funcs, onto shards with free capacity,
+// dialing new shards when all are full. A shard that subscribed its part keeps it even when another fails.
+// The shards dialed are returned. p.mu must not be held, no network call is made under it.
+func (p *V5WebsocketPublicPool) assign(topics []string) ([]*V5WebsocketPublicService, error) {
+	byShard := map[*V5WebsocketPublicService][]string{}
+	order := []*V5WebsocketPublicService{}
+	place := func(shard *V5WebsocketPublicService, topic string) {
+		if _, ok := byShard[shard]; !ok {
+			order = append(order, shard)
+		}
+		byShard[shard] = append(byShard[shard], topic)
+		p.reserved[shard]++
+	}
+
+	p.mu.Lock()
+	load := map[*V5WebsocketPublicService]int{}
+	for _, owner := range p.owners {
+		load[owner]++
+	}
+	unplaced := []string{}
+	for _, topic := range topics {
+		var target *V5WebsocketPublicService
+		for _, shard := range p.shards {
+			if p.maxTopicsPerConnection <= 0 || load[shard]+p.reserved[shard] < p.maxTopicsPerConnection {
+				target = shard
+				break
+			}
+		}
+		if target == nil {
+			unplaced = append(unplaced, topic)
+			continue
+		}
+		place(target, topic)
+	}
+	p.mu.Unlock()
+
+	perShard := p.maxTopicsPerConnection
+	if perShard <= 0 {
+		perShard = len(unplaced)
+	}
+	var dialed []*V5WebsocketPublicService
+	for i := 0; i < len(unplaced); i += perShard {
+		shard, err := newV5WebsocketPublicService(p.client, p.category)
+		if err != nil {
+			p.release(byShard)
+			closeV5WebsocketPublicShards(dialed)
+			return nil, err
+		}
+		shard.maxArgsPerRequest = p.maxArgsPerRequest
+		dialed = append(dialed, shard)
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		p.release(byShard)
+		closeV5WebsocketPublicShards(dialed)
+		return nil, errors.New("pool already closed")
+	}
+	for i, shard := range dialed {
+		p.shards = append(p.shards, shard)
+		p.startShard(shard)
+		end := (i + 1) * perShard
+		if end > len(unplaced) {
+			end = len(unplaced)
+		}
+		for _, topic := range unplaced[i*perShard : end] {
+			place(shard, topic)
+		}
+	}
+	p.mu.Unlock()
+
+	var failed error
+	for _, shard := range order {
+		shardTopics := byShard[shard]
+		if failed != nil {
+			p.release(map[*V5WebsocketPublicService][]string{shard: shardTopics})
+			continue
+		}
+		p.mu.Lock()
+		funcs := map[string]func([]byte) error{}
+		for _, topic := range shardTopics {
+			if f, ok := p.funcs[topic]; ok {
+				funcs[topic] = f
+			}
+		}
+		p.mu.Unlock()
+
+		err := shard.subscribeTopics(funcs)
+
+		p.mu.Lock()
+		p.reserved[shard] -= len(shardTopics)
+		stray := []string{}
+		for _, topic := range shardTopics {
+			if _, ok := funcs[topic]; !ok || err != nil {
+				continue
+			}
+			if _, ok := p.funcs[topic]; ok {
+				p.owners[topic] = shard
+			} else {
+				stray = append(stray, topic)
+			}
+		}
+		p.mu.Unlock()
+		if err != nil {
+			failed = err
+			continue
+		}
+		// unsubscribed while being subscribed
+		if len(stray) > 0 {
+			if err := shard.unsubscribeTopics(stray); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+	if failed != nil {
+		p.dropIdle(dialed)
+		return dialed, failed
+	}
+	return dialed, nil
+}
+
+// release : the capacity reserved for topics that will not be subscribed
+func (p *V5WebsocketPublicPool) release(byShard map[*V5WebsocketPublicService][]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for shard, topics := range byShard {
+		p.reserved[shard] -= len(topics)
+	}
+}
+
+// dropIdle : removes and closes the shards holding no topic
+func (p *V5WebsocketPublicPool) dropIdle(shards []*V5WebsocketPublicService) {
+	p.mu.Lock()
+	busy := map[*V5WebsocketPublicService]bool{}
+	for _, owner := range p.owners {
+		busy[owner] = true
+	}
+	idle := []*V5WebsocketPublicService{}
+	for _, shard := range shards {
+		if busy[shard] || p.reserved[shard] > 0 {
+			continue
+		}
+		p.removeShard(shard)
+		idle = append(idle, shard)
+	}
+	p.mu.Unlock()
+
+	closeV5WebsocketPublicShards(idle)
+}
+
+// removeShard : p.mu must be held
+func (p *V5WebsocketPublicPool) removeShard(shard *V5WebsocketPublicService) {
+	for i, s := range p.shards {
+		if s == shard {
+			p.shards = append(p.shards[:i], p.shards[i+1:]...)
+			break
+		}
+	}
+	delete(p.reserved, shard)
+}
+
+// hasShard :
+func (p *V5WebsocketPublicPool) hasShard(shard *V5WebsocketPublicService) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, s := range p.shards {
+		if s == shard {
+			return true
+		}
+	}
+	return false
+}
+
+func closeV5WebsocketPublicShards(shards []*V5WebsocketPublicService) {
+	for _, shard := range shards {
+		_ = shard.Close()
+		_ = shard.connection.Close()
+	}
+}
+
+// startShard : p.mu must be held
+func (p *V5WebsocketPublicPool) startShard(shard *V5WebsocketPublicService) {
+	if p.ctx == nil {
+		return
+	}
+	ctx := p.ctx
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.runShard(ctx, shard)
+	}()
+}
+
+// runShard : reads until the connection is lost, handler errors do not stop the shard.
+// The message spans are children of ctx, the one given to Start.
+func (p *V5WebsocketPublicPool) runShard(ctx context.Context, shard *V5WebsocketPublicService) {
+	for {
+		_, message, err := shard.connection.ReadMessage()
+		if err != nil {
+			if IsErrWebsocketClosed(err) || p.isClosed() || !p.hasShard(shard) {
+				return
+			}
+			p.rebalance(shard, err)
+			return
+		}
+		if err := dispatchWebsocketMessage(ctx, shard.connection, message, func() error {
+			return shard.handle(message)
+		}); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// MessageContext : of the message of topic whose handler is running, holding its span when tracing
+func (p *V5WebsocketPublicPool) MessageContext(topic string) context.Context {
+	p.mu.Lock()
+	shard, ok := p.owners[topic]
+	p.mu.Unlock()
+	if !ok {
+		return context.Background()
+	}
+	return shard.MessageContext()
+}
+
+func (p *V5WebsocketPublicPool) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// rebalance : drops the shard and moves its topics to the other shards, retrying until it succeeds or the pool closes
+func (p *V5WebsocketPublicPool) rebalance(dropped *V5WebsocketPublicService, cause error) {
+	if p.dropFunc != nil {
+		p.dropFunc(dropped, cause)
+	}
+	if p.client.metrics != nil {
+		p.client.metrics.IncWebsocketReconnect(V5WebsocketPublicPath + "/" + string(p.category))
+	}
+	_ = dropped.connection.Close()
+
+	p.mu.Lock()
+	p.removeShard(dropped)
+	// the topics keep their funcs and wait for a shard, unless they are unsubscribed meanwhile
+	topics := []string{}
+	for topic, owner := range p.owners {
+		if owner == dropped {
+			topics = append(topics, topic)
+			delete(p.owners, topic)
+		}
+	}
+	sort.Strings(topics)
+	p.mu.Unlock()
+
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return
+		}
+		remaining := []string{}
+		for _, topic := range topics {
+			_, wanted := p.funcs[topic]
+			_, owned := p.owners[topic]
+			if wanted && !owned {
+				remaining = append(remaining, topic)
+			}
+		}
+		p.mu.Unlock()
+		if len(remaining) == 0 {
+			return
+		}
+
+		_, err := p.assign(remaining)
+		if err == nil {
+			return
+		}
+		log.Println(err)
+
+		select {
+		case <-p.ctx.Done():
+		case <-time.After(p.reconnectInterval):
+		}
+		if p.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Start :
+func (p *V5WebsocketPublicPool) Start(ctx context.Context) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	p.mu.Lock()
+	p.ctx = ctx
+	for _, shard := range p.shards {
+		p.startShard(shard)
+	}
+	p.mu.Unlock()
+
+	ticker := time.NewTicker(20 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.Ping(); err != nil {
+				log.Println(err)
+			}
+		case <-ctx.Done():
+			log.Println("interrupt")
+
+			if err := p.Close(); err != nil {
+				log.Println(err)
+			}
+			done := make(chan struct{})
+			go func() {
+				p.wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+			return
+		}
+	}
+}
+
+// Ping :
+func (p *V5WebsocketPublicPool) Ping() error {
+	p.mu.Lock()
+	shards := append([]*V5WebsocketPublicService{}, p.shards...)
+	p.mu.Unlock()
+
+	for _, shard := range shards {
+		if err := shard.Ping(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close :
+func (p *V5WebsocketPublicPool) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	shards := append([]*V5WebsocketPublicService{}, p.shards...)
+	p.mu.Unlock()
+
+	var result error
+	for _, shard := range shards {
+		if err := shard.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
@@ -0,0 +1,328 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/oneart-dev/bybit/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV5WebsocketPublicTicker(t *testing.T) {
+	respBody := map[string]interface{}{
+		"topic": "tickers.BTCUSDT",
+		"type":  "snapshot",
+		"ts":    1673853746003,
+		"cs":    2588407389,
+		"data": map[string]interface{}{
+			"symbol":        "BTCUSDT",
+			"tickDirection": "MinusTick",
+			"lastPrice":     "21109.77",
+			"price24hPcnt":  "-0.011",
+			"bid1Price":     "21109.76",
+			"ask1Price":     "21109.77",
+		},
+	}
+	bytesBody, err := json.Marshal(respBody)
+	require.NoError(t, err)
+
+	server, teardown := testhelper.NewWebsocketServer(
+		testhelper.WithWebsocketHandlerOption(V5WebsocketPublicPath+"/"+string(CategoryV5Linear), bytesBody),
+	)
+	defer teardown()
+
+	wsClient := NewTestWebsocketClient().
+		WithBaseURL(server.URL)
+
+	svc, err := wsClient.V5().Public(CategoryV5Linear)
+	require.NoError(t, err)
+
+	unsubscribe, err := svc.SubscribeTicker(V5WebsocketPublicTickerParamKey{Symbol: SymbolV5BTCUSDT}, func(response V5WebsocketPublicTickerResponse) error {
+		assert.Equal(t, "tickers.BTCUSDT", response.Topic)
+		assert.Equal(t, SymbolV5BTCUSDT, response.Data.Symbol)
+		assert.Equal(t, "21109.77", response.Data.LastPrice)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, svc.Run())
+	assert.NoError(t, unsubscribe())
+	assert.NoError(t, svc.Ping())
+	assert.NoError(t, svc.Close())
+}
+
+type v5WebsocketPoolTestServer struct {
+	mu          sync.Mutex
+	connections []*websocket.Conn
+	requests    [][]V5WebsocketPublicParam
+	// refuse : dials fail while set
+	refuse bool
+	// delay : of the upgrade of every dial
+	delay time.Duration
+}
+
+func (s *v5WebsocketPoolTestServer) handlerOption(path string) func(*http.ServeMux) {
+	upgrader := websocket.Upgrader{}
+	return func(mux *http.ServeMux) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			refuse, delay := s.refuse, s.delay
+			s.mu.Unlock()
+			time.Sleep(delay)
+			if refuse {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			c, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			index := len(s.connections)
+			s.connections = append(s.connections, c)
+			s.requests = append(s.requests, nil)
+			s.mu.Unlock()
+			for {
+				_, message, err := c.ReadMessage()
+				if err != nil {
+					return
+				}
+				var param V5WebsocketPublicParam
+				if err := json.Unmarshal(message, &param); err != nil {
+					return
+				}
+				s.mu.Lock()
+				s.requests[index] = append(s.requests[index], param)
+				s.mu.Unlock()
+			}
+		})
+	}
+}
+
+func (s *v5WebsocketPoolTestServer) snapshot() [][]V5WebsocketPublicParam {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([][]V5WebsocketPublicParam, len(s.requests))
+	for i, requests := range s.requests {
+		result[i] = append([]V5WebsocketPublicParam{}, requests...)
+	}
+	return result
+}
+
+func TestV5WebsocketPublicPool(t *testing.T) {
+	t.Run("shard and batch", func(t *testing.T) {
+		fake := &v5WebsocketPoolTestServer{}
+		server, teardown := testhelper.NewWebsocketServer(
+			fake.handlerOption(V5WebsocketPublicPath + "/" + string(CategoryV5Spot)),
+		)
+		defer teardown()
+
+		pool := NewTestWebsocketClient().
+			WithBaseURL(server.URL).
+			V5().
+			PublicPool(CategoryV5Spot).
+			WithMaxArgsPerRequest(2).
+			WithMaxTopicsPerConnection(3)
+
+		keys := []V5WebsocketPublicTickerParamKey{
+			{Symbol: "AUSDT"}, {Symbol: "BUSDT"}, {Symbol: "CUSDT"}, {Symbol: "DUSDT"}, {Symbol: "EUSDT"},
+		}
+		unsubscribe, err := pool.SubscribeTickers(keys, func(V5WebsocketPublicTickerResponse) error { return nil })
+		require.NoError(t, err)
+		assert.Equal(t, 2, pool.Connections())
+
+		_, err = pool.SubscribeTickers(keys[:1], func(V5WebsocketPublicTickerResponse) error { return nil })
+		assert.Error(t, err)
+
+		require.Eventually(t, func() bool {
+			requests := fake.snapshot()
+			return len(requests) == 2 && len(requests[0]) == 2 && len(requests[1]) == 1
+		}, time.Second, 10*time.Millisecond)
+		requests := fake.snapshot()
+		assert.Equal(t, []string{"tickers.AUSDT", "tickers.BUSDT"}, requests[0][0].Args)
+		assert.Equal(t, []string{"tickers.CUSDT"}, requests[0][1].Args)
+		assert.Equal(t, []string{"tickers.DUSDT", "tickers.EUSDT"}, requests[1][0].Args)
+
+		require.NoError(t, unsubscribe())
+		assert.Empty(t, pool.Topics())
+		require.Eventually(t, func() bool {
+			requests := fake.snapshot()
+			return len(requests[0]) == 4 && len(requests[1]) == 2
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, V5WebsocketOperationUnsubscribe, fake.snapshot()[1][1].Op)
+	})
+	t.Run("rebalance on drop", func(t *testing.T) {
+		fake := &v5WebsocketPoolTestServer{}
+		server, teardown := testhelper.NewWebsocketServer(
+			fake.handlerOption(V5WebsocketPublicPath + "/" + string(CategoryV5Linear)),
+		)
+		defer teardown()
+
+		dropped := make(chan struct{}, 1)
+		pool := NewTestWebsocketClient().
+			WithBaseURL(server.URL).
+			V5().
+			PublicPool(CategoryV5Linear).
+			WithMaxTopicsPerConnection(2).
+			WithReconnectInterval(10 * time.Millisecond).
+			OnDrop(func(*V5WebsocketPublicService, error) { dropped <- struct{}{} })
+
+		keys := []V5WebsocketPublicTradeParamKey{{Symbol: "AUSDT"}, {Symbol: "BUSDT"}, {Symbol: "CUSDT"}}
+		_, err := pool.SubscribeTrades(keys, func(V5WebsocketPublicTradeResponse) error { return nil })
+		require.NoError(t, err)
+		require.Equal(t, 2, pool.Connections())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go pool.Start(ctx)
+		defer cancel()
+
+		require.Eventually(t, func() bool { return len(fake.snapshot()) == 2 }, time.Second, 10*time.Millisecond)
+		fake.mu.Lock()
+		require.NoError(t, fake.connections[0].UnderlyingConn().Close())
+		fake.mu.Unlock()
+
+		select {
+		case <-dropped:
+		case <-time.After(time.Second):
+			t.Fatal("drop was not detected")
+		}
+
+		require.Eventually(t, func() bool {
+			requests := fake.snapshot()
+			return len(requests) == 3 && len(requests[1]) == 2 && len(requests[2]) == 1
+		}, time.Second, 10*time.Millisecond)
+		requests := fake.snapshot()
+		assert.Equal(t, []string{"publicTrade.AUSDT"}, requests[1][1].Args)
+		assert.Equal(t, []string{"publicTrade.BUSDT"}, requests[2][0].Args)
+		assert.Equal(t, 2, pool.Connections())
+		assert.Len(t, pool.Topics(), 3)
+	})
+	t.Run("roll back a failed dial", func(t *testing.T) {
+		fake := &v5WebsocketPoolTestServer{}
+		server, teardown := testhelper.NewWebsocketServer(
+			fake.handlerOption(V5WebsocketPublicPath + "/" + string(CategoryV5Linear)),
+		)
+		defer teardown()
+
+		pool := NewTestWebsocketClient().
+			WithBaseURL(server.URL).
+			V5().
+			PublicPool(CategoryV5Linear).
+			WithMaxTopicsPerConnection(2)
+		f := func(V5WebsocketPublicTickerResponse) error { return nil }
+
+		_, err := pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "AUSDT"}, {Symbol: "BUSDT"}, {Symbol: "CUSDT"}}, f)
+		require.NoError(t, err)
+		require.Equal(t, 2, pool.Connections())
+
+		fake.mu.Lock()
+		fake.refuse = true
+		fake.mu.Unlock()
+		keys := []V5WebsocketPublicTickerParamKey{{Symbol: "DUSDT"}, {Symbol: "EUSDT"}, {Symbol: "FUSDT"}}
+		_, err = pool.SubscribeTickers(keys, f)
+		require.Error(t, err, "EUSDT and FUSDT need a new connection")
+		assert.Equal(t, []string{"tickers.AUSDT", "tickers.BUSDT", "tickers.CUSDT"}, pool.Topics())
+		assert.Equal(t, 2, pool.Connections())
+
+		fake.mu.Lock()
+		fake.refuse = false
+		fake.mu.Unlock()
+		_, err = pool.SubscribeTickers(keys, f)
+		require.NoError(t, err, "the topics can be subscribed again")
+		assert.Len(t, pool.Topics(), 6)
+		assert.Equal(t, 3, pool.Connections())
+	})
+	t.Run("roll back the shards that subscribed", func(t *testing.T) {
+		fake := &v5WebsocketPoolTestServer{}
+		server, teardown := testhelper.NewWebsocketServer(
+			fake.handlerOption(V5WebsocketPublicPath + "/" + string(CategoryV5Linear)),
+		)
+		defer teardown()
+
+		pool := NewTestWebsocketClient().
+			WithBaseURL(server.URL).
+			V5().
+			PublicPool(CategoryV5Linear).
+			WithMaxTopicsPerConnection(2)
+		f := func(V5WebsocketPublicTickerResponse) error { return nil }
+
+		unsubscribeA, err := pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "AUSDT"}}, f)
+		require.NoError(t, err)
+		_, err = pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "BUSDT"}, {Symbol: "CUSDT"}}, f)
+		require.NoError(t, err)
+		require.NoError(t, unsubscribeA())
+		// BUSDT and a free slot on the first connection, CUSDT and a free slot on the second
+		pool.mu.Lock()
+		broken := pool.owners["tickers.CUSDT"]
+		pool.mu.Unlock()
+		require.NoError(t, broken.connection.Close())
+
+		_, err = pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "DUSDT"}, {Symbol: "EUSDT"}}, f)
+		require.Error(t, err)
+		assert.Equal(t, []string{"tickers.BUSDT", "tickers.CUSDT"}, pool.Topics())
+		require.Eventually(t, func() bool {
+			requests := fake.snapshot()[0]
+			last := requests[len(requests)-1]
+			return last.Op == V5WebsocketOperationUnsubscribe && len(last.Args) == 1 && last.Args[0] == "tickers.DUSDT"
+		}, time.Second, 10*time.Millisecond, "DUSDT made it onto the first connection and is unsubscribed")
+
+		_, err = pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "DUSDT"}}, f)
+		assert.NoError(t, err, "DUSDT is not left registered")
+	})
+	t.Run("unsubscribe keeps what failed", func(t *testing.T) {
+		fake := &v5WebsocketPoolTestServer{}
+		server, teardown := testhelper.NewWebsocketServer(
+			fake.handlerOption(V5WebsocketPublicPath + "/" + string(CategoryV5Linear)),
+		)
+		defer teardown()
+
+		pool := NewTestWebsocketClient().
+			WithBaseURL(server.URL).
+			V5().
+			PublicPool(CategoryV5Linear).
+			WithMaxTopicsPerConnection(2)
+		unsubscribe, err := pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "AUSDT"}, {Symbol: "BUSDT"}, {Symbol: "CUSDT"}}, func(V5WebsocketPublicTickerResponse) error { return nil })
+		require.NoError(t, err)
+		pool.mu.Lock()
+		broken := pool.owners["tickers.CUSDT"]
+		pool.mu.Unlock()
+		require.NoError(t, broken.connection.Close())
+
+		assert.Error(t, unsubscribe())
+		assert.Equal(t, []string{"tickers.CUSDT"}, pool.Topics(), "the first connection unsubscribed its topics")
+		assert.Error(t, unsubscribe(), "CUSDT is retried")
+		assert.Equal(t, []string{"tickers.CUSDT"}, pool.Topics())
+	})
+	t.Run("dial without the lock", func(t *testing.T) {
+		fake := &v5WebsocketPoolTestServer{delay: 300 * time.Millisecond}
+		server, teardown := testhelper.NewWebsocketServer(
+			fake.handlerOption(V5WebsocketPublicPath + "/" + string(CategoryV5Linear)),
+		)
+		defer teardown()
+
+		pool := NewTestWebsocketClient().
+			WithBaseURL(server.URL).
+			V5().
+			PublicPool(CategoryV5Linear)
+		subscribed := make(chan error, 1)
+		go func() {
+			_, err := pool.SubscribeTickers([]V5WebsocketPublicTickerParamKey{{Symbol: "AUSDT"}}, func(V5WebsocketPublicTickerResponse) error { return nil })
+			subscribed <- err
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		start := time.Now()
+		assert.Equal(t, []string{"tickers.AUSDT"}, pool.Topics())
+		assert.Equal(t, 0, pool.Connections())
+		assert.NoError(t, pool.Ping())
+		assert.Less(t, int64(time.Since(start)), int64(100*time.Millisecond), "not blocked by the dial")
+		require.NoError(t, <-subscribed)
+		assert.Equal(t, 1, pool.Connections())
+	})
+}